@@ -0,0 +1,194 @@
+// Package anime maps TVDB shows and episodes to AniDB, AniList and MyAnimeList
+// entries, using offline mapping lists of the Anime-Lists project and
+// Fribb's anime-lists. Anime is often split into several AniDB entries
+// for one TVDB season (split-cour), so searches by AniDB titles need
+// the episode number within the AniDB entry.
+package anime
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/op/go-logging"
+)
+
+var log = logging.MustGetLogger("anime")
+
+// absoluteSeason marks entries, that are numbered by TVDB absolute numbers
+const absoluteSeason = -1
+
+// Entry is an AniDB anime with IDs in other databases
+type Entry struct {
+	AniDBID   int    `json:"anidb_id"`
+	AniListID int    `json:"anilist_id"`
+	MALID     int    `json:"mal_id"`
+	TVDBID    int    `json:"tvdb_id"`
+	TMDBID    int    `json:"tmdb_id"`
+	IMDBID    string `json:"imdb_id"`
+	// Title is the main, romanized, AniDB title
+	Title string `json:"title"`
+	// EnglishTitle is the official English title, if AniDB has one
+	EnglishTitle string `json:"english_title"`
+
+	// TVDBSeason is a season, that episodes of the entry are in,
+	// with TVDB episode = AniDB episode + EpisodeOffset
+	TVDBSeason    int `json:"tvdb_season"`
+	EpisodeOffset int `json:"episode_offset"`
+
+	// TMDBShowID, TMDBSeason and TMDBOffset place episodes in TMDB numbering
+	// the same way, TMDBShowID is 0, when it is unknown
+	TMDBShowID int `json:"tmdb_show_id"`
+	TMDBSeason int `json:"tmdb_season"`
+	TMDBOffset int `json:"tmdb_offset"`
+
+	mappings []*episodeMapping
+}
+
+// episodeMapping is an explicit mapping of AniDB season episodes
+// into TVDB season, by a range with offset or by episode pairs
+type episodeMapping struct {
+	aniDBSeason int
+	tvdbSeason  int
+	start       int
+	end         int
+	offset      int
+	// episodes maps TVDB episode to AniDB episode
+	episodes map[int]int
+}
+
+// Mapping is an episode of AniDB anime, that matches TVDB episode
+type Mapping struct {
+	*Entry
+	// Episode is a number of the episode within AniDB anime
+	Episode int `json:"episode"`
+}
+
+type index struct {
+	byTVDB map[int][]*Entry
+	byTMDB map[int][]*Entry
+}
+
+var (
+	mu      sync.Mutex
+	current *index
+)
+
+// IsAnime checks whether TVDB show is in mapping lists
+func IsAnime(tvdbID int) bool {
+	if tvdbID == 0 {
+		return false
+	}
+
+	idx := get()
+	return idx != nil && len(idx.byTVDB[tvdbID]) > 0
+}
+
+// GetEntries returns AniDB entries of TVDB show
+func GetEntries(tvdbID int) []*Entry {
+	if idx := get(); idx != nil {
+		return idx.byTVDB[tvdbID]
+	}
+	return nil
+}
+
+// GetEpisode maps TVDB season and episode to an AniDB anime and its episode.
+// Absolute number is used for entries without seasons, it can be 0 if unknown.
+func GetEpisode(tvdbID, season, episode, absoluteNumber int) *Mapping {
+	idx := get()
+	if idx == nil || tvdbID == 0 {
+		return nil
+	}
+
+	entries := idx.byTVDB[tvdbID]
+
+	// Explicit mappings take precedence over default season of entries
+	for _, entry := range entries {
+		for _, m := range entry.mappings {
+			if m.tvdbSeason != season || m.aniDBSeason != 1 {
+				continue
+			}
+			if number, ok := m.episodes[episode]; ok {
+				return &Mapping{Entry: entry, Episode: number}
+			}
+			if m.start > 0 {
+				number := episode - m.offset
+				if number >= m.start && (m.end == 0 || number <= m.end) {
+					return &Mapping{Entry: entry, Episode: number}
+				}
+			}
+		}
+	}
+
+	return findEpisode(entries, season, episode, absoluteNumber, func(e *Entry) (int, int) {
+		return e.TVDBSeason, e.EpisodeOffset
+	})
+}
+
+// GetTMDBEpisode maps TMDB season and episode to an AniDB anime and its episode,
+// for shows, that have TMDB numbering in mapping lists. Absolute number
+// is counted by TMDB seasons, it can be 0 if unknown.
+func GetTMDBEpisode(tmdbID, season, episode, absoluteNumber int) *Mapping {
+	idx := get()
+	if idx == nil || tmdbID == 0 {
+		return nil
+	}
+
+	return findEpisode(idx.byTMDB[tmdbID], season, episode, absoluteNumber, func(e *Entry) (int, int) {
+		return e.TMDBSeason, e.TMDBOffset
+	})
+}
+
+// findEpisode finds an entry by its default season and episode offset.
+// Split-cour seasons are several entries with growing offsets,
+// the entry with the largest offset below the episode is taken.
+func findEpisode(entries []*Entry, season, episode, absoluteNumber int, placement func(*Entry) (int, int)) *Mapping {
+	var ret *Mapping
+	retOffset := 0
+	for _, entry := range entries {
+		entrySeason, offset := placement(entry)
+
+		number := episode
+		if entrySeason == absoluteSeason {
+			if absoluteNumber == 0 {
+				continue
+			}
+			number = absoluteNumber
+		} else if entrySeason != season {
+			continue
+		}
+
+		number -= offset
+		if number < 1 {
+			continue
+		}
+		if ret == nil || offset > retOffset {
+			ret = &Mapping{Entry: entry, Episode: number}
+			retOffset = offset
+		}
+	}
+
+	return ret
+}
+
+// parseEpisodePairs parses ";1-5;2-6;3-7+8;" list of AniDB-TVDB episode pairs
+func parseEpisodePairs(s string) map[int]int {
+	ret := map[int]int{}
+	for _, pair := range strings.Split(s, ";") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "-", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		aniDB, err := strconv.Atoi(parts[0])
+		if err != nil || aniDB == 0 {
+			continue
+		}
+		for _, tvdb := range strings.Split(parts[1], "+") {
+			if number, err := strconv.Atoi(tvdb); err == nil && number > 0 {
+				ret[number] = aniDB
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,254 @@
+package anime
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const animeListFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<anime-list>
+  <anime anidbid="1" tvdbid="100" defaulttvdbseason="1" episodeoffset="" tmdbtv="1000" tmdbseason="1" tmdboffset="">
+    <name>Split Cour</name>
+  </anime>
+  <anime anidbid="2" tvdbid="100" defaulttvdbseason="1" episodeoffset="12" tmdbtv="1000" tmdbseason="2" tmdboffset="">
+    <name>Split Cour Part 2</name>
+  </anime>
+  <anime anidbid="3" tvdbid="100" defaulttvdbseason="2" episodeoffset="-1">
+    <name>Split Cour with Prologue</name>
+  </anime>
+  <anime anidbid="4" tvdbid="200" defaulttvdbseason="a" episodeoffset="">
+    <name>Long Runner</name>
+  </anime>
+  <anime anidbid="5" tvdbid="200" defaulttvdbseason="a" episodeoffset="50">
+    <name>Long Runner Arc 2</name>
+  </anime>
+  <anime anidbid="6" tvdbid="300" defaulttvdbseason="1" episodeoffset="">
+    <name>Mapped</name>
+    <mapping-list>
+      <mapping anidbseason="1" tvdbseason="2" start="13" end="24" offset="-12"/>
+      <mapping anidbseason="1" tvdbseason="3" offset="0">;1-5;2-6+7;</mapping>
+      <mapping anidbseason="0" tvdbseason="0">;1-1;</mapping>
+    </mapping-list>
+  </anime>
+  <anime anidbid="7" tvdbid="movie" tmdbid="77">
+    <name>Movie</name>
+  </anime>
+  <anime anidbid="8" tvdbid="800" defaulttvdbseason="1" tmdbtv="8000" tmdbseason="x" imdbid="tt8">
+    <name>Unknown TMDB Season</name>
+  </anime>
+</anime-list>`
+
+const idsListFixture = `[
+  {"anidb_id": 1, "anilist_id": 11, "mal_id": "21", "themoviedb_id": 1000, "imdb_id": "tt1"},
+  {"anidb_id": 8, "anilist_id": "unknown", "mal_id": 28, "imdb_id": "tt-other"}
+]`
+
+const titlesFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<animetitles>
+  <anime aid="1">
+    <title type="main" xml:lang="x-jat">Bunkatsu</title>
+    <title type="official" xml:lang="ja">分割</title>
+    <title type="official" xml:lang="en"> Split Cour </title>
+  </anime>
+  <anime aid="2">
+    <title type="synonym" xml:lang="en">Not Official</title>
+  </anime>
+</animetitles>`
+
+func loadFixture(t *testing.T) *index {
+	dir, err := ioutil.TempDir("", "anime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	listPath := filepath.Join(dir, animeListFile)
+	idsPath := filepath.Join(dir, idsListFile)
+	titlesPath := filepath.Join(dir, titlesListFile)
+	if err := ioutil.WriteFile(listPath, []byte(animeListFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(idsPath, []byte(idsListFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Create(titlesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := gzip.NewWriter(f)
+	w.Write([]byte(titlesFixture))
+	w.Close()
+	f.Close()
+
+	idx, err := parseLists(listPath, idsPath, titlesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return idx
+}
+
+// useFixture makes the fixture current index, without refreshing it
+func useFixture(t *testing.T) {
+	setCurrent(loadFixture(t))
+	mu.Lock()
+	lastAttempt = time.Now()
+	mu.Unlock()
+}
+
+func TestParseLists(t *testing.T) {
+	idx := loadFixture(t)
+
+	if len(idx.byTVDB) != 4 {
+		t.Errorf("expected 4 TVDB shows, got %d", len(idx.byTVDB))
+	}
+	if len(idx.byTVDB[100]) != 3 || len(idx.byTMDB[1000]) != 2 {
+		t.Errorf("expected 3 TVDB and 2 TMDB entries of split cour, got %d and %d", len(idx.byTVDB[100]), len(idx.byTMDB[1000]))
+	}
+	if _, ok := idx.byTMDB[8000]; ok {
+		t.Error("entry with unknown TMDB season is indexed by TMDB")
+	}
+
+	first := idx.byTVDB[100][0]
+	expected := Entry{
+		AniDBID:      1,
+		AniListID:    11,
+		MALID:        21,
+		TVDBID:       100,
+		TMDBID:       1000,
+		IMDBID:       "tt1",
+		Title:        "Split Cour",
+		EnglishTitle: "Split Cour",
+		TVDBSeason:   1,
+		TMDBShowID:   1000,
+		TMDBSeason:   1,
+	}
+	if !reflect.DeepEqual(*first, expected) {
+		t.Errorf("expected entry %+v, got %+v", expected, *first)
+	}
+
+	if e := idx.byTVDB[100][1]; e.EnglishTitle != "" || e.EpisodeOffset != 12 || e.TMDBSeason != 2 {
+		t.Errorf("wrong second entry %+v", e)
+	}
+	if e := idx.byTVDB[100][2]; e.EpisodeOffset != -1 || e.TMDBShowID != 0 {
+		t.Errorf("wrong entry with negative offset %+v", e)
+	}
+	if e := idx.byTVDB[200][0]; e.TVDBSeason != absoluteSeason {
+		t.Errorf("absolute entry has season %d", e.TVDBSeason)
+	}
+	if e := idx.byTVDB[800][0]; e.IMDBID != "tt8" || e.AniListID != 0 || e.MALID != 28 {
+		t.Errorf("IDs list overrides entry IDs %+v", e)
+	}
+	if m := idx.byTVDB[300][0].mappings; len(m) != 3 || m[0].start != 13 || m[0].end != 24 || m[0].offset != -12 {
+		t.Errorf("wrong mappings %+v", m)
+	}
+}
+
+func TestParseEpisodePairs(t *testing.T) {
+	tests := []struct {
+		pairs    string
+		expected map[int]int
+	}{
+		{"", map[int]int{}},
+		{";1-5;2-6;", map[int]int{5: 1, 6: 2}},
+		{";3-7+8;", map[int]int{7: 3, 8: 3}},
+		{" ; 1-2 ;0-3;4-0;x-5;6-y;7", map[int]int{2: 1}},
+	}
+
+	for _, test := range tests {
+		if pairs := parseEpisodePairs(test.pairs); !reflect.DeepEqual(pairs, test.expected) {
+			t.Errorf("parseEpisodePairs(%q) = %v, expected %v", test.pairs, pairs, test.expected)
+		}
+	}
+}
+
+func TestFindEpisode(t *testing.T) {
+	idx := loadFixture(t)
+	tvdbPlacement := func(e *Entry) (int, int) { return e.TVDBSeason, e.EpisodeOffset }
+
+	tests := []struct {
+		name     string
+		tvdbID   int
+		season   int
+		episode  int
+		absolute int
+		aniDBID  int
+		number   int
+	}{
+		{"first cour", 100, 1, 5, 0, 1, 5},
+		{"last episode of first cour", 100, 1, 12, 0, 1, 12},
+		{"second cour", 100, 1, 13, 0, 2, 1},
+		{"negative offset", 100, 2, 1, 0, 3, 2},
+		{"season without entries", 100, 3, 1, 0, 0, 0},
+		{"absolute entry needs absolute number", 200, 1, 5, 0, 0, 0},
+		{"absolute entry", 200, 1, 5, 5, 4, 5},
+		{"second absolute entry", 200, 3, 2, 51, 5, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := findEpisode(idx.byTVDB[test.tvdbID], test.season, test.episode, test.absolute, tvdbPlacement)
+			checkMapping(t, m, test.aniDBID, test.number)
+		})
+	}
+}
+
+func TestGetEpisode(t *testing.T) {
+	useFixture(t)
+
+	tests := []struct {
+		name    string
+		tvdbID  int
+		season  int
+		episode int
+		aniDBID int
+		number  int
+	}{
+		{"default season", 300, 1, 3, 6, 3},
+		{"mapping range start", 300, 2, 1, 6, 13},
+		{"mapping range end", 300, 2, 12, 6, 24},
+		{"after mapping range", 300, 2, 13, 0, 0},
+		{"episode pair", 300, 3, 5, 6, 1},
+		{"episode pair with two TVDB episodes", 300, 3, 7, 6, 2},
+		{"specials mapping is skipped", 300, 0, 1, 0, 0},
+		{"no mapping falls back to entries", 100, 1, 14, 2, 2},
+		{"unknown show", 999, 1, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkMapping(t, GetEpisode(test.tvdbID, test.season, test.episode, 0), test.aniDBID, test.number)
+		})
+	}
+}
+
+func TestGetTMDBEpisode(t *testing.T) {
+	useFixture(t)
+
+	// TMDB has the split cour as two seasons
+	checkMapping(t, GetTMDBEpisode(1000, 1, 12, 12), 1, 12)
+	checkMapping(t, GetTMDBEpisode(1000, 2, 1, 13), 2, 1)
+	checkMapping(t, GetTMDBEpisode(1000, 3, 1, 25), 0, 0)
+	checkMapping(t, GetTMDBEpisode(8000, 1, 1, 1), 0, 0)
+}
+
+func checkMapping(t *testing.T, m *Mapping, aniDBID, number int) {
+	t.Helper()
+
+	if aniDBID == 0 {
+		if m != nil {
+			t.Errorf("expected no mapping, got AniDB %d episode %d", m.AniDBID, m.Episode)
+		}
+		return
+	}
+	if m == nil {
+		t.Errorf("expected AniDB %d episode %d, got no mapping", aniDBID, number)
+	} else if m.AniDBID != aniDBID || m.Episode != number {
+		t.Errorf("expected AniDB %d episode %d, got AniDB %d episode %d", aniDBID, number, m.AniDBID, m.Episode)
+	}
+}
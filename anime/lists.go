@@ -0,0 +1,346 @@
+package anime
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
+	"github.com/bcrusher29/solaris/scrape"
+	"github.com/bcrusher29/solaris/util"
+)
+
+const (
+	animeListURL    = "https://raw.githubusercontent.com/Anime-Lists/anime-lists/master/anime-list-full.xml"
+	idsListURL      = "https://raw.githubusercontent.com/Fribb/anime-lists/master/anime-list-full.json"
+	titlesListURL   = "https://anidb.net/api/anime-titles.xml.gz"
+	animeListFile   = "anime-list-full.xml"
+	idsListFile     = "anime-list-ids.json"
+	titlesListFile  = "anime-titles.xml.gz"
+	refreshInterval = 7 * 24 * time.Hour
+	retryInterval   = 10 * time.Minute
+	// AniDB bans clients, that download titles dump more than once a day
+	titlesInterval = 24 * time.Hour
+)
+
+// AniDB asks clients to identify themselves
+var client = httpclient.New(httpclient.Config{
+	Name:       "anime",
+	Header:     http.Header{"User-Agent": []string{util.DefaultUserAgent()}},
+	HTTPClient: scrape.GetClient,
+})
+
+var (
+	refreshing  bool
+	lastAttempt time.Time
+	// Failed downloads of titles count too, so they are not retried
+	// with other lists
+	titlesAttempt time.Time
+)
+
+type xmlAnimeList struct {
+	Anime []*xmlAnime `xml:"anime"`
+}
+
+type xmlAnime struct {
+	AniDBID       string        `xml:"anidbid,attr"`
+	TVDBID        string        `xml:"tvdbid,attr"`
+	DefaultSeason string        `xml:"defaulttvdbseason,attr"`
+	EpisodeOffset string        `xml:"episodeoffset,attr"`
+	TMDBID        string        `xml:"tmdbid,attr"`
+	TMDBTVID      string        `xml:"tmdbtv,attr"`
+	TMDBSeason    string        `xml:"tmdbseason,attr"`
+	TMDBOffset    string        `xml:"tmdboffset,attr"`
+	IMDBID        string        `xml:"imdbid,attr"`
+	Name          string        `xml:"name"`
+	Mappings      []*xmlMapping `xml:"mapping-list>mapping"`
+}
+
+type xmlMapping struct {
+	AniDBSeason int    `xml:"anidbseason,attr"`
+	TVDBSeason  int    `xml:"tvdbseason,attr"`
+	Start       int    `xml:"start,attr"`
+	End         int    `xml:"end,attr"`
+	Offset      int    `xml:"offset,attr"`
+	Episodes    string `xml:",chardata"`
+}
+
+// AniDB titles dump, main titles are romanized, official ones are translated
+type xmlTitlesList struct {
+	Anime []struct {
+		AniDBID int `xml:"aid,attr"`
+		Titles  []struct {
+			Type     string `xml:"type,attr"`
+			Language string `xml:"lang,attr"`
+			Title    string `xml:",chardata"`
+		} `xml:"title"`
+	} `xml:"anime"`
+}
+
+// IDs are numbers or strings, like "unknown", in the list
+type idsEntry struct {
+	AniDBID   interface{} `json:"anidb_id"`
+	AniListID interface{} `json:"anilist_id"`
+	MALID     interface{} `json:"mal_id"`
+	TMDBID    interface{} `json:"themoviedb_id"`
+	IMDBID    interface{} `json:"imdb_id"`
+}
+
+// Init loads mapping lists at startup, lists on disk are used right away,
+// missing and outdated ones are downloaded after that
+func Init() {
+	mu.Lock()
+	lastAttempt = time.Now()
+	refreshing = true
+	mu.Unlock()
+
+	refresh()
+}
+
+// get returns current index, it never waits for lists to load,
+// so it is nil until first load is done. Outdated lists are refreshed
+// in background.
+func get() *index {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !refreshing && time.Since(lastAttempt) >= retryInterval && (current == nil || isOutdated(listPath(animeListFile))) {
+		lastAttempt = time.Now()
+		refreshing = true
+		go refresh()
+	}
+
+	return current
+}
+
+func refresh() {
+	defer func() {
+		mu.Lock()
+		refreshing = false
+		mu.Unlock()
+	}()
+
+	mu.Lock()
+	loaded := current != nil
+	mu.Unlock()
+
+	if !loaded {
+		idx, err := load(false)
+		if err != nil {
+			log.Warningf("Could not load anime mapping lists: %s", err)
+			return
+		}
+		setCurrent(idx)
+	}
+
+	if !isOutdated(listPath(animeListFile)) {
+		return
+	}
+	idx, err := load(true)
+	if err != nil {
+		log.Warningf("Could not refresh anime mapping lists: %s", err)
+		return
+	}
+	setCurrent(idx)
+}
+
+func setCurrent(idx *index) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = idx
+}
+
+func listPath(name string) string {
+	return filepath.Join(config.Get().ProfilePath, "anime", name)
+}
+
+func isOutdated(path string) bool {
+	return isOlder(path, refreshInterval)
+}
+
+// isOlder reports whether file is missing or modified before d ago
+func isOlder(path string, d time.Duration) bool {
+	fi, err := os.Stat(path)
+	return err != nil || time.Since(fi.ModTime()) > d
+}
+
+// load reads lists, downloading missing ones, or all of them with force.
+// Existing list is kept if download fails.
+func load(force bool) (*index, error) {
+	for name, u := range map[string]string{animeListFile: animeListURL, idsListFile: idsListURL, titlesListFile: titlesListURL} {
+		path := listPath(name)
+		if _, err := os.Stat(path); err == nil && !force {
+			continue
+		}
+		if name == titlesListFile {
+			if time.Since(titlesAttempt) < titlesInterval || !isOlder(path, titlesInterval) {
+				continue
+			}
+			titlesAttempt = time.Now()
+		}
+
+		if err := download(u, path); err != nil {
+			if _, statErr := os.Stat(path); statErr == nil {
+				log.Warningf("Could not download %s, using existing list: %s", u, err)
+			} else if name == animeListFile {
+				return nil, err
+			} else {
+				// Other lists only add IDs and titles
+				log.Warningf("Could not download %s: %s", u, err)
+			}
+		}
+	}
+
+	return parseLists(listPath(animeListFile), listPath(idsListFile), listPath(titlesListFile))
+}
+
+func download(u string, path string) error {
+	log.Infof("Downloading anime mapping list %s", u)
+	resp, err := client.Get(httpclient.Background(), u, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", resp.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func parseLists(animeListPath, idsListPath, titlesListPath string) (*index, error) {
+	f, err := os.Open(animeListPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	list := &xmlAnimeList{}
+	if err := xml.NewDecoder(f).Decode(list); err != nil {
+		return nil, err
+	}
+
+	// IDs list only adds AniList, MAL and TMDB IDs, so it is optional
+	ids := map[int]*idsEntry{}
+	if b, err := ioutil.ReadFile(idsListPath); err == nil {
+		entries := []*idsEntry{}
+		if err := json.Unmarshal(b, &entries); err != nil {
+			log.Warningf("Could not parse %s: %s", idsListPath, err)
+		}
+		for _, e := range entries {
+			ids[util.StrInterfaceToInt(e.AniDBID)] = e
+		}
+	}
+
+	titles := parseEnglishTitles(titlesListPath)
+
+	idx := &index{byTVDB: map[int][]*Entry{}, byTMDB: map[int][]*Entry{}}
+	for _, a := range list.Anime {
+		tvdbID, err := strconv.Atoi(a.TVDBID)
+		if err != nil || tvdbID == 0 {
+			// Movies, OVAs and unknown shows have no TVDB ID
+			continue
+		}
+
+		entry := &Entry{
+			TVDBID: tvdbID,
+			IMDBID: a.IMDBID,
+			Title:  strings.TrimSpace(a.Name),
+		}
+		entry.AniDBID, _ = strconv.Atoi(a.AniDBID)
+		entry.EnglishTitle = titles[entry.AniDBID]
+		entry.TMDBID, _ = strconv.Atoi(a.TMDBID)
+		entry.EpisodeOffset, _ = strconv.Atoi(a.EpisodeOffset)
+		if a.DefaultSeason == "a" {
+			entry.TVDBSeason = absoluteSeason
+		} else if entry.TVDBSeason, err = strconv.Atoi(a.DefaultSeason); err != nil {
+			continue
+		}
+
+		// TMDB numbering is known only for some entries, it differs from TVDB
+		// for shows, where TMDB has one season and TVDB has several, or vice versa
+		entry.TMDBShowID, _ = strconv.Atoi(a.TMDBTVID)
+		entry.TMDBOffset, _ = strconv.Atoi(a.TMDBOffset)
+		if a.TMDBSeason == "a" {
+			entry.TMDBSeason = absoluteSeason
+		} else if entry.TMDBSeason, err = strconv.Atoi(a.TMDBSeason); err != nil {
+			entry.TMDBShowID = 0
+		}
+
+		if e, ok := ids[entry.AniDBID]; ok {
+			entry.AniListID = util.StrInterfaceToInt(e.AniListID)
+			entry.MALID = util.StrInterfaceToInt(e.MALID)
+			if entry.TMDBID == 0 {
+				entry.TMDBID = util.StrInterfaceToInt(e.TMDBID)
+			}
+			if imdbID, ok := e.IMDBID.(string); ok && entry.IMDBID == "" {
+				entry.IMDBID = imdbID
+			}
+		}
+
+		for _, m := range a.Mappings {
+			entry.mappings = append(entry.mappings, &episodeMapping{
+				aniDBSeason: m.AniDBSeason,
+				tvdbSeason:  m.TVDBSeason,
+				start:       m.Start,
+				end:         m.End,
+				offset:      m.Offset,
+				episodes:    parseEpisodePairs(m.Episodes),
+			})
+		}
+
+		idx.byTVDB[tvdbID] = append(idx.byTVDB[tvdbID], entry)
+		if entry.TMDBShowID > 0 {
+			idx.byTMDB[entry.TMDBShowID] = append(idx.byTMDB[entry.TMDBShowID], entry)
+		}
+	}
+
+	log.Infof("Loaded anime mapping lists with %d shows", len(idx.byTVDB))
+	return idx, nil
+}
+
+// parseEnglishTitles reads official English titles by AniDB ID,
+// titles are optional, so errors are only logged
+func parseEnglishTitles(path string) map[int]string {
+	ret := map[int]string{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return ret
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		log.Warningf("Could not read %s: %s", path, err)
+		return ret
+	}
+	defer r.Close()
+
+	list := &xmlTitlesList{}
+	if err := xml.NewDecoder(r).Decode(list); err != nil {
+		log.Warningf("Could not parse %s: %s", path, err)
+		return ret
+	}
+
+	for _, a := range list.Anime {
+		for _, t := range a.Titles {
+			if t.Type == "official" && t.Language == "en" {
+				ret[a.AniDBID] = strings.TrimSpace(t.Title)
+				break
+			}
+		}
+	}
+	return ret
+}
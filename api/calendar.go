@@ -0,0 +1,185 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/library"
+	"github.com/bcrusher29/solaris/util"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Local calendar covers a week back and a month ahead,
+// subscribed calendars get a longer range
+const (
+	calendarPastDays   = 7
+	calendarFutureDays = 30
+	icsPastDays        = 30
+	icsFutureDays      = 90
+	// Episodes without known runtime take that many minutes in exported calendar
+	icsDefaultRuntime = 30
+)
+
+// LocalCalendarShows lists recently aired and upcoming episodes of library shows,
+// it does not need Trakt
+func LocalCalendarShows(ctx *gin.Context) {
+	now := time.Now()
+	episodes := library.Calendar(now.AddDate(0, 0, -calendarPastDays), now.AddDate(0, 0, calendarFutureDays))
+
+	colorDate := config.Get().TraktCalendarsColorDate
+	colorShow := config.Get().TraktCalendarsColorShow
+	colorEpisode := config.Get().TraktCalendarsColorEpisode
+	colorUnaired := config.Get().TraktCalendarsColorUnaired
+	dateFormat := getCalendarsDateFormat()
+
+	items := make(xbmc.ListItems, 0, len(episodes))
+	for _, e := range episodes {
+		show, episode := e.Show, e.Episode
+		item := episode.ToListItem(show, e.Season)
+
+		// Known air times are shown in local time, so episodes are listed
+		// on the day they air for the viewer
+		airDate := episode.AirDate
+		if !e.AllDay {
+			airDate = e.Aired.Local().Format("2006-01-02")
+		}
+		item.Info.Aired = airDate
+		item.Info.DateAdded = airDate
+		item.Info.Premiered = airDate
+		item.Info.LastPlayed = airDate
+
+		localEpisodeColor := colorEpisode
+		if util.IsUnaired(e.Aired) {
+			localEpisodeColor = colorUnaired
+		}
+
+		aired, _ := time.Parse("2006-01-02", airDate)
+		episodeLabel := fmt.Sprintf(`[COLOR %s]%s[/COLOR] | [B][COLOR %s]%s[/COLOR][/B] - [I][COLOR %s]%dx%02d %s[/COLOR][/I]`,
+			colorDate, aired.Format(dateFormat), colorShow, show.Name, localEpisodeColor, episode.SeasonNumber, episode.EpisodeNumber, episode.Name)
+		item.Label = episodeLabel
+		item.Info.Title = episodeLabel
+
+		thisURL := URLForXBMC("/show/%d/season/%d/episode/%d/",
+			show.ID,
+			episode.SeasonNumber,
+			episode.EpisodeNumber,
+		) + "%s/%s"
+		contextLabel := playLabel
+		contextTitle := fmt.Sprintf("%s S%dE%d", show.OriginalName, episode.SeasonNumber, episode.EpisodeNumber)
+		contextURL := contextPlayOppositeURL(thisURL, contextTitle, false)
+		if config.Get().ChooseStreamAuto {
+			contextLabel = linksLabel
+		}
+
+		item.Path = contextPlayURL(thisURL, contextTitle, false)
+		item.ContextMenu = [][]string{
+			[]string{contextLabel, fmt.Sprintf("XBMC.PlayMedia(%s)", contextURL)},
+			[]string{"LOCALIZE[30037]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/episodes"))},
+		}
+		if config.Get().Platform.Kodi < 17 {
+			item.ContextMenu = append(item.ContextMenu,
+				[]string{"LOCALIZE[30203]", "XBMC.Action(Info)"},
+				[]string{"LOCALIZE[30268]", "XBMC.Action(ToggleWatched)"},
+			)
+		}
+		item.IsPlayable = true
+
+		items = append(items, item)
+	}
+
+	ctx.JSON(200, xbmc.NewView("episodes", items))
+}
+
+// LocalCalendarSubscribe shows an address to subscribe to exported calendar
+// from other devices, they are not trusted without API credentials
+func LocalCalendarSubscribe(ctx *gin.Context) {
+	u, _ := url.Parse(URLForHTTP("/shows/calendar/export.ics"))
+	if ip, err := util.LocalIP(); err == nil {
+		u.Host = fmt.Sprintf("%s:%d", ip, config.Args.LocalPort)
+	}
+
+	conf := config.Get()
+	if conf.APIToken != "" {
+		u.RawQuery = url.Values{"token": []string{conf.APIToken}}.Encode()
+	} else if conf.APIUsername != "" {
+		u.User = url.UserPassword(conf.APIUsername, conf.APIPassword)
+	} else {
+		xbmc.Dialog("Elementum", "LOCALIZE[30812]")
+		ctx.String(200, "")
+		return
+	}
+
+	xbmc.Dialog("Elementum", "LOCALIZE[30813];;"+u.String())
+	ctx.String(200, "")
+}
+
+// LocalCalendarICS exports episodes of library shows as iCalendar,
+// so it can be subscribed to from other devices
+func LocalCalendarICS(ctx *gin.Context) {
+	now := time.Now()
+	episodes := library.Calendar(now.AddDate(0, 0, -icsPastDays), now.AddDate(0, 0, icsFutureDays))
+
+	var b bytes.Buffer
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Elementum//Library calendar//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "X-WR-CALNAME:Elementum")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range episodes {
+		show, episode := e.Show, e.Episode
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:tmdb-%d-%d-%d@elementum", show.ID, episode.SeasonNumber, episode.EpisodeNumber))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		if e.AllDay {
+			day, _ := time.Parse("2006-01-02", episode.AirDate)
+			writeICSLine(&b, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+			writeICSLine(&b, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			runtime := icsDefaultRuntime
+			if len(show.EpisodeRunTime) > 0 && show.EpisodeRunTime[0] > 0 {
+				runtime = show.EpisodeRunTime[0]
+			}
+			writeICSLine(&b, "DTSTART:"+e.Aired.UTC().Format("20060102T150405Z"))
+			writeICSLine(&b, "DTEND:"+e.Aired.Add(time.Duration(runtime)*time.Minute).UTC().Format("20060102T150405Z"))
+		}
+		writeICSLine(&b, "SUMMARY:"+icsEscape(fmt.Sprintf("%s %dx%02d %s", show.Name, episode.SeasonNumber, episode.EpisodeNumber, episode.Name)))
+		if episode.Overview != "" {
+			writeICSLine(&b, "DESCRIPTION:"+icsEscape(episode.Overview))
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+
+	ctx.Writer.Header().Set("Content-Disposition", `inline; filename="elementum.ics"`)
+	ctx.Data(200, "text/calendar; charset=utf-8", b.Bytes())
+}
+
+// writeICSLine writes a content line, folded to 75 octets, as RFC 5545 requires
+func writeICSLine(b *bytes.Buffer, line string) {
+	// Continuation lines start with a space, that counts too
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Do not split multi-byte characters
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line + "\r\n")
+}
+
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
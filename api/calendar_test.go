@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteICSLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{"short line", "BEGIN:VEVENT", "BEGIN:VEVENT\r\n"},
+		{"exactly 75 octets", strings.Repeat("a", 75), strings.Repeat("a", 75) + "\r\n"},
+		{"folded once", strings.Repeat("a", 80), strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 5) + "\r\n"},
+		{
+			"folded twice",
+			strings.Repeat("a", 75+74+3),
+			strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n " + strings.Repeat("a", 3) + "\r\n",
+		},
+		{
+			"multi-byte character is not split",
+			strings.Repeat("a", 74) + "é",
+			strings.Repeat("a", 74) + "\r\n é\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			writeICSLine(&b, test.line)
+			if b.String() != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, b.String())
+			}
+
+			for _, l := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+				if len(l) > 75 {
+					t.Errorf("line is longer than 75 octets: %q", l)
+				}
+			}
+		})
+	}
+}
+
+func TestICSEscape(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Plain text", "Plain text"},
+		{`One; two, three\four`, `One\; two\, three\\four`},
+		{"First line\r\nSecond\nThird", `First line\nSecond\nThird`},
+	}
+
+	for _, test := range tests {
+		if v := icsEscape(test.text); v != test.expected {
+			t.Errorf("icsEscape(%q) = %q, expected %q", test.text, v, test.expected)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+var discoverSorts = []struct {
+	Label  int
+	SortBy string
+}{
+	{30770, tmdb.DiscoverSortPopularity},
+	{30771, tmdb.DiscoverSortRating},
+	{30772, tmdb.DiscoverSortVotes},
+	{30773, tmdb.DiscoverSortNewest},
+	{30774, tmdb.DiscoverSortOldest},
+}
+
+var discoverVoteAverages = []float64{0, 5, 6, 6.5, 7, 7.5, 8}
+
+// DiscoverIndex is a discover builder, each item edits one of filters
+func DiscoverIndex(mediaType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		f := tmdb.ParseDiscoverFilters(ctx.Request.URL.Query())
+		genres := discoverGenres(mediaType)
+
+		included := []string{}
+		excluded := []string{}
+		for _, g := range genres {
+			if containsInt(f.Genres, g.ID) || f.Genre == strconv.Itoa(g.ID) {
+				included = append(included, g.Name)
+			} else if containsInt(f.WithoutGenres, g.ID) {
+				excluded = append(excluded, "-"+g.Name)
+			}
+		}
+
+		matching := xbmc.GetLocalizedString(30767)
+		if f.GenresAny {
+			matching = xbmc.GetLocalizedString(30766)
+		}
+		excludeWatched := xbmc.GetLocalizedString(30769)
+		if f.ExcludeWatched {
+			excludeWatched = xbmc.GetLocalizedString(30768)
+		}
+		sortBy := discoverSorts[0].Label
+		for _, s := range discoverSorts {
+			if s.SortBy == f.SortBy {
+				sortBy = s.Label
+			}
+		}
+
+		type field struct {
+			name  string
+			label int
+			value string
+		}
+		fields := []field{
+			{"genres", 30756, strings.Join(append(included, excluded...), ", ")},
+			{"genres_any", 30757, matching},
+			{"years", 30758, discoverRange(f.YearFrom, f.YearTo, "")},
+			{"vote_average_min", 30759, discoverFloat(f.VoteAverageMin)},
+			{"vote_count_min", 30760, discoverInt(f.VoteCountMin)},
+			{"runtime", 30761, discoverRange(f.RuntimeMin, f.RuntimeMax, " min")},
+			{"keywords", 30762, strings.Join(f.Keywords, ", ")},
+			{"exclude_watched", 30763, excludeWatched},
+			{"sort_by", 30764, xbmc.GetLocalizedString(sortBy)},
+		}
+		if mediaType == "movies" {
+			fields = append(fields[:6], append([]field{{"certification", 30765, f.Certification}}, fields[6:]...)...)
+		}
+
+		anyValue := xbmc.GetLocalizedString(30766)
+		query := f.Values()
+		items := xbmc.ListItems{
+			{
+				Label:     "LOCALIZE[30753]",
+				Path:      URLForXBMC("/%s/discover/results", mediaType) + "?" + query.Encode(),
+				Thumbnail: config.AddonResource("img", "search.png"),
+			},
+		}
+		for _, fd := range fields {
+			value := fd.value
+			if value == "" {
+				value = anyValue
+			}
+			items = append(items, &xbmc.ListItem{
+				Label:     fmt.Sprintf("LOCALIZE[%d];;%s", fd.label, value),
+				Path:      URLForXBMC("/%s/discover/edit/%s", mediaType, fd.name) + "?" + query.Encode(),
+				Thumbnail: config.AddonResource("img", "genre_comedy.png"),
+			})
+		}
+		items = append(items, &xbmc.ListItem{
+			Label:     "LOCALIZE[30754]",
+			Path:      URLForXBMC("/%s/discover/save", mediaType) + "?" + query.Encode(),
+			Thumbnail: config.AddonResource("img", "movies.png"),
+		})
+
+		view := "menus_movies"
+		if mediaType != "movies" {
+			view = "menus_tvshows"
+		}
+		ctx.JSON(200, xbmc.NewView(view, items))
+	}
+}
+
+// DiscoverResults lists movies or shows, that match filters
+func DiscoverResults(mediaType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		f := tmdb.ParseDiscoverFilters(ctx.Request.URL.Query())
+		page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+
+		if mediaType == "movies" {
+			movies, total := tmdb.DiscoverMovies(f, config.Get().Language, page)
+			renderMovies(ctx, movies, page, total, "")
+		} else {
+			shows, total := tmdb.DiscoverShows(f, config.Get().Language, page)
+			renderShows(ctx, shows, page, total, "")
+		}
+	}
+}
+
+// DiscoverEdit asks for a new value of a filter and returns to the builder
+func DiscoverEdit(mediaType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		f := tmdb.ParseDiscoverFilters(ctx.Request.URL.Query())
+
+		switch ctx.Params.ByName("field") {
+		case "genres":
+			editDiscoverGenres(&f, discoverGenres(mediaType))
+		case "genres_any":
+			f.GenresAny = !f.GenresAny
+		case "years":
+			if years, ok := editDiscoverText(discoverRange(f.YearFrom, f.YearTo, ""), "LOCALIZE[30776]"); ok {
+				f.YearFrom, f.YearTo = parseDiscoverRange(years)
+			}
+		case "vote_average_min":
+			labels := make([]string, 0, len(discoverVoteAverages))
+			for _, v := range discoverVoteAverages {
+				if v == 0 {
+					labels = append(labels, xbmc.GetLocalizedString(30766))
+				} else {
+					labels = append(labels, discoverFloat(v))
+				}
+			}
+			if choice := xbmc.ListDialog("LOCALIZE[30777]", labels...); choice >= 0 {
+				f.VoteAverageMin = discoverVoteAverages[choice]
+			}
+		case "vote_count_min":
+			if votes, ok := editDiscoverText(discoverInt(f.VoteCountMin), "LOCALIZE[30778]"); ok {
+				f.VoteCountMin, _ = strconv.Atoi(votes)
+			}
+		case "runtime":
+			if runtime, ok := editDiscoverText(discoverRange(f.RuntimeMin, f.RuntimeMax, ""), "LOCALIZE[30779]"); ok {
+				f.RuntimeMin, f.RuntimeMax = parseDiscoverRange(runtime)
+			}
+		case "certification":
+			if certification, ok := editDiscoverText(f.Certification, "LOCALIZE[30780]"); ok {
+				f.Certification = certification
+			}
+		case "keywords":
+			if keywords, ok := editDiscoverText(strings.Join(f.Keywords, ", "), "LOCALIZE[30781]"); ok {
+				f.Keywords = nil
+				for _, k := range strings.Split(keywords, ",") {
+					if k = strings.TrimSpace(k); k != "" {
+						f.Keywords = append(f.Keywords, k)
+					}
+				}
+			}
+		case "exclude_watched":
+			f.ExcludeWatched = !f.ExcludeWatched
+		case "sort_by":
+			labels := make([]string, 0, len(discoverSorts))
+			for _, s := range discoverSorts {
+				labels = append(labels, xbmc.GetLocalizedString(s.Label))
+			}
+			if choice := xbmc.ListDialog("LOCALIZE[30782]", labels...); choice >= 0 {
+				f.SortBy = discoverSorts[choice].SortBy
+			}
+		}
+
+		go xbmc.UpdatePath(URLForXBMC("/%s/discover", mediaType) + "?" + f.Values().Encode())
+		ctx.String(200, "")
+	}
+}
+
+// editDiscoverText asks for a new value of a text filter. Keyboard returns
+// empty text on cancel too, so the filter is cleared only after confirmation.
+func editDiscoverText(value, heading string) (string, bool) {
+	ret := strings.TrimSpace(xbmc.Keyboard(value, heading))
+	if ret == "" && (value == "" || !xbmc.DialogConfirm("Elementum", "LOCALIZE[30810]")) {
+		return "", false
+	}
+	return ret, true
+}
+
+// DiscoverSave adds filters as an item of custom movies or shows menu
+func DiscoverSave(mediaType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		f := tmdb.ParseDiscoverFilters(ctx.Request.URL.Query())
+
+		name := strings.TrimSpace(xbmc.Keyboard("", "LOCALIZE[30783]"))
+		if name == "" {
+			ctx.String(200, "")
+			return
+		}
+
+		i := &MenuItem{
+			Name:    name,
+			Link:    URLForXBMC("/%s/discover/results", mediaType) + "?" + f.Values().Encode(),
+			Filters: &f,
+		}
+		log.Debugf("Adding discover menu item: %#v", i)
+
+		if mediaType == "movies" {
+			MovieMenu.Add(addAction, i)
+		} else {
+			TVMenu.Add(addAction, i)
+		}
+
+		xbmc.Notify("Elementum", fmt.Sprintf("LOCALIZE[30784];;%s", name), config.AddonIcon())
+		ctx.String(200, "")
+	}
+}
+
+// editDiscoverGenres cycles selected genre through included, excluded and unset
+func editDiscoverGenres(f *tmdb.DiscoverFilters, genres []*tmdb.Genre) {
+	labels := make([]string, 0, len(genres))
+	for _, g := range genres {
+		mark := "[ ]"
+		if containsInt(f.Genres, g.ID) {
+			mark = "[+]"
+		} else if containsInt(f.WithoutGenres, g.ID) {
+			mark = "[-]"
+		}
+		labels = append(labels, mark+" "+g.Name)
+	}
+
+	choice := xbmc.ListDialog("LOCALIZE[30775]", labels...)
+	if choice < 0 {
+		return
+	}
+
+	// Single genre of old routes is moved into the list
+	if f.Genre != "" {
+		if id, err := strconv.Atoi(f.Genre); err == nil && !containsInt(f.Genres, id) {
+			f.Genres = append(f.Genres, id)
+		}
+		f.Genre = ""
+	}
+
+	id := genres[choice].ID
+	switch {
+	case containsInt(f.Genres, id):
+		f.Genres = removeInt(f.Genres, id)
+		f.WithoutGenres = append(f.WithoutGenres, id)
+	case containsInt(f.WithoutGenres, id):
+		f.WithoutGenres = removeInt(f.WithoutGenres, id)
+	default:
+		f.Genres = append(f.Genres, id)
+	}
+}
+
+func discoverGenres(mediaType string) []*tmdb.Genre {
+	if mediaType == "movies" {
+		return tmdb.GetMovieGenres(config.Get().Language)
+	}
+	return tmdb.GetTVGenres(config.Get().Language)
+}
+
+func discoverRange(from, to int, unit string) string {
+	if from == 0 && to == 0 {
+		return ""
+	}
+
+	ret := ""
+	if from > 0 {
+		ret = strconv.Itoa(from)
+	}
+	ret += "-"
+	if to > 0 {
+		ret += strconv.Itoa(to)
+	}
+	return ret + unit
+}
+
+// parseDiscoverRange reads "from-to" range, where either side can be empty
+func parseDiscoverRange(s string) (from, to int) {
+	parts := strings.SplitN(strings.TrimSpace(s), "-", 2)
+	from, _ = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if len(parts) == 2 {
+		to, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	} else {
+		to = from
+	}
+	return
+}
+
+func discoverInt(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+func discoverFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
+
+func removeInt(ints []int, i int) []int {
+	ret := make([]int, 0, len(ints))
+	for _, v := range ints {
+		if v != i {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bcrusher29/solaris/bittorrent"
+	"github.com/bcrusher29/solaris/broadcast"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventsTorrentsInterval  = 1 * time.Second
+	eventsKeepAliveInterval = 15 * time.Second
+)
+
+var (
+	eventsMu       sync.Mutex
+	eventsClients  int
+	eventsTorrents chan struct{}
+)
+
+// Events streams torrent, player, alert and library events as server-sent
+// events, so web clients don't need to poll torrents list.
+// Event types can be limited with "types" query, separated by commas.
+func Events(s *bittorrent.Service) gin.HandlerFunc {
+	stream := eventsStream(s)
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		stream(ctx)
+	}
+}
+
+func eventsStream(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		types := map[string]bool{}
+		for _, t := range strings.Split(ctx.Query("types"), ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types[t] = true
+			}
+		}
+
+		events, unsubscribe := broadcast.Subscribe()
+		defer unsubscribe()
+
+		if len(types) == 0 || types[broadcast.EventTorrents] {
+			defer subscribeTorrents(s)()
+		}
+
+		header := ctx.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		ctx.Writer.WriteHeader(200)
+		ctx.Writer.Flush()
+
+		keepAlive := time.NewTicker(eventsKeepAliveInterval)
+		defer keepAlive.Stop()
+		closed := ctx.Writer.CloseNotify()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case <-s.Closer.C():
+				return
+			case <-keepAlive.C:
+				fmt.Fprint(ctx.Writer, ": keep-alive\n\n")
+			case e, ok := <-events:
+				if !ok {
+					log.Warningf("Dropping events client %s, that does not keep up with events", ctx.ClientIP())
+					return
+				}
+				if len(types) > 0 && !types[e.Type] {
+					continue
+				}
+
+				data, err := json.Marshal(e.Data)
+				if err != nil {
+					log.Warningf("Could not encode %s event: %s", e.Type, err)
+					continue
+				}
+				fmt.Fprintf(ctx.Writer, "event: %s\ndata: %s\n\n", e.Type, data)
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// subscribeTorrents starts publishing torrents list for the first client,
+// and returns a function that stops it after the last client is gone
+func subscribeTorrents(s *bittorrent.Service) func() {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	eventsClients++
+	if eventsClients == 1 {
+		eventsTorrents = make(chan struct{})
+		go publishTorrents(s, eventsTorrents)
+	}
+
+	return func() {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+
+		eventsClients--
+		if eventsClients == 0 {
+			close(eventsTorrents)
+		}
+	}
+}
+
+func publishTorrents(s *bittorrent.Service, stop chan struct{}) {
+	ticker := time.NewTicker(eventsTorrentsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if s.Closer.IsSet() {
+				return
+			}
+			broadcast.Publish(broadcast.EventTorrents, torrentsWebInfo(s))
+		}
+	}
+}
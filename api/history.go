@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/database"
 	"github.com/bcrusher29/solaris/xbmc"
 
@@ -31,7 +32,9 @@ func History(ctx *gin.Context) {
 
 	name := ""
 	infohash = ""
-	items := []*xbmc.ListItem{}
+	items := []*xbmc.ListItem{
+		{Label: "LOCALIZE[30745]", Path: URLForXBMC("/history/sessions"), Thumbnail: config.AddonResource("img", "clock.png")},
+	}
 	for rows.Next() {
 		rows.Scan(&name, &infohash)
 
@@ -43,6 +46,10 @@ func History(ctx *gin.Context) {
 					URLQuery(URLForXBMC("/history/remove"),
 						"infohash", infohash,
 					))},
+				[]string{"LOCALIZE[30745]", fmt.Sprintf("Container.Update(%s)",
+					URLQuery(URLForXBMC("/history/sessions"),
+						"infohash", infohash,
+					))},
 			},
 		})
 	}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/bcrusher29/solaris/imagecache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImageProxy serves remote artwork from the local image cache,
+// resized for the art kind
+func ImageProxy(ctx *gin.Context) {
+	uri := ctx.Query("url")
+	path, err := imagecache.Get(ctx.Request.Context(), uri, ctx.Query("kind"))
+	if err != nil {
+		log.Warningf("Could not cache image %s: %s", uri, err)
+		ctx.String(404, err.Error())
+		return
+	}
+
+	ctx.Writer.Header().Set("Cache-Control", "public, max-age=604800")
+	ctx.File(path)
+}
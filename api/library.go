@@ -65,6 +65,34 @@ func AddMovie(ctx *gin.Context) {
 	}
 }
 
+// AddMovieCollection adds all movies of TMDB collection to the library
+func AddMovieCollection(ctx *gin.Context) {
+	collectionID, _ := strconv.Atoi(ctx.Params.ByName("collectionId"))
+
+	collection, added, err := library.AddCollection(collectionID)
+	if err != nil {
+		ctx.String(200, err.Error())
+		return
+	}
+	if config.Get().TraktToken != "" && config.Get().TraktSyncAddedMovies {
+		for _, movie := range added {
+			go trakt.SyncAddedItem("movies", strconv.Itoa(movie.ID), config.Get().TraktSyncAddedMoviesLocation)
+		}
+	}
+
+	log.Noticef("%d movies of %s (%d) added to library", len(added), collection.Name, collectionID)
+	if len(added) == 0 {
+		xbmc.Notify("Elementum", fmt.Sprintf("LOCALIZE[30287];;%s", collection.Name), config.AddonIcon())
+		return
+	}
+
+	if config.Get().LibraryUpdate == 0 || (config.Get().LibraryUpdate == 1 && xbmc.DialogConfirmFocused("Elementum", fmt.Sprintf("LOCALIZE[30277];;%s", collection.Name))) {
+		xbmc.VideoLibraryScanDirectory(library.MoviesLibraryPath(), true)
+	} else {
+		library.ClearPageCache()
+	}
+}
+
 // AddMoviesList ...
 func AddMoviesList(ctx *gin.Context) {
 	listID := ctx.Params.ByName("listId")
@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/tmdb"
 	"github.com/bcrusher29/solaris/xbmc"
 	"github.com/gin-gonic/gin"
 )
@@ -28,6 +29,9 @@ type Menu struct {
 type MenuItem struct {
 	Link string `json:"link"`
 	Name string `json:"name"`
+
+	// Filters are set for items, saved in discover builder
+	Filters *tmdb.DiscoverFilters `json:"filters,omitempty"`
 }
 
 // Load ...
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/bcrusher29/solaris/bittorrent"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	metricsMu    sync.Mutex
+	dbSizeMetric = metrics.NewGauge("elementum_database_size_bytes", "Size of database files", "file")
+)
+
+// Metrics exposes session, player, provider and metadata clients metrics
+// in Prometheus text format
+func Metrics(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Gauges are reset on collection, so scrapes should not overlap
+		metricsMu.Lock()
+		defer metricsMu.Unlock()
+
+		s.CollectMetrics()
+		for file, size := range database.FileSizes() {
+			dbSizeMetric.Set(float64(size), file)
+		}
+
+		ctx.Writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		ctx.Writer.WriteHeader(200)
+		metrics.Write(ctx.Writer)
+	}
+}
@@ -53,6 +53,10 @@ var genreSlugs = map[int]string{
 func MoviesIndex(ctx *gin.Context) {
 	items := xbmc.ListItems{
 		{Label: "LOCALIZE[30209]", Path: URLForXBMC("/movies/search"), Thumbnail: config.AddonResource("img", "search.png")},
+		{Label: "LOCALIZE[30750]", Path: URLForXBMC("/people/search"), Thumbnail: config.AddonResource("img", "search.png")},
+		{Label: "LOCALIZE[30752]", Path: URLForXBMC("/movies/discover"), Thumbnail: config.AddonResource("img", "genre_comedy.png")},
+		{Label: "LOCALIZE[30785]", Path: URLForXBMC("/movies/recommended"), Thumbnail: config.AddonResource("img", "movies.png")},
+		{Label: "LOCALIZE[30786]", Path: URLForXBMC("/movies/because"), Thumbnail: config.AddonResource("img", "movies.png")},
 		{Label: "LOCALIZE[30263]", Path: URLForXBMC("/movies/trakt/lists/"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
 		{Label: "LOCALIZE[30254]", Path: URLForXBMC("/movies/trakt/watchlist"), Thumbnail: config.AddonResource("img", "trakt.png"), ContextMenu: [][]string{[]string{"LOCALIZE[30252]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/library/movie/list/add/watchlist"))}}, TraktAuth: true},
 		{Label: "LOCALIZE[30257]", Path: URLForXBMC("/movies/trakt/collection"), Thumbnail: config.AddonResource("img", "trakt.png"), ContextMenu: [][]string{[]string{"LOCALIZE[30252]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/library/movie/list/add/collection"))}}, TraktAuth: true},
@@ -76,7 +80,11 @@ func MoviesIndex(ctx *gin.Context) {
 		{Label: "LOCALIZE[30373]", Path: URLForXBMC("/movies/languages"), Thumbnail: config.AddonResource("img", "movies.png")},
 		{Label: "LOCALIZE[30374]", Path: URLForXBMC("/movies/countries"), Thumbnail: config.AddonResource("img", "movies.png")},
 
+		{Label: "LOCALIZE[30725]", Path: URLForXBMC("/movies/continue"), Thumbnail: config.AddonResource("img", "clock.png")},
 		{Label: "LOCALIZE[30361]", Path: URLForXBMC("/movies/trakt/history"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30700]", Path: URLForXBMC("/trakt/outbox"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30721]", Path: URLForXBMC("/trakt/checkin"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30722]", Path: URLForXBMC("/trakt/checkin/cancel"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
 
 		{Label: "LOCALIZE[30517]", Path: URLForXBMC("/movies/library"), Thumbnail: config.AddonResource("img", "movies.png")},
 	}
@@ -94,6 +102,9 @@ func MoviesIndex(ctx *gin.Context) {
 			item.ContextMenu = [][]string{
 				[]string{"LOCALIZE[30521]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLQuery(URLForXBMC("/menu/movie/remove"), "name", i.Name, "link", i.Link))},
 			}
+			if i.Filters != nil {
+				item.ContextMenu = append(item.ContextMenu, []string{"LOCALIZE[30755]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/movies/discover")+"?"+i.Filters.Values().Encode())})
+			}
 
 			items = append(items[:index], append([]*xbmc.ListItem{item}, items[index:]...)...)
 			index++
@@ -179,6 +190,34 @@ func MovieLibrary(ctx *gin.Context) {
 	renderMovies(ctx, tmdbMovies, page, movies.Limits.Total, "")
 }
 
+// MovieCollection lists movies of TMDB collection
+func MovieCollection(ctx *gin.Context) {
+	renderMovieCollection(ctx, false)
+}
+
+// MovieCollectionMissing lists movies of TMDB collection, that are not in the library
+func MovieCollectionMissing(ctx *gin.Context) {
+	renderMovieCollection(ctx, true)
+}
+
+func renderMovieCollection(ctx *gin.Context, missing bool) {
+	collectionID, _ := strconv.Atoi(ctx.Params.ByName("collectionId"))
+	language := config.Get().Language
+
+	collection := tmdb.GetCollection(collectionID, language)
+	if collection == nil {
+		ctx.Error(fmt.Errorf("Collection with TMDB %d not found", collectionID))
+		return
+	}
+
+	ids := collection.MovieIDs()
+	if missing {
+		ids = library.GetMissingFromCollection(collection)
+	}
+
+	renderMovies(ctx, tmdb.GetMovies(ids, language), -1, 0, "")
+}
+
 // TopTraktLists ...
 func TopTraktLists(ctx *gin.Context) {
 	pageParam := ctx.DefaultQuery("page", "1")
@@ -305,12 +344,30 @@ func renderMovies(ctx *gin.Context, movies tmdb.Movies, page int, total int, que
 			collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/collection/remove", movie.ID))}
 		}
 
+		item.Info.UserRating = movieUserRating(movie.ID)
+
+		watchedAction := []string{"LOCALIZE[30726]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/watched", movie.ID))}
+		if item.Info.PlayCount > 0 {
+			watchedAction = []string{"LOCALIZE[30727]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/unwatched", movie.ID))}
+		}
+
 		item.ContextMenu = [][]string{
 			watchlistAction,
 			collectionAction,
+			watchedAction,
 			[]string{"LOCALIZE[30034]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/movies"))},
 		}
+		item.ContextMenu = append(item.ContextMenu, movieTraktActions(movie.ID)...)
 		item.ContextMenu = append(libraryActions, item.ContextMenu...)
+		item.ContextMenu = append(item.ContextMenu, personActions(movie.Credits, "movies")...)
+
+		if movie.Collection != nil {
+			item.ContextMenu = append(item.ContextMenu,
+				[]string{fmt.Sprintf("LOCALIZE[30749];;%s", movie.Collection.Name), fmt.Sprintf("Container.Update(%s)", URLForXBMC("/movies/collection/%d", movie.Collection.ID))},
+				[]string{"LOCALIZE[30747]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/movies/collection/%d/missing", movie.Collection.ID))},
+				[]string{"LOCALIZE[30748]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/library/movie/collection/add/%d", movie.Collection.ID))},
+			)
+		}
 
 		if config.Get().Platform.Kodi < 17 {
 			item.ContextMenu = append(item.ContextMenu,
@@ -327,6 +384,11 @@ func renderMovies(ctx *gin.Context, movies tmdb.Movies, page int, total int, que
 		nextPath := URLForXBMC(fmt.Sprintf("%s?page=%d", path, page+1))
 		if query != "" {
 			nextPath = URLForXBMC(fmt.Sprintf("%s?q=%s&page=%d", path, query, page+1))
+		} else if ctx.Request.URL.RawQuery != "" {
+			// Keeping filters of discover lists
+			values := ctx.Request.URL.Query()
+			values.Set("page", strconv.Itoa(page+1))
+			nextPath = URLForXBMC(path) + "?" + values.Encode()
 		}
 		next := &xbmc.ListItem{
 			Label:     "LOCALIZE[30415];;" + strconv.Itoa(page+1),
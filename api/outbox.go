@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/trakt"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const outboxViewLimit = 500
+
+// TraktOutbox shows Trakt changes, waiting to be sent, and failed ones
+func TraktOutbox(ctx *gin.Context) {
+	pending := database.Get().GetOutboxItems(database.OutboxPending, outboxViewLimit)
+	failed := database.Get().GetOutboxItems(database.OutboxFailed, outboxViewLimit)
+
+	items := make(xbmc.ListItems, 0, len(pending)+len(failed))
+	for _, i := range append(pending, failed...) {
+		id := strconv.FormatInt(i.ID, 10)
+
+		label := "LOCALIZE[30701]"
+		contextMenu := [][]string{
+			[]string{"LOCALIZE[30703]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLQuery(URLForXBMC("/trakt/outbox/remove"), "id", id))},
+			[]string{"LOCALIZE[30704]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/trakt/outbox/replay"))},
+		}
+		if i.State == database.OutboxFailed {
+			label = "LOCALIZE[30702]"
+			contextMenu = append(contextMenu,
+				[]string{"LOCALIZE[30705]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLQuery(URLForXBMC("/trakt/outbox/retry"), "id", id))},
+				[]string{"LOCALIZE[30706]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/trakt/outbox/retry"))},
+				[]string{"LOCALIZE[30707]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/trakt/outbox/clear"))},
+			)
+		}
+
+		items = append(items, &xbmc.ListItem{
+			Label:  fmt.Sprintf("%s;;%s;;%s", label, i.Kind, i.EndPoint),
+			Label2: i.Created.Format("2006-01-02 15:04"),
+			Info: &xbmc.ListItemInfo{
+				Plot: fmt.Sprintf("Attempts: %d\n%s\n\n%s", i.Attempts, i.Error, i.Payload),
+			},
+			ContextMenu: contextMenu,
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("", items))
+}
+
+// TraktOutboxReplay sends pending changes right away
+func TraktOutboxReplay(ctx *gin.Context) {
+	trakt.WakeupOutbox()
+	xbmc.Refresh()
+
+	ctx.String(200, "")
+}
+
+// TraktOutboxRetry moves failed changes back to the queue
+func TraktOutboxRetry(ctx *gin.Context) {
+	id, _ := strconv.ParseInt(ctx.DefaultQuery("id", "0"), 10, 64)
+
+	log.Debugf("Retrying Trakt outbox item %d", id)
+	database.Get().RetryOutboxItem(id)
+	trakt.WakeupOutbox()
+	xbmc.Refresh()
+
+	ctx.String(200, "")
+}
+
+// TraktOutboxRemove ...
+func TraktOutboxRemove(ctx *gin.Context) {
+	id, _ := strconv.ParseInt(ctx.DefaultQuery("id", "0"), 10, 64)
+	if id == 0 {
+		return
+	}
+
+	log.Debugf("Removing Trakt outbox item %d", id)
+	database.Get().DeleteOutboxItem(id)
+	xbmc.Refresh()
+
+	ctx.String(200, "")
+}
+
+// TraktOutboxClear removes failed changes
+func TraktOutboxClear(ctx *gin.Context) {
+	log.Debugf("Cleaning failed Trakt outbox items")
+	database.Get().ClearOutbox(database.OutboxFailed)
+	xbmc.Refresh()
+
+	ctx.String(200, "")
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchPeople ...
+func SearchPeople(ctx *gin.Context) {
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	query := ctx.Query("q")
+	keyboard := ctx.Query("keyboard")
+
+	if len(query) == 0 {
+		historyType := "people"
+		if len(keyboard) > 0 {
+			if query = xbmc.Keyboard("", "LOCALIZE[30209]"); len(query) == 0 {
+				return
+			}
+			searchHistoryAppend(ctx, historyType, query)
+		} else {
+			searchHistoryList(ctx, historyType)
+		}
+
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	people, total := tmdb.SearchPeople(query, config.Get().Language, page)
+
+	items := make(xbmc.ListItems, 0, len(people)+1)
+	for _, person := range people {
+		item := person.ToListItem()
+		item.Path = URLForXBMC("/person/%d/movies", person.ID)
+		item.ContextMenu = [][]string{
+			[]string{"LOCALIZE[30214]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/person/%d/movies", person.ID))},
+			[]string{"LOCALIZE[30215]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/person/%d/shows", person.ID))},
+		}
+		items = append(items, item)
+	}
+
+	if page*tmdb.TMDBResultsPerPage < total {
+		items = append(items, &xbmc.ListItem{
+			Label:     "LOCALIZE[30415];;" + strconv.Itoa(page+1),
+			Path:      URLQuery(URLForXBMC("/people/search"), "q", query, "page", strconv.Itoa(page+1)),
+			Thumbnail: config.AddonResource("img", "nextpage.png"),
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("", items))
+}
+
+// PersonMovies lists movies of a person, newest first
+func PersonMovies(ctx *gin.Context) {
+	person := getPerson(ctx)
+	if person == nil {
+		return
+	}
+
+	ids, page, total := idsPage(ctx, person.MovieIDs())
+	renderMovies(ctx, tmdb.GetMovies(ids, config.Get().Language), page, total, "")
+}
+
+// PersonShows lists shows of a person, newest first
+func PersonShows(ctx *gin.Context) {
+	person := getPerson(ctx)
+	if person == nil {
+		return
+	}
+
+	ids, page, total := idsPage(ctx, person.ShowIDs())
+	renderShows(ctx, tmdb.GetShows(ids, config.Get().Language), page, total, "")
+}
+
+func getPerson(ctx *gin.Context) *tmdb.Person {
+	personID, _ := strconv.Atoi(ctx.Params.ByName("personId"))
+	person := tmdb.GetPerson(personID, config.Get().Language)
+	if person == nil {
+		ctx.Error(fmt.Errorf("Person with TMDB %d not found", personID))
+	}
+	return person
+}
+
+// idsPage cuts a page out of IDs, that are listed all at once
+func idsPage(ctx *gin.Context, ids []int) ([]int, int, int) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	perPage := config.Get().ResultsPerPage
+	start := (page - 1) * perPage
+	if start > len(ids) {
+		start = len(ids)
+	}
+	end := start + perPage
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	return ids[start:end], page, len(ids)
+}
+
+// personActions are context menu items to browse the main actor and director of credits
+func personActions(credits *tmdb.Credits, mediaType string) [][]string {
+	if credits == nil {
+		return nil
+	}
+
+	actions := [][]string{}
+	for _, cast := range credits.Cast {
+		actions = append(actions, []string{fmt.Sprintf("LOCALIZE[30751];;%s", cast.Name), fmt.Sprintf("Container.Update(%s)", URLForXBMC("/person/%d/%s", cast.ID, mediaType))})
+		break
+	}
+	for _, crew := range credits.Crew {
+		if crew.Job == "Director" {
+			actions = append(actions, []string{fmt.Sprintf("LOCALIZE[30751];;%s", crew.Name), fmt.Sprintf("Container.Update(%s)", URLForXBMC("/person/%d/%s", crew.ID, mediaType))})
+			break
+		}
+	}
+	return actions
+}
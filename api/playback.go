@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/dustin/go-humanize"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	playbackSessionsPerPage = 50
+	// Web UI and API can't ask for more sessions at once
+	playbackSessionsMaxLimit = 500
+)
+
+// PlaybackSessions lists recorded playback sessions, optionally of one torrent or provider
+func PlaybackSessions(ctx *gin.Context) {
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+
+	infohash := ctx.Query("infohash")
+	provider := ctx.Query("provider")
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	sessions := database.Get().GetPlaybackSessions(infohash, provider, playbackSessionsPerPage, (page-1)*playbackSessionsPerPage)
+
+	items := make(xbmc.ListItems, 0, len(sessions)+1)
+	bufferLabel, stallsLabel := xbmc.GetLocalizedString(30793), xbmc.GetLocalizedString(30794)
+	for _, s := range sessions {
+		label := fmt.Sprintf("%s | %s", s.Started.Format("2006-01-02 15:04"), s.Name)
+		if s.Stalls > 0 || s.Error != "" {
+			label = fmt.Sprintf("[COLOR red]%s[/COLOR]", label)
+		}
+
+		item := &xbmc.ListItem{
+			Label:  label,
+			Label2: playbackSessionSummary(s, bufferLabel, stallsLabel),
+			Path:   URLQuery(URLForXBMC("/history/sessions/show"), "id", strconv.FormatInt(s.ID, 10)),
+		}
+		if s.Provider != "" {
+			item.ContextMenu = [][]string{
+				[]string{s.Provider, fmt.Sprintf("Container.Update(%s)", URLQuery(URLForXBMC("/history/sessions"), "provider", s.Provider))},
+			}
+		}
+		items = append(items, item)
+	}
+
+	if len(sessions) == playbackSessionsPerPage {
+		items = append(items, &xbmc.ListItem{
+			Label: "LOCALIZE[30415];;" + strconv.Itoa(page+1),
+			Path: URLQuery(URLForXBMC("/history/sessions"),
+				"infohash", infohash,
+				"provider", provider,
+				"page", strconv.Itoa(page+1)),
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("", items))
+}
+
+// PlaybackSessionShow displays stats and events of a playback session
+func PlaybackSessionShow(ctx *gin.Context) {
+	id, _ := strconv.ParseInt(ctx.Query("id"), 10, 64)
+	s := database.Get().GetPlaybackSession(id)
+	if s == nil {
+		ctx.Error(errors.New("Playback session not found"))
+		return
+	}
+
+	xbmc.DialogText(s.Name, playbackSessionText(s))
+	ctx.String(200, "")
+}
+
+// PlaybackSessionsWeb returns recorded playback sessions for the web UI
+func PlaybackSessionsWeb(ctx *gin.Context) {
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(playbackSessionsPerPage)))
+	if limit < 1 || limit > playbackSessionsMaxLimit {
+		limit = playbackSessionsPerPage
+	}
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	if id := ctx.Query("id"); id != "" {
+		sessionID, _ := strconv.ParseInt(id, 10, 64)
+		if s := database.Get().GetPlaybackSession(sessionID); s != nil {
+			ctx.JSON(200, s)
+		} else {
+			ctx.JSON(404, gin.H{"error": "Playback session not found"})
+		}
+		return
+	}
+
+	ctx.JSON(200, database.Get().GetPlaybackSessions(ctx.Query("infohash"), ctx.Query("provider"), limit, offset))
+}
+
+// playbackSessionSummary returns short stats of a session, labels are
+// localized once for the whole list
+func playbackSessionSummary(s *database.PlaybackSession, bufferLabel, stallsLabel string) string {
+	parts := []string{
+		fmt.Sprintf(bufferLabel, fmt.Sprintf("%.1f", s.BufferTime)),
+		fmt.Sprintf(stallsLabel, strconv.Itoa(s.Stalls), fmt.Sprintf("%.1f", s.StallTime)),
+		fmt.Sprintf("%d%%", s.WatchedPercent),
+	}
+	if s.EndReason != "" {
+		parts = append(parts, s.EndReason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func playbackSessionText(s *database.PlaybackSession) string {
+	type row struct {
+		label int
+		value string
+	}
+	rows := []row{
+		{30795, s.InfoHash},
+		{30796, fmt.Sprintf("%s (%s)", s.File, humanize.Bytes(uint64(s.Size)))},
+		{30797, s.Provider},
+		{30798, s.Started.Format("2006-01-02 15:04:05")},
+		{30799, fmt.Sprintf("%.1fs", s.BufferTime)},
+		{30800, fmt.Sprintf("%.1fs", s.FirstByteTime)},
+		{30801, fmt.Sprintf("%d, %.1fs", s.Stalls, s.StallTime)},
+		{30802, strconv.Itoa(s.Seeks)},
+		{30803, fmt.Sprintf("%d%%", s.WatchedPercent)},
+		{30804, s.EndReason},
+	}
+	if s.Error != "" {
+		rows = append(rows, row{30805, s.Error})
+	}
+
+	text := ""
+	for _, r := range rows {
+		text += fmt.Sprintf("[B]%s:[/B] %s\n", xbmc.GetLocalizedString(r.label), r.value)
+	}
+
+	if len(s.Events) == 0 {
+		return text
+	}
+
+	text += fmt.Sprintf("\n[B]%s:[/B]\n", xbmc.GetLocalizedString(30806))
+	stallLabel, seekLabel, peersLabel := xbmc.GetLocalizedString(30807), xbmc.GetLocalizedString(30808), xbmc.GetLocalizedString(30809)
+	for _, e := range s.Events {
+		at := e.Created.Sub(s.Started).Seconds()
+		switch e.Type {
+		case database.PlaybackEventStall:
+			text += fmt.Sprintf("  +%.0fs  %s\n", at, fmt.Sprintf(stallLabel, strconv.Itoa(e.Piece), fmt.Sprintf("%.1f", e.Duration)))
+		case database.PlaybackEventSeek:
+			text += fmt.Sprintf("  +%.0fs  %s\n", at, fmt.Sprintf(seekLabel, fmt.Sprintf("%.0f", e.Value)))
+		case database.PlaybackEventPeers:
+			text += fmt.Sprintf("  +%.0fs  %s\n", at, fmt.Sprintf(peersLabel, fmt.Sprintf("%.0f", e.Value)))
+		}
+	}
+
+	return text
+}
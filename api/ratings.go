@@ -0,0 +1,326 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcrusher29/solaris/bittorrent"
+	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/httpclient"
+	"github.com/bcrusher29/solaris/trakt"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ratingLabels are language string ids for each rating
+var ratingLabels = []int{0, 30710, 30711, 30712, 30713, 30714, 30715, 30716, 30717, 30718, 30719}
+
+// movieTraktActions returns context menu items for rating and reading comments,
+// that are shown only when Trakt is authorized
+func movieTraktActions(tmdbID int) [][]string {
+	if config.Get().TraktToken == "" {
+		return nil
+	}
+
+	return [][]string{
+		[]string{"LOCALIZE[30708]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/rate", tmdbID))},
+		[]string{"LOCALIZE[30709]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/movie/%d/comments", tmdbID))},
+	}
+}
+
+// showTraktActions ...
+func showTraktActions(showID int) [][]string {
+	if config.Get().TraktToken == "" {
+		return nil
+	}
+
+	return [][]string{
+		[]string{"LOCALIZE[30708]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/rate", showID))},
+		[]string{"LOCALIZE[30709]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/show/%d/comments", showID))},
+	}
+}
+
+// episodeTraktActions ...
+func episodeTraktActions(showID, season, episode int) [][]string {
+	if config.Get().TraktToken == "" {
+		return nil
+	}
+
+	return [][]string{
+		[]string{"LOCALIZE[30708]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/season/%d/episode/%d/rate", showID, season, episode))},
+		[]string{"LOCALIZE[30709]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/show/%d/season/%d/episode/%d/comments", showID, season, episode))},
+	}
+}
+
+func userRatings(itemType string) (ratings []*trakt.Rating) {
+	if config.Get().TraktToken == "" {
+		return
+	}
+
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.trakt.ratings.list.%s", itemType)
+	if err := cacheStore.Get(key, &ratings); err != nil {
+		switch itemType {
+		case "movies":
+			ratings, _ = trakt.RatedMovies()
+		case "shows":
+			ratings, _ = trakt.RatedShows()
+		case "episodes":
+			ratings, _ = trakt.RatedEpisodes()
+		}
+		cacheStore.Set(key, ratings, 30*time.Second)
+	}
+	return
+}
+
+func movieUserRating(tmdbID int) int {
+	for _, r := range userRatings("movies") {
+		if r.Movie != nil && r.Movie.IDs != nil && r.Movie.IDs.TMDB == tmdbID {
+			return r.Rating
+		}
+	}
+	return 0
+}
+
+func showUserRating(tmdbID int) int {
+	for _, r := range userRatings("shows") {
+		if r.Show != nil && r.Show.IDs != nil && r.Show.IDs.TMDB == tmdbID {
+			return r.Rating
+		}
+	}
+	return 0
+}
+
+func episodeUserRating(showID, season, episode int) int {
+	for _, r := range userRatings("episodes") {
+		if r.Show != nil && r.Show.IDs != nil && r.Show.IDs.TMDB == showID && r.Episode != nil && r.Episode.Season == season && r.Episode.Number == episode {
+			return r.Rating
+		}
+	}
+	return 0
+}
+
+// chooseRating takes rating from the query or asks the user for it,
+// returns -1 if the user has canceled the dialog
+func chooseRating(ctx *gin.Context, current int) int {
+	if r := ctx.Query("rating"); r != "" {
+		rating, err := strconv.Atoi(r)
+		if err != nil || rating < 0 || rating > 10 {
+			return -1
+		}
+		return rating
+	}
+
+	items := []string{}
+	for i := 10; i > 0; i-- {
+		label := xbmc.GetLocalizedString(ratingLabels[i])
+		if i == current {
+			label = "[B]" + label + "[/B]"
+		}
+		items = append(items, label)
+	}
+	if current > 0 {
+		items = append(items, xbmc.GetLocalizedString(30720))
+	}
+
+	choice := xbmc.ListDialog("LOCALIZE[30708]", items...)
+	if choice < 0 {
+		return -1
+	}
+	return 10 - choice
+}
+
+func notifyRating(resp *httpclient.Response, err error, rating int) {
+	if err != nil {
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+		return
+	} else if resp.Status() != 201 && resp.Status() != 200 {
+		xbmc.Notify("Elementum", fmt.Sprintf("Failed with %d status code", resp.Status()), config.AddonIcon())
+		return
+	}
+
+	if rating == 0 {
+		xbmc.Notify("Elementum", "LOCALIZE[30723]", config.AddonIcon())
+	} else {
+		xbmc.Notify("Elementum", "LOCALIZE[30724];;"+xbmc.GetLocalizedString(ratingLabels[rating]), config.AddonIcon())
+	}
+	database.GetCache().DeleteWithPrefix(database.CommonBucket, []byte("com.trakt.ratings"))
+	xbmc.Refresh()
+}
+
+// RateMovie ...
+func RateMovie(ctx *gin.Context) {
+	tmdbID, _ := strconv.Atoi(ctx.Params.ByName("tmdbId"))
+
+	rating := chooseRating(ctx, movieUserRating(tmdbID))
+	if rating >= 0 {
+		resp, err := trakt.RateMovie(tmdbID, rating)
+		notifyRating(resp, err, rating)
+	}
+
+	ctx.String(200, "")
+}
+
+// RateShow ...
+func RateShow(ctx *gin.Context) {
+	showID, _ := strconv.Atoi(ctx.Params.ByName("showId"))
+
+	rating := chooseRating(ctx, showUserRating(showID))
+	if rating >= 0 {
+		resp, err := trakt.RateShow(showID, rating)
+		notifyRating(resp, err, rating)
+	}
+
+	ctx.String(200, "")
+}
+
+// RateEpisode ...
+func RateEpisode(ctx *gin.Context) {
+	showID, _ := strconv.Atoi(ctx.Params.ByName("showId"))
+	season, _ := strconv.Atoi(ctx.Params.ByName("season"))
+	episode, _ := strconv.Atoi(ctx.Params.ByName("episode"))
+
+	rating := chooseRating(ctx, episodeUserRating(showID, season, episode))
+	if rating >= 0 {
+		resp, err := trakt.RateEpisode(showID, season, episode, rating)
+		notifyRating(resp, err, rating)
+	}
+
+	ctx.String(200, "")
+}
+
+// MovieComments ...
+func MovieComments(ctx *gin.Context) {
+	tmdbID, _ := strconv.Atoi(ctx.Params.ByName("tmdbId"))
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+
+	comments, total, err := trakt.MovieComments(tmdbID, page)
+	renderComments(ctx, comments, total, page, err)
+}
+
+// ShowComments ...
+func ShowComments(ctx *gin.Context) {
+	showID, _ := strconv.Atoi(ctx.Params.ByName("showId"))
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+
+	comments, total, err := trakt.ShowComments(showID, page)
+	renderComments(ctx, comments, total, page, err)
+}
+
+// EpisodeComments ...
+func EpisodeComments(ctx *gin.Context) {
+	showID, _ := strconv.Atoi(ctx.Params.ByName("showId"))
+	season, _ := strconv.Atoi(ctx.Params.ByName("season"))
+	episode, _ := strconv.Atoi(ctx.Params.ByName("episode"))
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+
+	comments, total, err := trakt.EpisodeComments(showID, season, episode, page)
+	renderComments(ctx, comments, total, page, err)
+}
+
+func renderComments(ctx *gin.Context, comments []*trakt.Comment, total int, page int, err error) {
+	if err != nil {
+		log.Warning(err)
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+	}
+
+	items := make(xbmc.ListItems, 0, len(comments)+1)
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+
+		author := ""
+		if c.User != nil {
+			author = c.User.Username
+		}
+		if c.UserRating > 0 {
+			author = fmt.Sprintf("%s (%d/10)", author, c.UserRating)
+		}
+
+		text := c.Comment
+		if c.Spoiler {
+			text = "[COLOR red][Spoiler][/COLOR] " + text
+		}
+
+		items = append(items, &xbmc.ListItem{
+			Label:  fmt.Sprintf("[B]%s[/B]: %s", author, strings.Replace(text, "\n", " ", -1)),
+			Label2: c.CreatedAt.Format("2006-01-02"),
+			Info: &xbmc.ListItemInfo{
+				Title: author,
+				Plot:  fmt.Sprintf("%s\n\nLikes: %d, Replies: %d", text, c.Likes, c.Replies),
+				Date:  c.CreatedAt.Format("02.01.2006"),
+			},
+		})
+	}
+
+	if total > page*config.Get().ResultsPerPage {
+		items = append(items, &xbmc.ListItem{
+			Label:     "LOCALIZE[30415];;" + strconv.Itoa(page+1),
+			Path:      URLForXBMC(fmt.Sprintf("%s?page=%d", ctx.Request.URL.Path, page+1)),
+			Thumbnail: config.AddonResource("img", "nextpage.png"),
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("", items))
+}
+
+// TraktCheckin checks the user in to the item, that is currently playing
+func TraktCheckin(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer ctx.String(200, "")
+
+		p := s.GetActivePlayer()
+		if p == nil || p.Params().TMDBId == 0 {
+			xbmc.Notify("Elementum", "LOCALIZE[30789]", config.AddonIcon())
+			return
+		}
+
+		var err error
+		params := p.Params()
+		if params.ContentType == movieType {
+			_, err = trakt.CheckinMovie(params.TMDBId)
+		} else if params.ContentType == episodeType {
+			_, err = trakt.CheckinEpisode(params.ShowID, params.Season, params.Episode)
+		} else {
+			return
+		}
+
+		if err == trakt.ErrCheckinInProgress {
+			if xbmc.DialogConfirmFocused("Elementum", "LOCALIZE[30790]") {
+				if err = trakt.CancelCheckin(); err == nil {
+					if params.ContentType == movieType {
+						_, err = trakt.CheckinMovie(params.TMDBId)
+					} else {
+						_, err = trakt.CheckinEpisode(params.ShowID, params.Season, params.Episode)
+					}
+				}
+			} else {
+				return
+			}
+		}
+
+		if err != nil {
+			log.Warningf("Trakt check-in failed: %s", err)
+			xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+		} else {
+			xbmc.Notify("Elementum", "LOCALIZE[30791]", config.AddonIcon())
+		}
+	}
+}
+
+// TraktCheckinCancel ...
+func TraktCheckinCancel(ctx *gin.Context) {
+	if err := trakt.CancelCheckin(); err != nil {
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+	} else {
+		xbmc.Notify("Elementum", "LOCALIZE[30792]", config.AddonIcon())
+	}
+
+	ctx.String(200, "")
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/recommend"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Seeds of merged recommendations and of "Because you watched" menu
+const (
+	recommendSeeds = 10
+	becauseSeeds   = 30
+)
+
+// RecommendedMovies lists movies, related to recently watched ones
+func RecommendedMovies(ctx *gin.Context) {
+	ids, page, total := idsPage(ctx, candidateIDs(recommend.Movies(recommend.MovieSeeds(recommendSeeds))))
+	renderMovies(ctx, tmdb.GetMovies(ids, config.Get().Language), page, total, "")
+}
+
+// RecommendedShows lists shows, related to recently watched ones
+func RecommendedShows(ctx *gin.Context) {
+	ids, page, total := idsPage(ctx, candidateIDs(recommend.Shows(recommend.ShowSeeds(recommendSeeds))))
+	renderShows(ctx, tmdb.GetShows(ids, config.Get().Language), page, total, "")
+}
+
+// BecauseYouWatchedMovies lists recently watched movies, each leads to related movies
+func BecauseYouWatchedMovies(ctx *gin.Context) {
+	seeds := recommend.MovieSeeds(becauseSeeds)
+	ids := make([]int, 0, len(seeds))
+	for _, s := range seeds {
+		ids = append(ids, s.TMDBID)
+	}
+
+	items := make(xbmc.ListItems, 0, len(seeds))
+	for _, movie := range tmdb.GetMovies(ids, config.Get().Language) {
+		if movie == nil {
+			continue
+		}
+		items = append(items, &xbmc.ListItem{
+			Label:     fmt.Sprintf("LOCALIZE[30787];;%s", movie.Title),
+			Path:      URLForXBMC("/movies/because/%d", movie.ID),
+			Thumbnail: tmdb.ImageURL(movie.PosterPath, "w500"),
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("menus_movies", items))
+}
+
+// BecauseYouWatchedShows lists recently watched shows, each leads to related shows
+func BecauseYouWatchedShows(ctx *gin.Context) {
+	seeds := recommend.ShowSeeds(becauseSeeds)
+	ids := make([]int, 0, len(seeds))
+	for _, s := range seeds {
+		ids = append(ids, s.TMDBID)
+	}
+
+	items := make(xbmc.ListItems, 0, len(seeds))
+	for _, show := range tmdb.GetShows(ids, config.Get().Language) {
+		if show == nil {
+			continue
+		}
+		items = append(items, &xbmc.ListItem{
+			Label:     fmt.Sprintf("LOCALIZE[30787];;%s", show.Name),
+			Path:      URLForXBMC("/shows/because/%d", show.ID),
+			Thumbnail: tmdb.ImageURL(show.PosterPath, "w500"),
+		})
+	}
+
+	ctx.JSON(200, xbmc.NewView("menus_tvshows", items))
+}
+
+// BecauseYouWatchedMovie lists movies, related to a watched movie
+func BecauseYouWatchedMovie(ctx *gin.Context) {
+	tmdbID, _ := strconv.Atoi(ctx.Params.ByName("tmdbId"))
+	ids, page, total := idsPage(ctx, candidateIDs(recommend.Movies([]*recommend.Seed{{TMDBID: tmdbID}})))
+	renderMovies(ctx, tmdb.GetMovies(ids, config.Get().Language), page, total, "")
+}
+
+// BecauseYouWatchedShow lists shows, related to a watched show
+func BecauseYouWatchedShow(ctx *gin.Context) {
+	tmdbID, _ := strconv.Atoi(ctx.Params.ByName("tmdbId"))
+	ids, page, total := idsPage(ctx, candidateIDs(recommend.Shows([]*recommend.Seed{{TMDBID: tmdbID}})))
+	renderShows(ctx, tmdb.GetShows(ids, config.Get().Language), page, total, "")
+}
+
+func candidateIDs(candidates []*recommend.Candidate) []int {
+	ids := make([]int, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/bcrusher29/solaris/api/repository"
 	"github.com/bcrusher29/solaris/bittorrent"
 	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/imagecache"
 	"github.com/bcrusher29/solaris/providers"
+	"github.com/bcrusher29/solaris/xbmc"
 
 	"github.com/gin-gonic/gin"
 	"github.com/op/go-logging"
@@ -19,29 +21,44 @@ var log = logging.MustGetLogger("api")
 func Routes(s *bittorrent.Service) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Recovery())
-	r.Use(gin.LoggerWithWriter(gin.DefaultWriter, "/torrents/list", "/notification"))
+	r.Use(gin.LoggerWithWriter(gin.DefaultWriter, "/torrents/list", "/notification", "/events", "/metrics"))
 
 	gin.SetMode(gin.ReleaseMode)
 
+	xbmc.ArtRewriter = imagecache.ProxyURL
+
+	// Routes, that change state, are not open to web pages
+	auth := KodiAuth()
+
 	r.GET("/", Index(s))
-	r.GET("/playtorrent", PlayTorrent)
+	r.GET("/playtorrent", auth, PlayTorrent)
 	r.GET("/infolabels", InfoLabelsStored(s))
+	r.GET("/events", Events(s))
+	r.GET("/metrics", Metrics(s))
 	r.GET("/changelog", Changelog)
 	r.GET("/donate", Donate)
 	r.GET("/status", Status)
+	r.GET("/image", ImageProxy)
+
+	APIRoutes(r, s)
 
 	history := r.Group("/history")
 	{
 		history.GET("", History)
-		history.GET("/remove", HistoryRemove)
-		history.GET("/clear", HistoryClear)
+		history.GET("/remove", auth, HistoryRemove)
+		history.GET("/clear", auth, HistoryClear)
+		history.GET("/watched/export", auth, WatchedExport)
+		history.GET("/watched/import", auth, WatchedImport)
+		history.GET("/sessions", PlaybackSessions)
+		history.GET("/sessions/show", PlaybackSessionShow)
+		history.GET("/sessions/list", PlaybackSessionsWeb)
 	}
 
 	search := r.Group("/search")
 	{
 		search.GET("", Search(s))
-		search.GET("/remove", SearchRemove)
-		search.GET("/clear", SearchClear)
+		search.GET("/remove", auth, SearchRemove)
+		search.GET("/clear", auth, SearchClear)
 		search.GET("/infolabels/:tmdbId", InfoLabelsSearch(s))
 	}
 
@@ -58,28 +75,42 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 	torrents := r.Group("/torrents")
 	{
 		torrents.GET("/", ListTorrents(s))
-		torrents.Any("/add", AddTorrent(s))
-		torrents.GET("/pause", PauseSession(s))
-		torrents.GET("/resume", ResumeSession(s))
-		torrents.GET("/move/:torrentId", MoveTorrent(s))
-		torrents.GET("/pause/:torrentId", PauseTorrent(s))
-		torrents.GET("/resume/:torrentId", ResumeTorrent(s))
-		torrents.GET("/delete/:torrentId", RemoveTorrent(s))
-		torrents.GET("/downloadall/:torrentId", DownloadAllTorrent(s))
-		torrents.GET("/undownloadall/:torrentId", UnDownloadAllTorrent(s))
+		torrents.Any("/add", auth, AddTorrent(s))
+		torrents.GET("/import", auth, ImportTorrents(s))
+		torrents.GET("/pause", auth, PauseSession(s))
+		torrents.GET("/resume", auth, ResumeSession(s))
+		torrents.GET("/move/:torrentId", auth, MoveTorrent(s))
+		torrents.GET("/pause/:torrentId", auth, PauseTorrent(s))
+		torrents.GET("/resume/:torrentId", auth, ResumeTorrent(s))
+		torrents.GET("/delete/:torrentId", auth, RemoveTorrent(s))
+		torrents.GET("/downloadall/:torrentId", auth, DownloadAllTorrent(s))
+		torrents.GET("/undownloadall/:torrentId", auth, UnDownloadAllTorrent(s))
+		torrents.GET("/category/:torrentId", auth, SetTorrentCategory(s))
+		torrents.GET("/queue/:torrentId", auth, QueueTorrent(s))
+		torrents.GET("/queue/:torrentId/:action", auth, QueueTorrent(s))
 
 		// Web UI json
 		torrents.GET("/list", ListTorrentsWeb(s))
+		torrents.GET("/categories", ListCategoriesWeb(s))
+		torrents.GET("/files/:torrentId", ListTorrentFilesWeb(s))
 	}
 
 	movies := r.Group("/movies")
 	{
 		movies.GET("/", MoviesIndex)
 		movies.GET("/search", SearchMovies)
+		movies.GET("/discover", DiscoverIndex("movies"))
+		movies.GET("/discover/results", DiscoverResults("movies"))
+		movies.GET("/discover/edit/:field", DiscoverEdit("movies"))
+		movies.GET("/discover/save", auth, DiscoverSave("movies"))
+		movies.GET("/recommended", RecommendedMovies)
+		movies.GET("/because", BecauseYouWatchedMovies)
+		movies.GET("/because/:tmdbId", BecauseYouWatchedMovie)
 		movies.GET("/popular", PopularMovies)
 		movies.GET("/popular/genre/:genre", PopularMovies)
 		movies.GET("/popular/language/:language", PopularMovies)
 		movies.GET("/popular/country/:country", PopularMovies)
+		movies.GET("/continue", ContinueWatchingMovies)
 		movies.GET("/recent", RecentMovies)
 		movies.GET("/recent/genre/:genre", RecentMovies)
 		movies.GET("/recent/language/:language", RecentMovies)
@@ -91,6 +122,8 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 		movies.GET("/languages", MovieLanguages)
 		movies.GET("/countries", MovieCountries)
 		movies.GET("/library", MovieLibrary)
+		movies.GET("/collection/:collectionId", MovieCollection)
+		movies.GET("/collection/:collectionId/missing", MovieCollectionMissing)
 
 		trakt := movies.Group("/trakt")
 		{
@@ -134,16 +167,27 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 		movie.GET("/:tmdbId/play/:ident", MoviePlaySelector("play", s))
 		movie.GET("/:tmdbId/forceplay", MoviePlaySelector("forceplay", s))
 		movie.GET("/:tmdbId/forceplay/:ident", MoviePlaySelector("forceplay", s))
-		movie.GET("/:tmdbId/watchlist/add", AddMovieToWatchlist)
-		movie.GET("/:tmdbId/watchlist/remove", RemoveMovieFromWatchlist)
-		movie.GET("/:tmdbId/collection/add", AddMovieToCollection)
-		movie.GET("/:tmdbId/collection/remove", RemoveMovieFromCollection)
+		movie.GET("/:tmdbId/watchlist/add", auth, AddMovieToWatchlist)
+		movie.GET("/:tmdbId/watchlist/remove", auth, RemoveMovieFromWatchlist)
+		movie.GET("/:tmdbId/collection/add", auth, AddMovieToCollection)
+		movie.GET("/:tmdbId/collection/remove", auth, RemoveMovieFromCollection)
+		movie.GET("/:tmdbId/rate", auth, RateMovie)
+		movie.GET("/:tmdbId/comments", MovieComments)
+		movie.GET("/:tmdbId/watched", auth, MarkMovieWatched)
+		movie.GET("/:tmdbId/unwatched", auth, MarkMovieUnwatched)
 	}
 
 	shows := r.Group("/shows")
 	{
 		shows.GET("/", TVIndex)
 		shows.GET("/search", SearchShows)
+		shows.GET("/discover", DiscoverIndex("shows"))
+		shows.GET("/discover/results", DiscoverResults("shows"))
+		shows.GET("/discover/edit/:field", DiscoverEdit("shows"))
+		shows.GET("/discover/save", auth, DiscoverSave("shows"))
+		shows.GET("/recommended", RecommendedShows)
+		shows.GET("/because", BecauseYouWatchedShows)
+		shows.GET("/because/:tmdbId", BecauseYouWatchedShow)
 		shows.GET("/popular", PopularShows)
 		shows.GET("/popular/genre/:genre", PopularShows)
 		shows.GET("/popular/language/:language", PopularShows)
@@ -152,6 +196,7 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 		shows.GET("/recent/shows/genre/:genre", RecentShows)
 		shows.GET("/recent/shows/language/:language", RecentShows)
 		shows.GET("/recent/shows/country/:country", RecentShows)
+		shows.GET("/continue", ContinueWatchingShows)
 		shows.GET("/recent/episodes", RecentEpisodes)
 		shows.GET("/recent/episodes/genre/:genre", RecentEpisodes)
 		shows.GET("/recent/episodes/language/:language", RecentEpisodes)
@@ -162,6 +207,9 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 		shows.GET("/languages", TVLanguages)
 		shows.GET("/countries", TVCountries)
 		shows.GET("/library", TVLibrary)
+		shows.GET("/calendar", LocalCalendarShows)
+		shows.GET("/calendar/subscribe", auth, LocalCalendarSubscribe)
+		shows.GET("/calendar/export.ics", auth, LocalCalendarICS)
 
 		trakt := shows.Group("/trakt")
 		{
@@ -212,10 +260,16 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 		show.GET("/:showId/season/:season/episode/:episode/links/:ident", ShowEpisodePlaySelector("links", s))
 		show.GET("/:showId/season/:season/episode/:episode/forcelinks", ShowEpisodePlaySelector("forcelinks", s))
 		show.GET("/:showId/season/:season/episode/:episode/forcelinks/:ident", ShowEpisodePlaySelector("forcelinks", s))
-		show.GET("/:showId/watchlist/add", AddShowToWatchlist)
-		show.GET("/:showId/watchlist/remove", RemoveShowFromWatchlist)
-		show.GET("/:showId/collection/add", AddShowToCollection)
-		show.GET("/:showId/collection/remove", RemoveShowFromCollection)
+		show.GET("/:showId/watchlist/add", auth, AddShowToWatchlist)
+		show.GET("/:showId/watchlist/remove", auth, RemoveShowFromWatchlist)
+		show.GET("/:showId/collection/add", auth, AddShowToCollection)
+		show.GET("/:showId/collection/remove", auth, RemoveShowFromCollection)
+		show.GET("/:showId/rate", auth, RateShow)
+		show.GET("/:showId/comments", ShowComments)
+		show.GET("/:showId/season/:season/episode/:episode/rate", auth, RateEpisode)
+		show.GET("/:showId/season/:season/episode/:episode/comments", EpisodeComments)
+		show.GET("/:showId/season/:season/episode/:episode/watched", auth, MarkEpisodeWatched)
+		show.GET("/:showId/season/:season/episode/:episode/unwatched", auth, MarkEpisodeUnwatched)
 	}
 	// TODO
 	// episode := r.Group("/episode")
@@ -223,18 +277,30 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 	// 	episode.GET("/:episodeId/watchlist/add", AddEpisodeToWatchlist)
 	// }
 
+	people := r.Group("/people")
+	{
+		people.GET("/search", SearchPeople)
+	}
+
+	person := r.Group("/person")
+	{
+		person.GET("/:personId/movies", PersonMovies)
+		person.GET("/:personId/shows", PersonShows)
+	}
+
 	library := r.Group("/library")
 	{
-		library.GET("/movie/add/:tmdbId", AddMovie)
-		library.GET("/movie/remove/:tmdbId", RemoveMovie)
-		library.GET("/movie/list/add/:listId", AddMoviesList)
+		library.GET("/movie/add/:tmdbId", auth, AddMovie)
+		library.GET("/movie/remove/:tmdbId", auth, RemoveMovie)
+		library.GET("/movie/list/add/:listId", auth, AddMoviesList)
+		library.GET("/movie/collection/add/:collectionId", auth, AddMovieCollection)
 		library.GET("/movie/play/:tmdbId", PlayMovie(s))
-		library.GET("/show/add/:tmdbId", AddShow)
-		library.GET("/show/remove/:tmdbId", RemoveShow)
-		library.GET("/show/list/add/:listId", AddShowsList)
+		library.GET("/show/add/:tmdbId", auth, AddShow)
+		library.GET("/show/remove/:tmdbId", auth, RemoveShow)
+		library.GET("/show/list/add/:listId", auth, AddShowsList)
 		library.GET("/show/play/:showId/:season/:episode", PlayShow(s))
 
-		library.GET("/update", UpdateLibrary)
+		library.GET("/update", auth, UpdateLibrary)
 
 		// DEPRECATED
 		library.GET("/play/movie/:tmdbId", PlayMovie(s))
@@ -250,10 +316,10 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 	{
 		provider.GET("/", ProviderList)
 		provider.GET("/:provider/check", ProviderCheck)
-		provider.GET("/:provider/enable", ProviderEnable)
-		provider.GET("/:provider/disable", ProviderDisable)
-		provider.GET("/:provider/failure", ProviderFailure)
-		provider.GET("/:provider/settings", ProviderSettings)
+		provider.GET("/:provider/enable", auth, ProviderEnable)
+		provider.GET("/:provider/disable", auth, ProviderDisable)
+		provider.GET("/:provider/failure", auth, ProviderFailure)
+		provider.GET("/:provider/settings", auth, ProviderSettings)
 
 		provider.GET("/:provider/movie/:tmdbId", ProviderGetMovie)
 		provider.GET("/:provider/show/:showId/season/:season/episode/:episode", ProviderGetEpisode)
@@ -261,8 +327,8 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 
 	allproviders := r.Group("/providers")
 	{
-		allproviders.GET("/enable", ProvidersEnableAll)
-		allproviders.GET("/disable", ProvidersDisableAll)
+		allproviders.GET("/enable", auth, ProvidersEnableAll)
+		allproviders.GET("/disable", auth, ProvidersDisableAll)
 	}
 
 	repo := r.Group("/repository")
@@ -273,22 +339,29 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 
 	trakt := r.Group("/trakt")
 	{
-		trakt.GET("/authorize", AuthorizeTrakt)
-		trakt.GET("/select_list/:action/:media", SelectTraktUserList)
-		trakt.GET("/update", UpdateTrakt)
+		trakt.GET("/authorize", auth, AuthorizeTrakt)
+		trakt.GET("/select_list/:action/:media", auth, SelectTraktUserList)
+		trakt.GET("/update", auth, UpdateTrakt)
+		trakt.GET("/checkin", auth, TraktCheckin(s))
+		trakt.GET("/checkin/cancel", auth, TraktCheckinCancel)
+		trakt.GET("/outbox", TraktOutbox)
+		trakt.GET("/outbox/replay", auth, TraktOutboxReplay)
+		trakt.GET("/outbox/retry", auth, TraktOutboxRetry)
+		trakt.GET("/outbox/remove", auth, TraktOutboxRemove)
+		trakt.GET("/outbox/clear", auth, TraktOutboxClear)
 	}
 
-	r.GET("/migrate/:plugin", MigratePlugin)
+	r.GET("/migrate/:plugin", auth, MigratePlugin)
 
-	r.GET("/setviewmode/:content_type", SetViewMode)
+	r.GET("/setviewmode/:content_type", auth, SetViewMode)
 
 	r.GET("/subtitles", SubtitlesIndex(s))
 	r.GET("/subtitle/:id", SubtitleGet)
 
-	r.GET("/play", Play(s))
-	r.GET("/play/:ident", Play(s))
-	r.Any("/playuri", PlayURI(s))
-	r.Any("/playuri/:ident", PlayURI(s))
+	r.GET("/play", auth, Play(s))
+	r.GET("/play/:ident", auth, Play(s))
+	r.Any("/playuri", auth, PlayURI(s))
+	r.Any("/playuri/:ident", auth, PlayURI(s))
 
 	r.POST("/callbacks/:cid", providers.CallbackHandler)
 
@@ -298,40 +371,40 @@ func Routes(s *bittorrent.Service) *gin.Engine {
 
 	cmd := r.Group("/cmd")
 	{
-		cmd.GET("/clear_cache_key/:key", ClearCache)
-		cmd.GET("/clear_page_cache", ClearPageCache)
-		cmd.GET("/clear_trakt_cache", ClearTraktCache)
-		cmd.GET("/clear_tmdb_cache", ClearTmdbCache)
+		cmd.GET("/clear_cache_key/:key", auth, ClearCache)
+		cmd.GET("/clear_page_cache", auth, ClearPageCache)
+		cmd.GET("/clear_trakt_cache", auth, ClearTraktCache)
+		cmd.GET("/clear_tmdb_cache", auth, ClearTmdbCache)
 
-		cmd.GET("/reset_path", ResetPath)
-		cmd.GET("/reset_path/:path", ResetCustomPath)
+		cmd.GET("/reset_path", auth, ResetPath)
+		cmd.GET("/reset_path/:path", auth, ResetCustomPath)
 
-		cmd.GET("/paste/:type", Pastebin)
+		cmd.GET("/paste/:type", auth, Pastebin)
 
-		cmd.GET("/select_interface/:type", SelectNetworkInterface)
-		cmd.GET("/select_strm_language", SelectStrmLanguage)
+		cmd.GET("/select_interface/:type", auth, SelectNetworkInterface)
+		cmd.GET("/select_strm_language", auth, SelectStrmLanguage)
 
 		database := cmd.Group("/database")
 		{
-			database.GET("/clear_movies", ClearDatabaseMovies)
-			database.GET("/clear_shows", ClearDatabaseShows)
-			database.GET("/clear_torrent_history", ClearDatabaseTorrentHistory)
-			database.GET("/clear_search_history", ClearDatabaseSearchHistory)
-			database.GET("/clear_database", ClearDatabase)
+			database.GET("/clear_movies", auth, ClearDatabaseMovies)
+			database.GET("/clear_shows", auth, ClearDatabaseShows)
+			database.GET("/clear_torrent_history", auth, ClearDatabaseTorrentHistory)
+			database.GET("/clear_search_history", auth, ClearDatabaseSearchHistory)
+			database.GET("/clear_database", auth, ClearDatabase)
 		}
 
 		cache := cmd.Group("/cache")
 		{
-			cache.GET("/clear_tmdb", ClearCacheTMDB)
-			cache.GET("/clear_trakt", ClearCacheTrakt)
-			cache.GET("/clear_cache", ClearCache)
+			cache.GET("/clear_tmdb", auth, ClearCacheTMDB)
+			cache.GET("/clear_trakt", auth, ClearCacheTrakt)
+			cache.GET("/clear_cache", auth, ClearCache)
 		}
 	}
 
 	menu := r.Group("/menu")
 	{
-		menu.GET("/:type/add", MenuAdd)
-		menu.GET("/:type/remove", MenuRemove)
+		menu.GET("/:type/add", auth, MenuAdd)
+		menu.GET("/:type/remove", auth, MenuRemove)
 	}
 
 	MovieMenu.Load()
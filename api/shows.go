@@ -21,6 +21,10 @@ import (
 func TVIndex(ctx *gin.Context) {
 	items := xbmc.ListItems{
 		{Label: "LOCALIZE[30209]", Path: URLForXBMC("/shows/search"), Thumbnail: config.AddonResource("img", "search.png")},
+		{Label: "LOCALIZE[30750]", Path: URLForXBMC("/people/search"), Thumbnail: config.AddonResource("img", "search.png")},
+		{Label: "LOCALIZE[30752]", Path: URLForXBMC("/shows/discover"), Thumbnail: config.AddonResource("img", "genre_comedy.png")},
+		{Label: "LOCALIZE[30785]", Path: URLForXBMC("/shows/recommended"), Thumbnail: config.AddonResource("img", "tv.png")},
+		{Label: "LOCALIZE[30786]", Path: URLForXBMC("/shows/because"), Thumbnail: config.AddonResource("img", "tv.png")},
 
 		{Label: "LOCALIZE[30360]", Path: URLForXBMC("/shows/trakt/progress"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
 		{Label: "LOCALIZE[30263]", Path: URLForXBMC("/shows/trakt/lists/"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
@@ -47,9 +51,14 @@ func TVIndex(ctx *gin.Context) {
 		// and modify the URL params to /discover endpoint
 		// {Label: "LOCALIZE[30374]", Path: URLForXBMC("/shows/countries"), Thumbnail: config.AddonResource("img", "genre_tv.png")},
 
+		{Label: "LOCALIZE[30725]", Path: URLForXBMC("/shows/continue"), Thumbnail: config.AddonResource("img", "clock.png")},
 		{Label: "LOCALIZE[30361]", Path: URLForXBMC("/shows/trakt/history"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30700]", Path: URLForXBMC("/trakt/outbox"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30721]", Path: URLForXBMC("/trakt/checkin"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
+		{Label: "LOCALIZE[30722]", Path: URLForXBMC("/trakt/checkin/cancel"), Thumbnail: config.AddonResource("img", "trakt.png"), TraktAuth: true},
 
 		{Label: "LOCALIZE[30517]", Path: URLForXBMC("/shows/library"), Thumbnail: config.AddonResource("img", "genre_tv.png")},
+		{Label: "LOCALIZE[30788]", Path: URLForXBMC("/shows/calendar"), Thumbnail: config.AddonResource("img", "most_anticipated.png")},
 	}
 	for _, item := range items {
 		item.ContextMenu = [][]string{
@@ -65,6 +74,9 @@ func TVIndex(ctx *gin.Context) {
 			item.ContextMenu = [][]string{
 				[]string{"LOCALIZE[30521]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLQuery(URLForXBMC("/menu/tv/remove"), "name", i.Name, "link", i.Link))},
 			}
+			if i.Filters != nil {
+				item.ContextMenu = append(item.ContextMenu, []string{"LOCALIZE[30755]", fmt.Sprintf("Container.Update(%s)", URLForXBMC("/shows/discover")+"?"+i.Filters.Values().Encode())})
+			}
 
 			items = append(items[:index], append([]*xbmc.ListItem{item}, items[index:]...)...)
 			index++
@@ -188,6 +200,8 @@ func TVTraktLists(ctx *gin.Context) {
 // CalendarShows ...
 func CalendarShows(ctx *gin.Context) {
 	items := xbmc.ListItems{
+		{Label: "LOCALIZE[30788]", Path: URLForXBMC("/shows/calendar"), Thumbnail: config.AddonResource("img", "most_anticipated.png")},
+		{Label: "LOCALIZE[30811]", Path: URLForXBMC("/shows/calendar/subscribe"), Thumbnail: config.AddonResource("img", "most_anticipated.png")},
 		{Label: "LOCALIZE[30295]", Path: URLForXBMC("/shows/trakt/calendars/shows"), Thumbnail: config.AddonResource("img", "tv.png")},
 		{Label: "LOCALIZE[30296]", Path: URLForXBMC("/shows/trakt/calendars/newshows"), Thumbnail: config.AddonResource("img", "fresh.png")},
 		{Label: "LOCALIZE[30297]", Path: URLForXBMC("/shows/trakt/calendars/premieres"), Thumbnail: config.AddonResource("img", "box_office.png")},
@@ -234,12 +248,16 @@ func renderShows(ctx *gin.Context, shows tmdb.Shows, page int, total int, query
 			collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/collection/remove", show.ID))}
 		}
 
+		item.Info.UserRating = showUserRating(show.ID)
+
 		item.ContextMenu = [][]string{
 			watchlistAction,
 			collectionAction,
 			[]string{"LOCALIZE[30035]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/tvshows"))},
 		}
+		item.ContextMenu = append(item.ContextMenu, showTraktActions(show.ID)...)
 		item.ContextMenu = append(libraryActions, item.ContextMenu...)
+		item.ContextMenu = append(item.ContextMenu, personActions(show.Credits, "shows")...)
 
 		if config.Get().Platform.Kodi < 17 {
 			item.ContextMenu = append(item.ContextMenu,
@@ -254,6 +272,11 @@ func renderShows(ctx *gin.Context, shows tmdb.Shows, page int, total int, query
 		nextPath := URLForXBMC(fmt.Sprintf("%s?page=%d", path, page+1))
 		if query != "" {
 			nextPath = URLForXBMC(fmt.Sprintf("%s?q=%s&page=%d", path, query, page+1))
+		} else if ctx.Request.URL.RawQuery != "" {
+			// Keeping filters of discover lists
+			values := ctx.Request.URL.Query()
+			values.Set("page", strconv.Itoa(page+1))
+			nextPath = URLForXBMC(path) + "?" + values.Encode()
 		}
 		next := &xbmc.ListItem{
 			Label:     "LOCALIZE[30415];;" + strconv.Itoa(page+1),
@@ -425,6 +448,12 @@ func ShowEpisodes(ctx *gin.Context) {
 		}
 
 		item.Path = contextPlayURL(thisURL, contextTitle, false)
+		item.Info.UserRating = episodeUserRating(show.ID, seasonNumber, item.Info.Episode)
+
+		watchedAction := []string{"LOCALIZE[30726]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/season/%d/episode/%d/watched", show.ID, seasonNumber, item.Info.Episode))}
+		if item.Info.PlayCount > 0 {
+			watchedAction = []string{"LOCALIZE[30727]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/season/%d/episode/%d/unwatched", show.ID, seasonNumber, item.Info.Episode))}
+		}
 
 		if config.Get().Platform.Kodi < 17 {
 			item.ContextMenu = [][]string{
@@ -439,6 +468,8 @@ func ShowEpisodes(ctx *gin.Context) {
 				[]string{"LOCALIZE[30037]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/episodes"))},
 			}
 		}
+		item.ContextMenu = append(item.ContextMenu, watchedAction)
+		item.ContextMenu = append(item.ContextMenu, episodeTraktActions(show.ID, seasonNumber, item.Info.Episode)...)
 		item.IsPlayable = true
 	}
 
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -49,6 +50,20 @@ type TorrentsWeb struct {
 	SeedersTotal  int     `json:"seeders_total"`
 	Peers         int     `json:"peers"`
 	PeersTotal    int     `json:"peers_total"`
+	Category      string  `json:"category"`
+	QueuePosition int     `json:"queue_position"`
+	ForceStart    bool    `json:"force_start"`
+}
+
+// TorrentFileWeb ...
+type TorrentFileWeb struct {
+	Index    int     `json:"index"`
+	Name     string  `json:"name"`
+	Path     string  `json:"path"`
+	Size     int64   `json:"size"`
+	Progress float64 `json:"progress"`
+	Priority string  `json:"priority" enum:"skip,low,normal,high"`
+	Position int     `json:"position"`
 }
 
 // AddToTorrentsMap ...
@@ -163,7 +178,14 @@ func SetCachedTorrents(tmdbID string, torrents []*bittorrent.TorrentFile) error
 // ListTorrents ...
 func ListTorrents(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		items := make(xbmc.ListItems, 0, len(s.GetTorrents()))
+		items := make(xbmc.ListItems, 0, len(s.GetTorrents())+1)
+		if !s.IsMemoryStorage() {
+			items = append(items, &xbmc.ListItem{
+				Label:     "LOCALIZE[30742]",
+				Path:      URLForXBMC("/torrents/import"),
+				Thumbnail: config.AddonResource("img", "cloud.png"),
+			})
+		}
 		if len(s.GetTorrents()) == 0 {
 			ctx.JSON(200, xbmc.NewView("", items))
 			return
@@ -261,6 +283,13 @@ func ListTorrents(s *bittorrent.Service) gin.HandlerFunc {
 				[]string{"LOCALIZE[30308]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/torrents/move/%s", t.InfoHash()))},
 				sessionAction,
 			}
+			if !s.IsMemoryStorage() {
+				item.ContextMenu = append(item.ContextMenu, []string{"LOCALIZE[30735]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/torrents/queue/%s", t.InfoHash()))})
+				item.ContextMenu = append(item.ContextMenu, []string{"LOCALIZE[30742]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/torrents/import"))})
+			}
+			if !s.IsMemoryStorage() && config.Get().TorrentCategories != "" {
+				item.ContextMenu = append(item.ContextMenu, []string{"LOCALIZE[30732]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/torrents/category/%s", t.InfoHash()))})
+			}
 
 			if !s.IsMemoryStorage() {
 				if t.HasAvailableFiles() {
@@ -289,7 +318,6 @@ func ListTorrentsWeb(s *bittorrent.Service) gin.HandlerFunc {
 		torrentsVector := s.Session.GetHandle().GetTorrents()
 		torrentsVectorSize := int(torrentsVector.Size())
 		torrents := make([]*TorrentsWeb, 0, torrentsVectorSize)
-		seedTimeLimit := config.Get().SeedTimeLimit
 
 		if torrentsVectorSize == 0 {
 			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
@@ -297,77 +325,93 @@ func ListTorrentsWeb(s *bittorrent.Service) gin.HandlerFunc {
 			return
 		}
 
-		for _, t := range s.GetTorrents() {
-			th := t.GetHandle()
-			if th == nil || !th.IsValid() {
-				continue
-			}
+		torrents = append(torrents, torrentsWebInfo(s)...)
 
-			torrentStatus := th.Status()
-			defer lt.DeleteTorrentStatus(torrentStatus)
+		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		ctx.JSON(200, torrents)
+	}
+}
 
-			torrentName := torrentStatus.GetName()
-			progress := float64(torrentStatus.GetProgress()) * 100
+// torrentsWebInfo collects status of all torrents in the order of queue
+func torrentsWebInfo(s *bittorrent.Service) []*TorrentsWeb {
+	torrents := []*TorrentsWeb{}
+	for _, t := range s.GetTorrents() {
+		if ti := torrentWebInfo(t); ti != nil {
+			torrents = append(torrents, ti)
+		}
+	}
+	return torrents
+}
 
-			infoHash := t.InfoHash()
-			status := t.GetStateString()
+// torrentWebInfo collects torrent status for web clients
+func torrentWebInfo(t *bittorrent.Torrent) *TorrentsWeb {
+	th := t.GetHandle()
+	if th == nil || !th.IsValid() {
+		return nil
+	}
 
-			ratio := float64(0)
-			allTimeDownload := float64(torrentStatus.GetAllTimeDownload())
-			if allTimeDownload > 0 {
-				ratio = float64(torrentStatus.GetAllTimeUpload()) / allTimeDownload
-			}
+	torrentStatus := th.Status()
+	defer lt.DeleteTorrentStatus(torrentStatus)
 
-			timeRatio := float64(0)
-			finishedTime := float64(torrentStatus.GetFinishedTime())
-			downloadTime := float64(torrentStatus.GetActiveTime()) - finishedTime
-			if downloadTime > 1 {
-				timeRatio = finishedTime / downloadTime
-			}
-			seedingTime := time.Duration(torrentStatus.GetSeedingTime()) * time.Second
-			if progress == 100 && seedingTime == 0 {
-				seedingTime = time.Duration(finishedTime) * time.Second
-			}
+	torrentName := torrentStatus.GetName()
+	progress := float64(torrentStatus.GetProgress()) * 100
 
-			size := humanize.Bytes(uint64(t.Length()))
-
-			downloadRate := float64(torrentStatus.GetDownloadPayloadRate()) / 1024
-			uploadRate := float64(torrentStatus.GetUploadPayloadRate()) / 1024
-
-			seeders, seedersTotal, peers, peersTotal := t.GetConnections()
-
-			ti := &TorrentsWeb{
-				ID:            infoHash,
-				Name:          torrentName,
-				Size:          size,
-				Status:        status,
-				Progress:      progress,
-				Ratio:         ratio,
-				TimeRatio:     timeRatio,
-				SeedingTime:   seedingTime.String(),
-				SeedTime:      seedingTime.Seconds(),
-				SeedTimeLimit: seedTimeLimit,
-				DownloadRate:  downloadRate,
-				UploadRate:    uploadRate,
-				Seeders:       seeders,
-				SeedersTotal:  seedersTotal,
-				Peers:         peers,
-				PeersTotal:    peersTotal,
-			}
-			torrents = append(torrents, ti)
-		}
+	infoHash := t.InfoHash()
+	status := t.GetStateString()
 
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		ctx.JSON(200, torrents)
+	ratio := float64(0)
+	allTimeDownload := float64(torrentStatus.GetAllTimeDownload())
+	if allTimeDownload > 0 {
+		ratio = float64(torrentStatus.GetAllTimeUpload()) / allTimeDownload
+	}
+
+	timeRatio := float64(0)
+	finishedTime := float64(torrentStatus.GetFinishedTime())
+	downloadTime := float64(torrentStatus.GetActiveTime()) - finishedTime
+	if downloadTime > 1 {
+		timeRatio = finishedTime / downloadTime
+	}
+	seedingTime := time.Duration(torrentStatus.GetSeedingTime()) * time.Second
+	if progress == 100 && seedingTime == 0 {
+		seedingTime = time.Duration(finishedTime) * time.Second
+	}
+
+	size := humanize.Bytes(uint64(t.Length()))
+
+	downloadRate := float64(torrentStatus.GetDownloadPayloadRate()) / 1024
+	uploadRate := float64(torrentStatus.GetUploadPayloadRate()) / 1024
+
+	seeders, seedersTotal, peers, peersTotal := t.GetConnections()
+
+	return &TorrentsWeb{
+		ID:            infoHash,
+		Name:          torrentName,
+		Size:          size,
+		Status:        status,
+		Progress:      progress,
+		Ratio:         ratio,
+		TimeRatio:     timeRatio,
+		SeedingTime:   seedingTime.String(),
+		SeedTime:      seedingTime.Seconds(),
+		SeedTimeLimit: t.Policy().SeedTimeLimit,
+		DownloadRate:  downloadRate,
+		UploadRate:    uploadRate,
+		Seeders:       seeders,
+		SeedersTotal:  seedersTotal,
+		Peers:         peers,
+		PeersTotal:    peersTotal,
+		Category:      t.CategoryName(),
+		QueuePosition: torrentStatus.GetQueuePosition(),
+		ForceStart:    t.IsForceStarted(),
 	}
 }
 
 // PauseSession ...
 func PauseSession(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		// TODO: Add Global Pause
+		s.PauseSession()
+
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -375,9 +419,9 @@ func PauseSession(s *bittorrent.Service) gin.HandlerFunc {
 // ResumeSession ...
 func ResumeSession(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		// TODO: Add Global Resume
+		s.ResumeSession()
+
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -386,6 +430,7 @@ func ResumeSession(s *bittorrent.Service) gin.HandlerFunc {
 func AddTorrent(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		uri := ctx.Request.FormValue("uri")
+		category := ctx.Request.FormValue("category")
 		file, header, fileError := ctx.Request.FormFile("file")
 
 		if file != nil && header != nil && fileError == nil {
@@ -395,15 +440,13 @@ func AddTorrent(s *bittorrent.Service) gin.HandlerFunc {
 			}
 		}
 
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-
 		if uri == "" {
 			ctx.String(404, "Missing torrent URI")
 			return
 		}
 		torrentsLog.Infof("Adding torrent from %s", uri)
 
-		_, err := s.AddTorrent(uri, false)
+		_, err := s.AddTorrent(uri, false, category)
 		if err != nil {
 			ctx.String(404, err.Error())
 			return
@@ -429,7 +472,6 @@ func ResumeTorrent(s *bittorrent.Service) gin.HandlerFunc {
 		torrent.Resume()
 
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -448,7 +490,6 @@ func MoveTorrent(s *bittorrent.Service) gin.HandlerFunc {
 		s.MarkedToMove = torrent.InfoHash()
 
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -466,7 +507,6 @@ func PauseTorrent(s *bittorrent.Service) gin.HandlerFunc {
 		torrent.Pause()
 
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -483,23 +523,6 @@ func RemoveTorrent(s *bittorrent.Service) gin.HandlerFunc {
 			return
 		}
 
-		// Delete torrent file
-		infoHash := torrent.InfoHash()
-		if _, err := os.Stat(torrent.TorrentPath); err == nil {
-			torrentsLog.Infof("Removed torrent file %s", torrent.TorrentPath)
-			defer os.Remove(torrent.TorrentPath)
-		}
-
-		// Delete torrent file, in case it it not 'infohash' + '.torrent'
-		torrentsPath := config.Get().TorrentsPath
-		torrentFile := filepath.Join(torrentsPath, fmt.Sprintf("%s.torrent", infoHash))
-		if _, err := os.Stat(torrentFile); err == nil {
-			torrentsLog.Infof("Removed torrent file %s", torrentFile)
-			defer os.Remove(torrentFile)
-		}
-
-		torrentsLog.Infof("Removed %s from database", infoHash)
-
 		keepSetting := config.Get().KeepFilesFinished
 		deleteAnswer := false
 		if keepSetting == 1 && deleteFiles == "" && xbmc.DialogConfirm("Elementum", "LOCALIZE[30269]") {
@@ -508,20 +531,41 @@ func RemoveTorrent(s *bittorrent.Service) gin.HandlerFunc {
 			deleteAnswer = true
 		}
 
-		if deleteAnswer == true || deleteFiles == trueType {
-			torrentsLog.Info("Removing the torrent and deleting files from the web ...")
-			s.RemoveTorrent(torrent, true)
-		} else {
-			torrentsLog.Info("Removing the torrent without deleting files from the web ...")
-			s.RemoveTorrent(torrent, false)
-		}
+		removeTorrent(s, torrent, deleteAnswer == true || deleteFiles == trueType)
 
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
 
+// removeTorrent removes torrent from the session, together with its .torrent file
+func removeTorrent(s *bittorrent.Service, torrent *bittorrent.Torrent, deleteFiles bool) {
+	// Delete torrent file
+	infoHash := torrent.InfoHash()
+	if _, err := os.Stat(torrent.TorrentPath); err == nil {
+		torrentsLog.Infof("Removed torrent file %s", torrent.TorrentPath)
+		defer os.Remove(torrent.TorrentPath)
+	}
+
+	// Delete torrent file, in case it it not 'infohash' + '.torrent'
+	torrentsPath := config.Get().TorrentsPath
+	torrentFile := filepath.Join(torrentsPath, fmt.Sprintf("%s.torrent", infoHash))
+	if _, err := os.Stat(torrentFile); err == nil {
+		torrentsLog.Infof("Removed torrent file %s", torrentFile)
+		defer os.Remove(torrentFile)
+	}
+
+	torrentsLog.Infof("Removed %s from database", infoHash)
+
+	if deleteFiles {
+		torrentsLog.Info("Removing the torrent and deleting files from the web ...")
+		s.RemoveTorrent(torrent, true)
+	} else {
+		torrentsLog.Info("Removing the torrent without deleting files from the web ...")
+		s.RemoveTorrent(torrent, false)
+	}
+}
+
 // DownloadAllTorrent ...
 func DownloadAllTorrent(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -535,7 +579,6 @@ func DownloadAllTorrent(s *bittorrent.Service) gin.HandlerFunc {
 		torrent.DownloadAllFiles()
 
 		xbmc.Refresh()
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		ctx.String(200, "")
 	}
 }
@@ -553,11 +596,211 @@ func UnDownloadAllTorrent(s *bittorrent.Service) gin.HandlerFunc {
 		torrent.UnDownloadAllFiles()
 
 		xbmc.Refresh()
+		ctx.String(200, "")
+	}
+}
+
+// ListTorrentFilesWeb lists files of a torrent with their priorities,
+// chosen files go first in the order of the download sequence
+func ListTorrentFilesWeb(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		ctx.JSON(200, torrentFilesWebInfo(torrent))
+	}
+}
+
+func torrentFileFromParams(s *bittorrent.Service, ctx *gin.Context) (*bittorrent.Torrent, *bittorrent.File, error) {
+	torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index, err := strconv.Atoi(ctx.Params.ByName("fileIndex"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Wrong file index: %s", ctx.Params.ByName("fileIndex"))
+	}
+
+	file := torrent.GetFileByIndex(index)
+	if file == nil {
+		return nil, nil, fmt.Errorf("File with index %d not found", index)
+	}
+
+	return torrent, file, nil
+}
+
+func torrentFilesWebInfo(torrent *bittorrent.Torrent) []*TorrentFileWeb {
+	files := []*TorrentFileWeb{}
+	for _, f := range torrent.Files() {
+		files = append(files, &TorrentFileWeb{
+			Index:    f.Index,
+			Name:     f.Name,
+			Path:     f.Path,
+			Size:     f.Size,
+			Progress: torrent.GetFileProgress(f),
+			Priority: bittorrent.FilePriorityName(f.Priority),
+			Position: torrent.GetFilePosition(f),
+		})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if (files[i].Position == -1) != (files[j].Position == -1) {
+			return files[i].Position != -1
+		}
+		return files[i].Position < files[j].Position
+	})
+
+	return files
+}
+
+// queueActions are actions for queue dialog in Kodi, with their language string ids
+var queueActions = []struct {
+	action string
+	label  int
+}{
+	{bittorrent.QueueTop, 30736},
+	{bittorrent.QueueUp, 30737},
+	{bittorrent.QueueDown, 30738},
+	{bittorrent.QueueBottom, 30739},
+	{"force", 30740},
+	{"auto", 30741},
+}
+
+// QueueTorrent changes queue position of a torrent, or forces its start.
+// Without action in the path, action is chosen in a dialog.
+func QueueTorrent(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		action := ctx.Params.ByName("action")
+		if action == "" {
+			labels := make([]string, 0, len(queueActions))
+			for _, a := range queueActions {
+				labels = append(labels, xbmc.GetLocalizedString(a.label))
+			}
+
+			choice := xbmc.ListDialog("LOCALIZE[30735]", labels...)
+			if choice < 0 {
+				ctx.String(200, "")
+				return
+			}
+			action = queueActions[choice].action
+		}
+
+		switch action {
+		case "force":
+			err = s.ForceStart(torrent, true)
+		case "auto":
+			err = s.ForceStart(torrent, false)
+		default:
+			err = s.MoveInQueue(torrent, action)
+		}
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		xbmc.Refresh()
+		ctx.String(200, "")
+	}
+}
+
+// ListCategoriesWeb lists torrent categories from settings
+func ListCategoriesWeb(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
 		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		categories := s.GetCategories()
+		if categories == nil {
+			categories = []*bittorrent.Category{}
+		}
+		ctx.JSON(200, categories)
+	}
+}
+
+// SetTorrentCategory ...
+func SetTorrentCategory(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		category := ctx.Query("category")
+		if _, ok := ctx.Request.URL.Query()["category"]; !ok {
+			// Called from Kodi, so category is chosen in a dialog
+			names := []string{xbmc.GetLocalizedString(30733)}
+			for _, c := range s.GetCategories() {
+				names = append(names, c.Name)
+			}
+
+			choice := xbmc.ListDialog("LOCALIZE[30734]", names...)
+			if choice < 0 {
+				ctx.String(200, "")
+				return
+			} else if choice > 0 {
+				category = names[choice]
+			}
+		}
+
+		if err := s.SetTorrentCategory(torrent, category); err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		xbmc.Refresh()
 		ctx.String(200, "")
 	}
 }
 
+// ImportTorrents adds .torrent files from a folder, or from a state folder of
+// qBittorrent, Transmission or Deluge, with their existing data, and matches
+// them to TMDB items. Import runs in background, result is notified in Kodi.
+func ImportTorrents(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer ctx.String(200, "")
+
+		// Folder is chosen only in Kodi, since the HTTP server is reachable from the network
+		path := xbmc.BrowseSingle(xbmc.BrowseDirectory, "LOCALIZE[30743]", "files")
+		if path == "" {
+			return
+		}
+
+		items, err := bittorrent.FindImportItems(path, "")
+		if err != nil {
+			torrentsLog.Warningf("Could not read import folder %s: %s", path, err)
+			xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+			return
+		}
+
+		go func() {
+			imported, identified := 0, 0
+			for _, r := range s.ImportTorrents(items) {
+				if r.Error == "" {
+					imported++
+				}
+				if r.TMDBId != 0 {
+					identified++
+				}
+			}
+
+			torrentsLog.Infof("Imported %d of %d torrents from %s, identified %d", imported, len(items), path, identified)
+			xbmc.Notify("Elementum", fmt.Sprintf("LOCALIZE[30744];;%d;;%d;;%d", imported, len(items), identified), config.AddonIcon())
+			xbmc.Refresh()
+		}()
+	}
+}
+
 // Versions ...
 func Versions(s *bittorrent.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
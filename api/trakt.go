@@ -438,11 +438,14 @@ func renderTraktMovies(ctx *gin.Context, movies []*trakt.Movies, total int, page
 				collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/collection/remove", movieListing.Movie.IDs.TMDB))}
 			}
 
+			item.Info.UserRating = movieUserRating(movieListing.Movie.IDs.TMDB)
+
 			item.ContextMenu = [][]string{
 				watchlistAction,
 				collectionAction,
 				[]string{"LOCALIZE[30034]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/movies"))},
 			}
+			item.ContextMenu = append(item.ContextMenu, movieTraktActions(movieListing.Movie.IDs.TMDB)...)
 			item.ContextMenu = append(libraryActions, item.ContextMenu...)
 
 			if config.Get().Platform.Kodi < 17 {
@@ -662,11 +665,14 @@ func renderTraktShows(ctx *gin.Context, shows []*trakt.Shows, total int, page in
 			collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/collection/remove", showListing.Show.IDs.TMDB))}
 		}
 
+		item.Info.UserRating = showUserRating(showListing.Show.IDs.TMDB)
+
 		item.ContextMenu = [][]string{
 			watchlistAction,
 			collectionAction,
 			[]string{"LOCALIZE[30035]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/tvshows"))},
 		}
+		item.ContextMenu = append(item.ContextMenu, showTraktActions(showListing.Show.IDs.TMDB)...)
 		item.ContextMenu = append(libraryActions, item.ContextMenu...)
 
 		if config.Get().Platform.Kodi < 17 {
@@ -984,11 +990,14 @@ func renderCalendarMovies(ctx *gin.Context, movies []*trakt.CalendarMovie, total
 				collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/movie/%d/collection/remove", movieListing.Movie.IDs.TMDB))}
 			}
 
+			item.Info.UserRating = movieUserRating(movieListing.Movie.IDs.TMDB)
+
 			item.ContextMenu = [][]string{
 				watchlistAction,
 				collectionAction,
 				[]string{"LOCALIZE[30034]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/movies"))},
 			}
+			item.ContextMenu = append(item.ContextMenu, movieTraktActions(movieListing.Movie.IDs.TMDB)...)
 			item.ContextMenu = append(libraryActions, item.ContextMenu...)
 
 			if config.Get().Platform.Kodi < 17 {
@@ -1054,7 +1063,6 @@ func renderCalendarShows(ctx *gin.Context, shows []*trakt.CalendarShow, total in
 	colorUnaired := config.Get().TraktCalendarsColorUnaired
 	dateFormat := getCalendarsDateFormat()
 
-	now := util.UTCBod()
 	items := make(xbmc.ListItems, len(shows)+hasNextPage)
 
 	wg := sync.WaitGroup{}
@@ -1108,13 +1116,16 @@ func renderCalendarShows(ctx *gin.Context, shows []*trakt.CalendarShow, total in
 			if epi.FirstAired != "" {
 				airDate = epi.FirstAired
 			}
-			if len(airDate) > 10 {
-				airDate = airDate[0:strings.Index(airDate, "T")]
+			// Full timestamps are shown in local time, so episodes are listed
+			// on the day they air for the viewer
+			airTime := showListing.Show.AirTime(airDate)
+			if len(airDate) > 10 && !airTime.IsZero() {
+				airDate = airTime.Local().Format("2006-01-02")
 			}
 
 			aired, _ := time.Parse("2006-01-02", airDate)
 			localEpisodeColor := colorEpisode
-			if aired.After(now) || aired.Equal(now) {
+			if util.IsUnaired(airTime) {
 				localEpisodeColor = colorUnaired
 			}
 
@@ -1159,11 +1170,14 @@ func renderCalendarShows(ctx *gin.Context, shows []*trakt.CalendarShow, total in
 				collectionAction = []string{"LOCALIZE[30259]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/collection/remove", showListing.Show.IDs.TMDB))}
 			}
 
+			item.Info.UserRating = showUserRating(showListing.Show.IDs.TMDB)
+
 			item.ContextMenu = [][]string{
 				watchlistAction,
 				collectionAction,
 				[]string{"LOCALIZE[30035]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/tvshows"))},
 			}
+			item.ContextMenu = append(item.ContextMenu, showTraktActions(showListing.Show.IDs.TMDB)...)
 			item.ContextMenu = append(libraryActions, item.ContextMenu...)
 
 			if config.Get().Platform.Kodi < 17 {
@@ -1208,7 +1222,6 @@ func renderProgressShows(ctx *gin.Context, shows []*trakt.ProgressShow, total in
 	dateFormat := getProgressDateFormat()
 
 	items := make(xbmc.ListItems, len(shows))
-	now := util.UTCBod()
 
 	wg := sync.WaitGroup{}
 	wg.Add(len(shows))
@@ -1255,13 +1268,17 @@ func renderProgressShows(ctx *gin.Context, shows []*trakt.ProgressShow, total in
 				}
 			}
 
-			aired, errDate := time.Parse("2006-01-02", airDate)
-			if config.Get().TraktProgressUnaired && errDate == nil && (aired.After(now) || aired.Equal(now)) {
+			airTime := showListing.Show.AirTime(airDate)
+			if epi.FirstAired != "" {
+				airTime = showListing.Show.AirTime(epi.FirstAired)
+			}
+			if config.Get().TraktProgressUnaired && util.IsUnaired(airTime) {
 				return
 			}
 
+			aired, _ := time.Parse("2006-01-02", airDate)
 			localEpisodeColor := colorEpisode
-			if aired.After(now) || aired.Equal(now) {
+			if util.IsUnaired(airTime) {
 				localEpisodeColor = colorUnaired
 			}
 
@@ -0,0 +1,854 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bcrusher29/solaris/bittorrent"
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/util"
+	"github.com/bcrusher29/solaris/xbmc"
+)
+
+// REST API for scripts and dashboards. Unlike Kodi routes, it uses
+// proper HTTP verbs, requires authentication, allows cross-origin requests
+// only from configured origins and reports errors as JSON.
+
+const apiV1Prefix = "/api/v1"
+
+// APIRoute describes single API endpoint, used both for routing
+// and for the OpenAPI description
+type APIRoute struct {
+	Method   string
+	Path     string
+	Summary  string
+	Params   []APIParam
+	Body     interface{}
+	Response interface{}
+	Status   int
+	Handler  func(s *bittorrent.Service) gin.HandlerFunc
+}
+
+// APIParam describes path or query parameter of an endpoint
+type APIParam struct {
+	Name        string
+	In          string
+	Type        string
+	Description string
+}
+
+// APIError is a body of failed API response
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIVersion ...
+type APIVersion struct {
+	Version   string `json:"version"`
+	UserAgent string `json:"user_agent"`
+}
+
+// APIAddTorrent is a body of torrent add request,
+// torrent file can be sent instead as a multipart "file" field
+type APIAddTorrent struct {
+	URI      string `json:"uri"`
+	Paused   bool   `json:"paused"`
+	Category string `json:"category,omitempty"`
+}
+
+// APITorrentPatch is a body of torrent update request,
+// only specified fields are applied
+type APITorrentPatch struct {
+	Paused     *bool   `json:"paused,omitempty"`
+	Priority   *string `json:"priority,omitempty" enum:"all,none"`
+	Category   *string `json:"category,omitempty"`
+	Queue      *string `json:"queue,omitempty" enum:"up,down,top,bottom"`
+	ForceStart *bool   `json:"force_start,omitempty"`
+}
+
+// APIFilePatch is a body of torrent file update request,
+// position moves file in the download sequence
+type APIFilePatch struct {
+	Priority *string `json:"priority,omitempty" enum:"skip,low,normal,high"`
+	Position *int    `json:"position,omitempty"`
+}
+
+// APIImportTorrents is a body of torrents import request. Path is a folder
+// with .torrent files, or a state folder of qBittorrent, Transmission or Deluge.
+// Save path is used for torrents without resume data of other client.
+type APIImportTorrents struct {
+	Path     string `json:"path"`
+	SavePath string `json:"save_path,omitempty"`
+}
+
+// APISessionPatch is a body of session update request
+type APISessionPatch struct {
+	Paused *bool `json:"paused,omitempty"`
+}
+
+var torrentIDParam = APIParam{Name: "torrentId", In: "path", Type: "string", Description: "Torrent infohash"}
+var fileIndexParam = APIParam{Name: "fileIndex", In: "path", Type: "integer", Description: "File index in the torrent"}
+
+var apiV1Routes = []*APIRoute{
+	{
+		Method:   "GET",
+		Path:     "/version",
+		Summary:  "Daemon version",
+		Response: APIVersion{},
+		Handler:  apiVersion,
+	},
+	{
+		Method:   "GET",
+		Path:     "/session",
+		Summary:  "Session pause state, bandwidth limits and active schedule window",
+		Response: bittorrent.SessionStatus{},
+		Handler:  apiGetSession,
+	},
+	{
+		Method:   "PATCH",
+		Path:     "/session",
+		Summary:  "Pause or resume all torrents",
+		Body:     APISessionPatch{},
+		Response: bittorrent.SessionStatus{},
+		Handler:  apiPatchSession,
+	},
+	{
+		Method:  "GET",
+		Path:    "/events",
+		Summary: "Stream of torrent, player, alert and library events, as server-sent events",
+		Params: []APIParam{
+			{Name: "types", In: "query", Type: "string", Description: "Event types to receive, separated by commas"},
+		},
+		Handler: eventsStream,
+	},
+	{
+		Method:   "GET",
+		Path:     "/torrents",
+		Summary:  "List torrents in the order of queue positions",
+		Response: []TorrentsWeb{},
+		Handler:  apiListTorrents,
+	},
+	{
+		Method:   "POST",
+		Path:     "/torrents",
+		Summary:  "Add torrent by URI or from uploaded .torrent file",
+		Body:     APIAddTorrent{},
+		Response: TorrentsWeb{},
+		Status:   http.StatusCreated,
+		Handler:  apiAddTorrent,
+	},
+	{
+		Method:   "POST",
+		Path:     "/import",
+		Summary:  "Start import of torrents with existing data from a folder or other client's state",
+		Body:     APIImportTorrents{},
+		Response: bittorrent.ImportJob{},
+		Status:   http.StatusAccepted,
+		Handler:  apiImportTorrents,
+	},
+	{
+		Method:   "GET",
+		Path:     "/import/:jobId",
+		Summary:  "Get progress and results of a recent import",
+		Params:   []APIParam{{Name: "jobId", In: "path", Type: "integer", Description: "Import job ID"}},
+		Response: bittorrent.ImportJob{},
+		Handler:  apiGetImportJob,
+	},
+	{
+		Method:   "GET",
+		Path:     "/categories",
+		Summary:  "List torrent categories with their seeding policies",
+		Response: []bittorrent.Category{},
+		Handler:  apiListCategories,
+	},
+	{
+		Method:   "GET",
+		Path:     "/watchfolders",
+		Summary:  "List watch folders, where dropped torrent and magnet files are added",
+		Response: []bittorrent.WatchFolder{},
+		Handler:  apiListWatchFolders,
+	},
+	{
+		Method:   "GET",
+		Path:     "/torrents/:torrentId",
+		Summary:  "Get torrent status",
+		Params:   []APIParam{torrentIDParam},
+		Response: TorrentsWeb{},
+		Handler:  apiGetTorrent,
+	},
+	{
+		Method:   "PATCH",
+		Path:     "/torrents/:torrentId",
+		Summary:  "Pause or resume torrent, change files priority, category or queue position",
+		Params:   []APIParam{torrentIDParam},
+		Body:     APITorrentPatch{},
+		Response: TorrentsWeb{},
+		Handler:  apiPatchTorrent,
+	},
+	{
+		Method:  "DELETE",
+		Path:    "/torrents/:torrentId",
+		Summary: "Remove torrent",
+		Params: []APIParam{
+			torrentIDParam,
+			{Name: "files", In: "query", Type: "boolean", Description: "Delete downloaded files"},
+		},
+		Status:  http.StatusNoContent,
+		Handler: apiRemoveTorrent,
+	},
+	{
+		Method:   "GET",
+		Path:     "/torrents/:torrentId/files",
+		Summary:  "List torrent files with progress, priority and position in the download sequence",
+		Params:   []APIParam{torrentIDParam},
+		Response: []TorrentFileWeb{},
+		Handler:  apiListTorrentFiles,
+	},
+	{
+		Method:   "PATCH",
+		Path:     "/torrents/:torrentId/files/:fileIndex",
+		Summary:  "Change file priority or position in the download sequence",
+		Params:   []APIParam{torrentIDParam, fileIndexParam},
+		Body:     APIFilePatch{},
+		Response: []TorrentFileWeb{},
+		Handler:  apiPatchTorrentFile,
+	},
+	{
+		Method:  "GET",
+		Path:    "/history/sessions",
+		Summary: "List recorded playback sessions, recent first",
+		Params: []APIParam{
+			{Name: "infohash", In: "query", Type: "string", Description: "Sessions of a torrent"},
+			{Name: "provider", In: "query", Type: "string", Description: "Sessions of torrents from a provider"},
+			{Name: "limit", In: "query", Type: "integer", Description: "Number of sessions, 50 by default, 500 at most"},
+			{Name: "offset", In: "query", Type: "integer", Description: "Number of skipped sessions"},
+		},
+		Response: []database.PlaybackSession{},
+		Handler:  apiListPlaybackSessions,
+	},
+	{
+		Method:   "GET",
+		Path:     "/history/sessions/:sessionId",
+		Summary:  "Get playback session with its stalls, seeks and peers samples",
+		Params:   []APIParam{{Name: "sessionId", In: "path", Type: "integer", Description: "Playback session ID"}},
+		Response: database.PlaybackSession{},
+		Handler:  apiGetPlaybackSession,
+	},
+}
+
+var apiPathParamRe = regexp.MustCompile(`:(\w+)`)
+
+// APIRoutes registers REST API endpoints
+func APIRoutes(r *gin.Engine, s *bittorrent.Service) {
+	v1 := r.Group(apiV1Prefix)
+	v1.Use(apiCORS())
+
+	// Description of the API is public, so tools can discover it
+	v1.GET("/openapi.json", apiOpenAPI)
+
+	preflight := map[string]bool{}
+	for _, route := range apiV1Routes {
+		v1.Handle(route.Method, route.Path, apiAuth(), route.Handler(s))
+		if !preflight[route.Path] {
+			v1.OPTIONS(route.Path, apiPreflight)
+			preflight[route.Path] = true
+		}
+	}
+}
+
+// apiAuth checks API token, passed in Authorization or X-Api-Token header,
+// or basic auth credentials. API is disabled, until one of them is configured.
+func apiAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		conf := config.Get()
+		if conf.APIToken == "" && conf.APIUsername == "" {
+			apiError(ctx, http.StatusForbidden, errors.New("API is disabled, configure API token or credentials in settings"))
+			return
+		}
+
+		if hasCredentials(ctx, false) {
+			ctx.Next()
+			return
+		}
+		if conf.APIUsername != "" {
+			ctx.Writer.Header().Set("WWW-Authenticate", `Basic realm="Elementum"`)
+		}
+		apiError(ctx, http.StatusUnauthorized, errors.New("Unauthorized"))
+	}
+}
+
+// KodiAuth protects Kodi routes, that change state. Kodi calls them from
+// the same device and without browser headers, other clients need API token,
+// in headers or in token query parameter, or basic auth credentials,
+// so web pages can't trigger them with forged requests.
+func KodiAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if isKodiRequest(ctx.Request) || hasCredentials(ctx, true) {
+			ctx.Next()
+			return
+		}
+
+		if config.Get().APIUsername != "" {
+			ctx.Writer.Header().Set("WWW-Authenticate", `Basic realm="Elementum"`)
+		}
+		ctx.String(http.StatusUnauthorized, "Unauthorized")
+		ctx.Abort()
+	}
+}
+
+// isKodiRequest checks whether request comes from loopback address and not
+// from a browser, browsers send Origin, Referer or Sec-Fetch-Site headers
+func isKodiRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return false
+	}
+
+	return r.Header.Get("Origin") == "" && r.Header.Get("Referer") == "" && r.Header.Get("Sec-Fetch-Site") == ""
+}
+
+// hasCredentials checks configured API token, passed in Authorization
+// or X-Api-Token header, or in token query parameter, when query is allowed,
+// and basic auth credentials
+func hasCredentials(ctx *gin.Context, query bool) bool {
+	conf := config.Get()
+	if conf.APIToken != "" {
+		token := ctx.Request.Header.Get("X-Api-Token")
+		if auth := ctx.Request.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if token == "" && query {
+			token = ctx.Query("token")
+		}
+		if token != "" && secureCompare(token, conf.APIToken) {
+			return true
+		}
+	}
+
+	if conf.APIUsername != "" {
+		if user, password, ok := ctx.Request.BasicAuth(); ok && secureCompare(user, conf.APIUsername) && secureCompare(password, conf.APIPassword) {
+			return true
+		}
+	}
+	return false
+}
+
+func secureCompare(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
+
+// apiCORS allows cross-origin requests only from the same host and from
+// configured origins. Requests from other origins are rejected before
+// reaching handlers, so web pages can't use browser's saved credentials.
+func apiCORS() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		origin := ctx.Request.Header.Get("Origin")
+		if origin == "" {
+			ctx.Next()
+			return
+		}
+
+		if !apiOriginAllowed(origin, ctx.Request.Host) {
+			apiError(ctx, http.StatusForbidden, fmt.Errorf("Origin %s is not allowed", origin))
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Set("Access-Control-Allow-Credentials", "true")
+		header.Add("Vary", "Origin")
+		ctx.Next()
+	}
+}
+
+func apiPreflight(ctx *gin.Context) {
+	header := ctx.Writer.Header()
+	header.Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+	header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Api-Token")
+	header.Set("Access-Control-Max-Age", "600")
+	ctx.AbortWithStatus(http.StatusNoContent)
+}
+
+func apiOriginAllowed(origin string, host string) bool {
+	if u, err := url.Parse(origin); err == nil && u.Host == host {
+		return true
+	}
+
+	for _, o := range strings.Split(config.Get().APIAllowedOrigins, ",") {
+		if o = strings.TrimRight(strings.TrimSpace(o), "/"); o != "" && strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func apiError(ctx *gin.Context, code int, err error) {
+	ctx.JSON(code, APIError{Code: code, Message: err.Error()})
+	ctx.Abort()
+}
+
+func apiVersion(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, APIVersion{
+			Version:   util.GetVersion(),
+			UserAgent: s.UserAgent,
+		})
+	}
+}
+
+func apiGetSession(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, s.GetSessionStatus())
+	}
+}
+
+func apiPatchSession(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		req := APISessionPatch{}
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if req.Paused != nil {
+			if *req.Paused {
+				s.PauseSession()
+			} else {
+				s.ResumeSession()
+			}
+		}
+
+		xbmc.Refresh()
+		ctx.JSON(http.StatusOK, s.GetSessionStatus())
+	}
+}
+
+func apiListTorrents(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrents := []*TorrentsWeb{}
+		if !s.Closer.IsSet() {
+			torrents = torrentsWebInfo(s)
+		}
+
+		ctx.JSON(http.StatusOK, torrents)
+	}
+}
+
+func apiListCategories(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		categories := s.GetCategories()
+		if categories == nil {
+			categories = []*bittorrent.Category{}
+		}
+		ctx.JSON(http.StatusOK, categories)
+	}
+}
+
+func apiListWatchFolders(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		folders := s.GetWatchFolders()
+		if folders == nil {
+			folders = []*bittorrent.WatchFolder{}
+		}
+		ctx.JSON(http.StatusOK, folders)
+	}
+}
+
+func apiGetTorrent(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		apiTorrentResponse(ctx, http.StatusOK, torrent)
+	}
+}
+
+func apiAddTorrent(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		req := APIAddTorrent{}
+		if strings.HasPrefix(ctx.Request.Header.Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+				apiError(ctx, http.StatusBadRequest, err)
+				return
+			}
+		} else {
+			req.URI = ctx.Request.FormValue("uri")
+			req.Paused = ctx.Request.FormValue("paused") == trueType
+			req.Category = ctx.Request.FormValue("category")
+
+			if file, header, err := ctx.Request.FormFile("file"); err == nil {
+				path, err := saveTorrentFile(file, header)
+				if err != nil {
+					apiError(ctx, http.StatusBadRequest, err)
+					return
+				}
+				req.URI = path
+			}
+		}
+
+		if req.URI == "" {
+			apiError(ctx, http.StatusBadRequest, errors.New("Missing torrent URI or file"))
+			return
+		}
+
+		torrentsLog.Infof("Adding torrent from %s with API", req.URI)
+		torrent, err := s.AddTorrent(req.URI, req.Paused, req.Category)
+		if err != nil {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		xbmc.Refresh()
+		apiTorrentResponse(ctx, http.StatusCreated, torrent)
+	}
+}
+
+func apiImportTorrents(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		req := APIImportTorrents{}
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		} else if req.Path == "" {
+			apiError(ctx, http.StatusBadRequest, errors.New("Missing import path"))
+			return
+		}
+
+		items, err := bittorrent.FindImportItems(req.Path, req.SavePath)
+		if err != nil {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		torrentsLog.Infof("Importing %d torrents from %s with API", len(items), req.Path)
+		job := s.StartImport(req.Path, items)
+
+		ctx.Header("Location", fmt.Sprintf("%s/import/%d", apiV1Prefix, job.ID))
+		ctx.JSON(http.StatusAccepted, job)
+	}
+}
+
+func apiGetImportJob(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Params.ByName("jobId"))
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("Wrong import job ID: %s", ctx.Params.ByName("jobId")))
+			return
+		}
+
+		job := bittorrent.GetImportJob(id)
+		if job == nil {
+			apiError(ctx, http.StatusNotFound, fmt.Errorf("Import job %d not found", id))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, job)
+	}
+}
+
+func apiPatchTorrent(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		req := APITorrentPatch{}
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if req.Priority != nil && *req.Priority != "all" && *req.Priority != "none" {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("Unknown priority %q, expected 'all' or 'none'", *req.Priority))
+			return
+		}
+
+		if req.Category != nil {
+			if err := s.SetTorrentCategory(torrent, *req.Category); err != nil {
+				apiError(ctx, http.StatusBadRequest, err)
+				return
+			}
+		}
+		if req.Queue != nil {
+			if err := s.MoveInQueue(torrent, *req.Queue); err != nil {
+				apiError(ctx, http.StatusBadRequest, err)
+				return
+			}
+		}
+		if req.ForceStart != nil {
+			if err := s.ForceStart(torrent, *req.ForceStart); err != nil {
+				apiError(ctx, http.StatusBadRequest, err)
+				return
+			}
+		}
+		if req.Paused != nil {
+			if *req.Paused {
+				torrent.Pause()
+			} else {
+				torrent.Resume()
+			}
+		}
+		if req.Priority != nil {
+			if *req.Priority == "all" {
+				torrent.DownloadAllFiles()
+			} else {
+				torrent.UnDownloadAllFiles()
+			}
+		}
+
+		xbmc.Refresh()
+		apiTorrentResponse(ctx, http.StatusOK, torrent)
+	}
+}
+
+func apiRemoveTorrent(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		removeTorrent(s, torrent, ctx.Query("files") == trueType)
+
+		xbmc.Refresh()
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+func apiListTorrentFiles(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, err := GetTorrentFromParam(s, ctx.Params.ByName("torrentId"))
+		if err != nil {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, torrentFilesWebInfo(torrent))
+	}
+}
+
+func apiPatchTorrentFile(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		torrent, file, err := torrentFileFromParams(s, ctx)
+		if err != nil {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		req := APIFilePatch{}
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if req.Priority != nil {
+			priority, err := bittorrent.ParseFilePriority(*req.Priority)
+			if err != nil {
+				apiError(ctx, http.StatusBadRequest, err)
+				return
+			}
+			torrent.SetFilePriority(file, priority)
+		}
+		if req.Position != nil {
+			if err := torrent.MoveFile(file, *req.Position); err != nil {
+				apiError(ctx, http.StatusConflict, err)
+				return
+			}
+		}
+
+		xbmc.Refresh()
+		ctx.JSON(http.StatusOK, torrentFilesWebInfo(torrent))
+	}
+}
+
+func apiListPlaybackSessions(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit, err := strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(playbackSessionsPerPage)))
+		if err != nil || limit < 1 || limit > playbackSessionsMaxLimit {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("Bad limit '%s'", ctx.Query("limit")))
+			return
+		}
+		offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+		if err != nil || offset < 0 {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("Bad offset '%s'", ctx.Query("offset")))
+			return
+		}
+
+		sessions := database.Get().GetPlaybackSessions(ctx.Query("infohash"), ctx.Query("provider"), limit, offset)
+		if sessions == nil {
+			sessions = []*database.PlaybackSession{}
+		}
+		ctx.JSON(http.StatusOK, sessions)
+	}
+}
+
+func apiGetPlaybackSession(s *bittorrent.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Params.ByName("sessionId"), 10, 64)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("Bad session ID '%s'", ctx.Params.ByName("sessionId")))
+			return
+		}
+
+		session := database.Get().GetPlaybackSession(id)
+		if session == nil {
+			apiError(ctx, http.StatusNotFound, errors.New("Playback session not found"))
+			return
+		}
+		ctx.JSON(http.StatusOK, session)
+	}
+}
+
+func apiTorrentResponse(ctx *gin.Context, code int, torrent *bittorrent.Torrent) {
+	if ti := torrentWebInfo(torrent); ti != nil {
+		ctx.JSON(code, ti)
+	} else {
+		ctx.JSON(code, &TorrentsWeb{ID: torrent.InfoHash()})
+	}
+}
+
+func apiOpenAPI(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, OpenAPI())
+}
+
+// OpenAPI builds OpenAPI 3 description of the REST API from its route table
+func OpenAPI() map[string]interface{} {
+	paths := map[string]map[string]interface{}{}
+	for _, route := range apiV1Routes {
+		path := apiPathParamRe.ReplaceAllString(route.Path, "{$1}")
+		if _, ok := paths[path]; !ok {
+			paths[path] = map[string]interface{}{}
+		}
+
+		params := []map[string]interface{}{}
+		for _, p := range route.Params {
+			params = append(params, map[string]interface{}{
+				"name":        p.Name,
+				"in":          p.In,
+				"required":    p.In == "path",
+				"description": p.Description,
+				"schema":      map[string]interface{}{"type": p.Type},
+			})
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := map[string]interface{}{"description": http.StatusText(status)}
+		if route.Response != nil {
+			response["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": apiSchema(reflect.TypeOf(route.Response))},
+			}
+		}
+
+		operation := map[string]interface{}{
+			"summary":    route.Summary,
+			"parameters": params,
+			"responses": map[string]interface{}{
+				fmt.Sprint(status): response,
+				"default": map[string]interface{}{
+					"description": "Error",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": apiSchema(reflect.TypeOf(APIError{}))},
+					},
+				},
+			},
+		}
+		if route.Body != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": apiSchema(reflect.TypeOf(route.Body))},
+				},
+			}
+		}
+		operation["security"] = []map[string][]string{{"token": {}}, {"basic": {}}}
+
+		paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "Elementum API",
+			"version": util.GetVersion(),
+		},
+		"servers": []map[string]string{{"url": apiV1Prefix}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"token": map[string]string{"type": "http", "scheme": "bearer"},
+				"basic": map[string]string{"type": "http", "scheme": "basic"},
+			},
+		},
+	}
+}
+
+// apiSchema describes Go type as a JSON schema, using json tags for names
+func apiSchema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": apiSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": apiSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "-" || f.PkgPath != "" {
+				continue
+			} else if name == "" {
+				name = f.Name
+			}
+
+			schema := apiSchema(f.Type)
+			if enum := f.Tag.Get("enum"); enum != "" {
+				schema["enum"] = strings.Split(enum, ",")
+			}
+			properties[name] = schema
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	}
+
+	return map[string]interface{}{}
+}
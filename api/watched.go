@@ -0,0 +1,322 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/playcount"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Watch history is exported in the format of Trakt's sync/watched,
+// so it can be moved between Trakt and local history
+type watchedExport struct {
+	Movies []*watchedEntry `json:"movies"`
+	Shows  []*watchedEntry `json:"shows"`
+}
+
+type watchedEntry struct {
+	Plays         int              `json:"plays"`
+	LastWatchedAt time.Time        `json:"last_watched_at"`
+	Movie         *watchedMedia    `json:"movie,omitempty"`
+	Show          *watchedMedia    `json:"show,omitempty"`
+	Seasons       []*watchedSeason `json:"seasons,omitempty"`
+}
+
+type watchedMedia struct {
+	Title string     `json:"title,omitempty"`
+	Year  int        `json:"year,omitempty"`
+	IDs   watchedIDs `json:"ids"`
+}
+
+type watchedIDs struct {
+	Trakt int    `json:"trakt,omitempty"`
+	TMDB  int    `json:"tmdb"`
+	IMDB  string `json:"imdb,omitempty"`
+	TVDB  int    `json:"tvdb,omitempty"`
+}
+
+type watchedSeason struct {
+	Number   int               `json:"number"`
+	Episodes []*watchedEpisode `json:"episodes"`
+}
+
+type watchedEpisode struct {
+	Number        int       `json:"number"`
+	Plays         int       `json:"plays"`
+	LastWatchedAt time.Time `json:"last_watched_at"`
+}
+
+// MarkMovieWatched ...
+func MarkMovieWatched(ctx *gin.Context) {
+	markWatched(ctx, playcount.MovieType, true)
+}
+
+// MarkMovieUnwatched ...
+func MarkMovieUnwatched(ctx *gin.Context) {
+	markWatched(ctx, playcount.MovieType, false)
+}
+
+// MarkEpisodeWatched ...
+func MarkEpisodeWatched(ctx *gin.Context) {
+	markWatched(ctx, playcount.EpisodeType, true)
+}
+
+// MarkEpisodeUnwatched ...
+func MarkEpisodeUnwatched(ctx *gin.Context) {
+	markWatched(ctx, playcount.EpisodeType, false)
+}
+
+func markWatched(ctx *gin.Context, mediaType int, watched bool) {
+	if mediaType == playcount.MovieType {
+		tmdbID, _ := strconv.Atoi(ctx.Params.ByName("tmdbId"))
+		playcount.SetLocalMovie(tmdbID, watched)
+	} else {
+		showID, _ := strconv.Atoi(ctx.Params.ByName("showId"))
+		season, _ := strconv.Atoi(ctx.Params.ByName("season"))
+		episode, _ := strconv.Atoi(ctx.Params.ByName("episode"))
+		playcount.SetLocalEpisode(showID, season, episode, watched)
+	}
+
+	xbmc.Refresh()
+	ctx.String(200, "")
+}
+
+// ContinueWatchingMovies lists movies with a resume point in local watch history
+func ContinueWatchingMovies(ctx *gin.Context) {
+	ids := []int{}
+	for _, item := range database.Get().GetInProgressItems(playcount.MovieType) {
+		ids = append(ids, item.TMDB)
+	}
+
+	renderMovies(ctx, tmdb.GetMovies(ids, config.Get().Language), -1, 0, "")
+}
+
+// ContinueWatchingShows lists episodes with a resume point in local watch history
+func ContinueWatchingShows(ctx *gin.Context) {
+	language := config.Get().Language
+	inProgress := database.Get().GetInProgressItems(playcount.EpisodeType)
+
+	items := make(xbmc.ListItems, 0, len(inProgress))
+	for _, w := range inProgress {
+		show := tmdb.GetShow(w.TMDB, language)
+		season := tmdb.GetSeason(w.TMDB, w.Season, language)
+		episode := tmdb.GetEpisode(w.TMDB, w.Season, w.Episode, language)
+		if show == nil || season == nil || episode == nil {
+			continue
+		}
+
+		item := episode.ToListItem(show, season)
+		item.Label = fmt.Sprintf("%s - %dx%02d %s (%d%%)", show.Name, w.Season, w.Episode, episode.Name, int(w.Progress()))
+		item.Info.Title = item.Label
+
+		thisURL := URLForXBMC("/show/%d/season/%d/episode/%d/", w.TMDB, w.Season, w.Episode) + "%s/%s"
+		contextLabel := playLabel
+		contextTitle := fmt.Sprintf("%s S%dE%d", show.OriginalName, w.Season, w.Episode)
+		contextURL := contextPlayOppositeURL(thisURL, contextTitle, false)
+		if config.Get().ChooseStreamAuto {
+			contextLabel = linksLabel
+		}
+
+		item.Path = contextPlayURL(thisURL, contextTitle, false)
+		item.ContextMenu = [][]string{
+			[]string{contextLabel, fmt.Sprintf("XBMC.PlayMedia(%s)", contextURL)},
+			[]string{"LOCALIZE[30726]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/show/%d/season/%d/episode/%d/watched", w.TMDB, w.Season, w.Episode))},
+			[]string{"LOCALIZE[30037]", fmt.Sprintf("XBMC.RunPlugin(%s)", URLForXBMC("/setviewmode/episodes"))},
+		}
+		item.IsPlayable = true
+		items = append(items, item)
+	}
+
+	ctx.JSON(200, xbmc.NewView("episodes", filterListItems(items)))
+}
+
+// WatchedExport saves local watch history into a Trakt compatible JSON file
+func WatchedExport(ctx *gin.Context) {
+	defer ctx.String(200, "")
+
+	path := watchedFilePath("LOCALIZE[30728]")
+	if path == "" {
+		return
+	}
+
+	export := buildWatchedExport(database.Get().GetWatchedItems())
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		log.Warningf("Could not export watch history: %s", err)
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+		return
+	}
+
+	xbmc.Notify("Elementum", fmt.Sprintf("LOCALIZE[30730];;%d;;%d", len(export.Movies), len(export.Shows)), config.AddonIcon())
+}
+
+// WatchedImport reads Trakt compatible JSON file into local watch history.
+// File can be an export of local history, or Trakt's watched movies
+// or watched shows list.
+func WatchedImport(ctx *gin.Context) {
+	defer ctx.String(200, "")
+
+	path := watchedFilePath("LOCALIZE[30729]")
+	if path == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+		return
+	}
+
+	var entries []*watchedEntry
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		export := &watchedExport{}
+		if err = json.Unmarshal(data, export); err == nil {
+			entries = append(export.Movies, export.Shows...)
+		}
+	}
+	if err != nil {
+		log.Warningf("Could not parse watch history from %s: %s", path, err)
+		xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
+		return
+	}
+
+	imported, skipped := 0, 0
+	for _, item := range watchedItemsFromEntries(entries) {
+		if item.TMDB == 0 {
+			skipped++
+			continue
+		}
+		if err := database.Get().ImportWatchedItem(item); err != nil {
+			log.Warningf("Could not import watched item %#v: %s", item, err)
+			skipped++
+			continue
+		}
+		imported++
+	}
+
+	playcount.LoadLocal()
+	log.Infof("Imported %d watched items from %s, skipped %d", imported, path, skipped)
+	xbmc.Notify("Elementum", fmt.Sprintf("LOCALIZE[30731];;%d;;%d", imported, skipped), config.AddonIcon())
+}
+
+// watchedFilePath asks the user for a file path, it is never taken from request,
+// since the HTTP server is reachable from the network
+func watchedFilePath(title string) string {
+	return xbmc.Keyboard(filepath.Join(config.Get().ProfilePath, "watched_history.json"), title)
+}
+
+func buildWatchedExport(items []*database.WatchedItem) *watchedExport {
+	export := &watchedExport{
+		Movies: []*watchedEntry{},
+		Shows:  []*watchedEntry{},
+	}
+
+	shows := map[int]*watchedEntry{}
+	seasons := map[string]*watchedSeason{}
+	for _, w := range items {
+		if w.State != database.WatchedYes {
+			continue
+		}
+
+		if w.MediaType == playcount.MovieType {
+			export.Movies = append(export.Movies, &watchedEntry{
+				Plays:         w.Plays,
+				LastWatchedAt: w.LastWatched.UTC(),
+				Movie:         &watchedMedia{IDs: watchedIDs{TMDB: w.TMDB}},
+			})
+			continue
+		} else if w.MediaType != playcount.EpisodeType {
+			continue
+		}
+
+		show, ok := shows[w.TMDB]
+		if !ok {
+			show = &watchedEntry{Show: &watchedMedia{IDs: watchedIDs{TMDB: w.TMDB}}}
+			shows[w.TMDB] = show
+			export.Shows = append(export.Shows, show)
+		}
+
+		seasonKey := fmt.Sprintf("%d_%d", w.TMDB, w.Season)
+		season, ok := seasons[seasonKey]
+		if !ok {
+			season = &watchedSeason{Number: w.Season, Episodes: []*watchedEpisode{}}
+			seasons[seasonKey] = season
+			show.Seasons = append(show.Seasons, season)
+		}
+
+		season.Episodes = append(season.Episodes, &watchedEpisode{
+			Number:        w.Episode,
+			Plays:         w.Plays,
+			LastWatchedAt: w.LastWatched.UTC(),
+		})
+		show.Plays += w.Plays
+		if w.LastWatched.After(show.LastWatchedAt) {
+			show.LastWatchedAt = w.LastWatched.UTC()
+		}
+	}
+
+	for _, show := range export.Shows {
+		sort.Slice(show.Seasons, func(i, j int) bool {
+			return show.Seasons[i].Number < show.Seasons[j].Number
+		})
+	}
+
+	return export
+}
+
+func watchedItemsFromEntries(entries []*watchedEntry) (items []*database.WatchedItem) {
+	for _, e := range entries {
+		if e == nil {
+			continue
+		}
+
+		if e.Movie != nil {
+			items = append(items, &database.WatchedItem{
+				MediaType:   playcount.MovieType,
+				TMDB:        e.Movie.IDs.TMDB,
+				Plays:       watchedPlays(e.Plays),
+				LastWatched: e.LastWatchedAt,
+			})
+		} else if e.Show != nil {
+			for _, s := range e.Seasons {
+				for _, ep := range s.Episodes {
+					items = append(items, &database.WatchedItem{
+						MediaType:   playcount.EpisodeType,
+						TMDB:        e.Show.IDs.TMDB,
+						Season:      s.Number,
+						Episode:     ep.Number,
+						Plays:       watchedPlays(ep.Plays),
+						LastWatched: ep.LastWatchedAt,
+					})
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// watchedPlays makes sure imported item counts at least as a single play
+func watchedPlays(plays int) int {
+	if plays < 1 {
+		return 1
+	}
+	return plays
+}
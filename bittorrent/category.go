@@ -0,0 +1,279 @@
+package bittorrent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/bencode"
+
+	"github.com/bcrusher29/solaris/database"
+)
+
+// Torrent categories are defined as a list, separated by ';'. Each category is
+//   <name>: <key>=<value>, <key>=<value>, ...
+// with keys:
+//   trackers    - tracker hosts, separated by spaces, to assign category automatically
+//   share_ratio - share ratio limit, in percents
+//   time_ratio  - seeding time ratio limit, in percents
+//   seed_time   - seeding time limit, in hours
+//   upload      - upload limit, in KB/s
+//   path        - save path for new torrents
+//   move        - target folder to move completed downloads to
+// Limits, that are not set, are taken from global settings, 0 means no limit.
+// Example: "private: trackers=tracker.example.org, share_ratio=200, seed_time=168; kids: move=/media/kids"
+
+// Category is a seeding policy for a group of torrents
+type Category struct {
+	Name               string   `json:"name"`
+	Trackers           []string `json:"trackers"`
+	ShareRatioLimit    int      `json:"share_ratio_limit"`
+	SeedTimeRatioLimit int      `json:"seed_time_ratio_limit"`
+	SeedTimeLimit      int      `json:"seed_time_limit"`
+	UploadLimit        int      `json:"upload_limit"`
+	SavePath           string   `json:"save_path"`
+	MovePath           string   `json:"move_path"`
+}
+
+// ParseCategories parses torrent categories string, using global
+// configuration for limits, that are not set in categories
+func (s *Service) ParseCategories(spec string) (categories []*Category, err error) {
+	names := map[string]bool{}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		c, err := s.parseCategory(part)
+		if err != nil {
+			return nil, fmt.Errorf("Bad category '%s': %s", part, err)
+		} else if names[c.Name] {
+			return nil, fmt.Errorf("Duplicate category '%s'", c.Name)
+		}
+		names[c.Name] = true
+		categories = append(categories, c)
+	}
+
+	return
+}
+
+func (s *Service) parseCategory(spec string) (*Category, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	c := s.defaultPolicy()
+	c.Name = strings.TrimSpace(parts[0])
+	if c.Name == "" {
+		return nil, fmt.Errorf("missing name")
+	} else if len(parts) == 1 {
+		return c, nil
+	}
+
+	for _, option := range strings.Split(parts[1], ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("expected '<key>=<value>' in '%s'", option)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+
+		switch key {
+		case "trackers":
+			c.Trackers = strings.Fields(strings.ToLower(value))
+			continue
+		case "path":
+			c.SavePath = value
+			continue
+		case "move":
+			c.MovePath = value
+			continue
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("bad value '%s' for '%s'", value, key)
+		}
+		switch key {
+		case "share_ratio":
+			c.ShareRatioLimit = number
+		case "time_ratio":
+			c.SeedTimeRatioLimit = number
+		case "seed_time":
+			c.SeedTimeLimit = number * 3600
+		case "upload":
+			c.UploadLimit = number * 1024
+		default:
+			return nil, fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+
+	return c, nil
+}
+
+// GetCategories returns categories from current configuration
+func (s *Service) GetCategories() []*Category {
+	categories, err := s.ParseCategories(s.config.TorrentCategories)
+	if err != nil {
+		log.Warningf("Could not parse torrent categories: %s", err)
+	}
+	return categories
+}
+
+// GetCategory returns category by its name, or nil
+func (s *Service) GetCategory(name string) *Category {
+	for _, c := range s.GetCategories() {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// categoryForTrackers returns first category, that lists host of one of the trackers
+func (s *Service) categoryForTrackers(trackers []string) *Category {
+	hosts := map[string]bool{}
+	for _, tracker := range trackers {
+		if u, err := url.Parse(tracker); err == nil && u.Hostname() != "" {
+			hosts[strings.ToLower(u.Hostname())] = true
+		}
+	}
+
+	for _, c := range s.GetCategories() {
+		for _, host := range c.Trackers {
+			if hosts[host] {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// defaultPolicy returns seeding policy from global configuration
+func (s *Service) defaultPolicy() *Category {
+	return &Category{
+		ShareRatioLimit:    s.config.ShareRatioLimit,
+		SeedTimeRatioLimit: s.config.SeedTimeRatioLimit,
+		SeedTimeLimit:      s.config.SeedTimeLimit,
+	}
+}
+
+// resolvePolicy finds seeding policy for a torrent, that is being added.
+// Explicit category goes first, then stored one, then the category,
+// matched by tracker host. Stored category is refreshed from configuration,
+// so changed settings are applied to existing torrents.
+func (s *Service) resolvePolicy(infoHash string, category string, trackers []string) (*Category, error) {
+	if category != "" {
+		c := s.GetCategory(category)
+		if c == nil {
+			return nil, fmt.Errorf("Unknown torrent category: %s", category)
+		}
+		s.storePolicy(infoHash, c)
+		return c, nil
+	}
+
+	if item := database.Get().GetBTItem(infoHash); item != nil && item.Category != "" {
+		if c := s.GetCategory(item.Category); c != nil {
+			s.storePolicy(infoHash, c)
+			return c, nil
+		}
+
+		c := &Category{}
+		if err := json.Unmarshal([]byte(item.Policy), c); err != nil {
+			log.Warningf("Could not read stored policy of %s: %s", infoHash, err)
+			return nil, nil
+		}
+		return c, nil
+	}
+
+	if c := s.categoryForTrackers(trackers); c != nil {
+		log.Infof("Assigning category %s to %s by tracker", c.Name, infoHash)
+		s.storePolicy(infoHash, c)
+		return c, nil
+	}
+
+	return nil, nil
+}
+
+func (s *Service) storePolicy(infoHash string, c *Category) error {
+	policy, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return database.Get().UpdateBTItemCategory(infoHash, c.Name, string(policy))
+}
+
+// SetTorrentCategory assigns category to a torrent, empty name resets it to
+// global settings. Save path is used only for new torrents, so downloaded
+// files stay where they are.
+func (s *Service) SetTorrentCategory(t *Torrent, name string) error {
+	infoHash := t.InfoHash()
+	if name == "" {
+		t.policy = nil
+		t.applyPolicy()
+		return database.Get().UpdateBTItemCategory(infoHash, "", "")
+	}
+
+	c := s.GetCategory(name)
+	if c == nil {
+		return fmt.Errorf("Unknown torrent category: %s", name)
+	}
+	if err := s.storePolicy(infoHash, c); err != nil {
+		return err
+	}
+
+	t.policy = c
+	t.applyPolicy()
+	return nil
+}
+
+// Policy returns seeding policy of the torrent
+func (t *Torrent) Policy() *Category {
+	if t.policy != nil {
+		return t.policy
+	}
+	return t.Service.defaultPolicy()
+}
+
+// CategoryName returns name of torrent's category, or empty string
+func (t *Torrent) CategoryName() string {
+	if t.policy != nil {
+		return t.policy.Name
+	}
+	return ""
+}
+
+// applyPolicy sets torrent specific limits in libtorrent
+func (t *Torrent) applyPolicy() {
+	if t.th == nil {
+		return
+	}
+
+	t.th.SetUploadLimit(t.Policy().UploadLimit)
+}
+
+// torrentFileTrackers reads announce urls from a .torrent file
+func torrentFileTrackers(path string) (trackers []string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	raw := &TorrentFileRaw{}
+	if err := bencode.DecodeBytes(data, raw); err != nil {
+		return
+	}
+
+	if raw.Announce != "" {
+		trackers = append(trackers, raw.Announce)
+	}
+	for _, tier := range raw.AnnounceList {
+		trackers = append(trackers, tier...)
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package bittorrent
+
+import (
+	"github.com/bcrusher29/solaris/broadcast"
+)
+
+// Player states in the event stream
+const (
+	PlayerStateBuffering = "buffering"
+	PlayerStatePlaying   = "playing"
+	PlayerStatePaused    = "paused"
+	PlayerStateStopped   = "stopped"
+	PlayerStateError     = "error"
+)
+
+// TorrentEvent describes added, removed or changed torrent
+type TorrentEvent struct {
+	InfoHash string `json:"info_hash"`
+	Name     string `json:"name"`
+	State    string `json:"state,omitempty"`
+}
+
+// AlertEvent is a libtorrent alert
+type AlertEvent struct {
+	Type     int    `json:"type"`
+	Category int    `json:"category"`
+	What     string `json:"what"`
+	Message  string `json:"message"`
+	InfoHash string `json:"info_hash,omitempty"`
+}
+
+// PlayerEvent describes buffering and playback state of a player
+type PlayerEvent struct {
+	InfoHash       string  `json:"info_hash"`
+	TMDBId         int     `json:"tmdb_id"`
+	State          string  `json:"state"`
+	BufferProgress float64 `json:"buffer_progress"`
+	WatchedTime    float64 `json:"watched_time"`
+	VideoDuration  float64 `json:"video_duration"`
+}
+
+func publishTorrent(eventType string, t *Torrent) {
+	broadcast.Publish(eventType, &TorrentEvent{
+		InfoHash: t.InfoHash(),
+		Name:     t.Name(),
+	})
+}
+
+func (btp *Player) publishState(state string) {
+	e := &PlayerEvent{
+		TMDBId:        btp.p.TMDBId,
+		State:         state,
+		WatchedTime:   btp.p.WatchedTime,
+		VideoDuration: btp.p.VideoDuration,
+	}
+	if btp.t != nil {
+		e.InfoHash = btp.t.InfoHash()
+		e.BufferProgress = btp.t.BufferProgress
+	}
+	broadcast.Publish(broadcast.EventPlayer, e)
+}
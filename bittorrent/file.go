@@ -1,5 +1,47 @@
 package bittorrent
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// File priorities, chosen by user. Skipped files are not downloaded,
+// others are downloaded in the order of priority.
+const (
+	FilePrioritySkip = iota
+	FilePriorityLow
+	FilePriorityNormal
+	FilePriorityHigh
+)
+
+var filePriorityNames = []string{"skip", "low", "normal", "high"}
+
+// filePriorityBands are ranges of libtorrent priorities for each file priority,
+// files with the same priority get lower values down the download sequence
+var filePriorityBands = [][2]int{
+	FilePriorityLow:    {1, 2},
+	FilePriorityNormal: {3, 5},
+	FilePriorityHigh:   {6, 7},
+}
+
+// bandPriority returns libtorrent priority of a file at position among count
+// chosen files with the same priority. Libtorrent has only 7 levels, so files
+// are spread evenly over the band: with more files, than levels in the band,
+// neighbours share a level, but a file never gets a higher level, than files
+// before it in the download sequence.
+func bandPriority(priority int, position int, count int) int {
+	band := filePriorityBands[priority]
+	levels := band[1] - band[0] + 1
+	if count < levels {
+		count = levels
+	}
+	return band[1] - position*levels/count
+}
+
+// fileEntrySeparator separates path and priority in BTItem.Files entries
+const fileEntrySeparator = "\t"
+
 // File ...
 type File struct {
 	Selected   bool
@@ -10,4 +52,46 @@ type File struct {
 	Offset     int64
 	PieceStart int
 	PieceEnd   int
+	Priority   int
+}
+
+// ParseFilePriority converts priority name into file priority
+func ParseFilePriority(name string) (int, error) {
+	for i, n := range filePriorityNames {
+		if strings.EqualFold(n, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown file priority %q, expected one of: %s", name, strings.Join(filePriorityNames, ", "))
+}
+
+// FilePriorityName returns name of a file priority
+func FilePriorityName(priority int) string {
+	if priority < 0 || priority >= len(filePriorityNames) {
+		return ""
+	}
+	return filePriorityNames[priority]
+}
+
+// dbEntry returns BTItem.Files entry for the file. Normal priority is stored
+// as a plain path, so entries stay compatible with older versions.
+func (f *File) dbEntry() string {
+	if f.Priority == FilePriorityNormal || f.Priority == FilePrioritySkip {
+		return f.Path
+	}
+	return f.Path + fileEntrySeparator + strconv.Itoa(f.Priority)
+}
+
+// parseFileEntry splits BTItem.Files entry into path and priority
+func parseFileEntry(entry string) (path string, priority int) {
+	idx := strings.LastIndex(entry, fileEntrySeparator)
+	if idx == -1 {
+		return entry, FilePriorityNormal
+	}
+
+	priority, err := strconv.Atoi(entry[idx+1:])
+	if err != nil || priority <= FilePrioritySkip || priority > FilePriorityHigh {
+		priority = FilePriorityNormal
+	}
+	return entry[:idx], priority
 }
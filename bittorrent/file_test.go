@@ -0,0 +1,39 @@
+package bittorrent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBandPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		priority   int
+		count      int
+		priorities []int
+	}{
+		{"single file", FilePriorityNormal, 1, []int{5}},
+		{"fewer files than levels", FilePriorityNormal, 2, []int{5, 4}},
+		{"files fill the band", FilePriorityNormal, 3, []int{5, 4, 3}},
+		{"files spread over the band", FilePriorityNormal, 9, []int{5, 5, 5, 4, 4, 4, 3, 3, 3}},
+		{"uneven spread", FilePriorityHigh, 5, []int{7, 7, 7, 6, 6}},
+		{"low band", FilePriorityLow, 4, []int{2, 2, 1, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			band := filePriorityBands[test.priority]
+			priorities := []int{}
+			for i := 0; i < test.count; i++ {
+				p := bandPriority(test.priority, i, test.count)
+				if p < band[0] || p > band[1] {
+					t.Errorf("position %d got priority %d out of band %v", i, p, band)
+				}
+				priorities = append(priorities, p)
+			}
+			if !reflect.DeepEqual(priorities, test.priorities) {
+				t.Errorf("expected priorities %v, got %v", test.priorities, priorities)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package bittorrent
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/bencode"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/xbmc"
+)
+
+// Import clients
+const (
+	ImportTorrentFiles  = "torrents"
+	ImportQBittorrent   = "qbittorrent"
+	ImportTransmission  = "transmission"
+	ImportDeluge        = "deluge"
+	delugeResumeFile    = "torrents.fastresume"
+	transmissionResumes = "resume"
+)
+
+var (
+	releaseEpisodeRe = regexp.MustCompile(`(?i)[\s\.\-_\[\(]s(\d{1,2})(?:[\s\.\-_]?e(\d{1,3}))?(?:\b|_)`)
+	releaseYearRe    = regexp.MustCompile(`[\s\.\-_\[\(]((?:19|20)\d{2})(?:\b|_)`)
+	releaseGroupRe   = regexp.MustCompile(`^\s*\[[^\]]*\]`)
+	releaseTagsRe    = regexp.MustCompile(`(?i)[\s\.\-_\[\(](\d{3,4}p|2160p|4k|web-?(dl|rip)|hdtv|blu-?ray|bdrip|dvdrip|x26[45]|h\.?26[45]|hevc)`)
+)
+
+// ImportItem is a torrent, found in a folder of torrent files
+// or in a state folder of other client
+type ImportItem struct {
+	TorrentFile string `json:"torrent_file"`
+	SavePath    string `json:"save_path"`
+	Client      string `json:"client"`
+}
+
+// ImportResult describes imported torrent
+type ImportResult struct {
+	ImportItem
+	InfoHash  string `json:"info_hash"`
+	Name      string `json:"name"`
+	MediaType string `json:"media_type"`
+	TMDBId    int    `json:"tmdb_id"`
+	Error     string `json:"error,omitempty"`
+}
+
+// FindImportItems scans a folder for .torrent files and resolves their data
+// location from qBittorrent, Transmission or Deluge resume files next to them.
+// Torrents without resume data are expected in savePath, or download path.
+func FindImportItems(dir string, savePath string) ([]*ImportItem, error) {
+	if savePath == "" {
+		savePath = config.Get().DownloadPath
+	}
+
+	// Transmission keeps torrents and resume files in separate folders
+	if fi, err := os.Stat(filepath.Join(dir, "torrents")); err == nil && fi.IsDir() {
+		if _, err := os.Stat(filepath.Join(dir, transmissionResumes)); err == nil {
+			dir = filepath.Join(dir, "torrents")
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	delugePaths := readDelugeResume(filepath.Join(dir, delugeResumeFile))
+
+	items := []*ImportItem{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(strings.ToLower(f.Name()), ".torrent") {
+			continue
+		}
+
+		base := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		item := &ImportItem{
+			TorrentFile: filepath.Join(dir, f.Name()),
+			SavePath:    savePath,
+			Client:      ImportTorrentFiles,
+		}
+
+		if resume := readBencodedFile(filepath.Join(dir, base+".fastresume")); resume != nil {
+			item.Client = ImportQBittorrent
+			if path := bencodedString(resume, "qBt-savePath"); path != "" {
+				item.SavePath = path
+			} else if path := bencodedString(resume, "save_path"); path != "" {
+				item.SavePath = path
+			}
+		} else if resume := readBencodedFile(filepath.Join(filepath.Dir(dir), transmissionResumes, base+".resume")); resume != nil {
+			item.Client = ImportTransmission
+			if path := bencodedString(resume, "destination"); path != "" {
+				item.SavePath = path
+			}
+		} else if path, ok := delugePaths[strings.ToLower(base)]; ok {
+			item.Client = ImportDeluge
+			item.SavePath = path
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// readDelugeResume returns save paths by infohash from Deluge's
+// torrents.fastresume, that keeps bencoded resume data of each torrent
+func readDelugeResume(path string) map[string]string {
+	paths := map[string]string{}
+	resumes := readBencodedFile(path)
+	for hash, data := range resumes {
+		raw, ok := data.(string)
+		if !ok {
+			continue
+		}
+
+		resume := map[string]interface{}{}
+		if err := bencode.DecodeString(raw, &resume); err != nil {
+			continue
+		}
+		if savePath := bencodedString(resume, "save_path"); savePath != "" {
+			paths[strings.ToLower(hash)] = savePath
+		}
+	}
+	return paths
+}
+
+func readBencodedFile(path string) map[string]interface{} {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	ret := map[string]interface{}{}
+	if err := bencode.DecodeBytes(data, &ret); err != nil {
+		log.Warningf("Could not decode %s: %s", path, err)
+		return nil
+	}
+	return ret
+}
+
+func bencodedString(dict map[string]interface{}, key string) string {
+	if v, ok := dict[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// ImportTorrents adds found torrents with their existing data, rechecks them,
+// so they can continue seeding, and identifies them in TMDB
+func (s *Service) ImportTorrents(items []*ImportItem) []*ImportResult {
+	results := make([]*ImportResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, s.importItem(item))
+	}
+
+	return results
+}
+
+// ImportJob is an import, running in background
+type ImportJob struct {
+	ID      int             `json:"id"`
+	Path    string          `json:"path"`
+	Total   int             `json:"total"`
+	Done    bool            `json:"done"`
+	Started time.Time       `json:"started"`
+	Results []*ImportResult `json:"results"`
+}
+
+var (
+	importJobsMu sync.Mutex
+	importJobs   []*ImportJob
+	importJobID  int
+)
+
+// importJobsKept is a number of recent import jobs, that can be looked up
+const importJobsKept = 10
+
+// StartImport imports torrents in background, since TMDB identification takes
+// a while for big folders, and returns the job to follow the progress
+func (s *Service) StartImport(path string, items []*ImportItem) *ImportJob {
+	importJobsMu.Lock()
+	importJobID++
+	job := &ImportJob{
+		ID:      importJobID,
+		Path:    path,
+		Total:   len(items),
+		Started: time.Now(),
+		Results: []*ImportResult{},
+	}
+	importJobs = append(importJobs, job)
+	if len(importJobs) > importJobsKept {
+		importJobs = importJobs[len(importJobs)-importJobsKept:]
+	}
+	snapshot := job.snapshot()
+	importJobsMu.Unlock()
+
+	go func() {
+		for _, item := range items {
+			result := s.importItem(item)
+
+			importJobsMu.Lock()
+			job.Results = append(job.Results, result)
+			importJobsMu.Unlock()
+		}
+
+		importJobsMu.Lock()
+		job.Done = true
+		importJobsMu.Unlock()
+
+		log.Infof("Import job %d of %s is finished", job.ID, path)
+		xbmc.Refresh()
+	}()
+
+	return snapshot
+}
+
+// GetImportJob returns current state of a recent import job, or nil
+func GetImportJob(id int) *ImportJob {
+	importJobsMu.Lock()
+	defer importJobsMu.Unlock()
+
+	for _, job := range importJobs {
+		if job.ID == id {
+			return job.snapshot()
+		}
+	}
+	return nil
+}
+
+// snapshot copies the job, must be called with importJobsMu held
+func (job *ImportJob) snapshot() *ImportJob {
+	c := *job
+	c.Results = append([]*ImportResult{}, job.Results...)
+	return &c
+}
+
+func (s *Service) importItem(item *ImportItem) *ImportResult {
+	result := &ImportResult{ImportItem: *item}
+
+	t, err := s.ImportTorrent(item.TorrentFile, item.SavePath)
+	if err != nil {
+		log.Warningf("Could not import %s: %s", item.TorrentFile, err)
+		result.Error = err.Error()
+		return result
+	}
+
+	result.InfoHash = t.InfoHash()
+	result.Name = t.Name()
+	result.MediaType, result.TMDBId = identifyTorrent(t)
+	return result
+}
+
+// ImportTorrent adds torrent with existing data in savePath,
+// chooses all files and forces data recheck
+func (s *Service) ImportTorrent(uri string, savePath string) (*Torrent, error) {
+	if s.IsMemoryStorage() {
+		return nil, fmt.Errorf("Import is not possible with memory storage")
+	}
+
+	log.Infof("Importing torrent %s with data in %s", uri, savePath)
+	t, err := s.addTorrent(uri, false, "", savePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range t.files {
+		t.DownloadFile(f)
+	}
+	t.th.ForceRecheck()
+
+	return t, nil
+}
+
+// identifyTorrent searches TMDB by the release name of a torrent,
+// and stores found media into torrent's BTItem
+func identifyTorrent(t *Torrent) (mediaType string, tmdbID int) {
+	language := config.Get().Language
+	title, year, season, episode := parseReleaseName(t.Name())
+
+	files := make([]string, 0, len(t.ChosenFiles))
+	for _, f := range t.ChosenFiles {
+		files = append(files, f.dbEntry())
+	}
+
+	showID := 0
+	if title != "" && episode != 0 {
+		shows, _ := tmdb.SearchShows(title, language, 1)
+		for _, show := range shows {
+			if show == nil || (year > 0 && !strings.HasPrefix(show.FirstAirDate, strconv.Itoa(year))) {
+				continue
+			}
+
+			showID = show.ID
+			if episode < 0 {
+				mediaType, tmdbID, episode = "show", show.ID, 0
+			} else if ep := tmdb.GetEpisode(show.ID, season, episode, language); ep != nil {
+				mediaType, tmdbID = "episode", ep.ID
+			}
+			break
+		}
+	} else if title != "" {
+		movies, _ := tmdb.SearchMovies(title, language, 1)
+		for _, movie := range movies {
+			if movie == nil || (year > 0 && !strings.HasPrefix(movie.ReleaseDate, strconv.Itoa(year))) {
+				continue
+			}
+
+			mediaType, tmdbID = "movie", movie.ID
+			break
+		}
+	}
+
+	if tmdbID == 0 {
+		log.Infof("Could not identify %s in TMDB", t.Name())
+	} else {
+		log.Infof("Identified %s as %s %d", t.Name(), mediaType, tmdbID)
+	}
+
+	if episode < 0 {
+		episode = 0
+	}
+
+	infoHash := t.InfoHash()
+	database.Get().UpdateBTItem(infoHash, tmdbID, mediaType, files, t.Name(), showID, season, episode)
+	t.FetchDBItem()
+	return
+}
+
+// parseReleaseName extracts title, year, season and episode from a release name,
+// like "Some.Show.S01E02.1080p.WEB-DL" or "Some Movie (2010) 720p".
+// Episode is -1 for season packs.
+func parseReleaseName(name string) (title string, year, season, episode int) {
+	end := len(name)
+	if m := releaseEpisodeRe.FindStringSubmatchIndex(name); m != nil {
+		season, _ = strconv.Atoi(name[m[2]:m[3]])
+		if m[4] >= 0 {
+			episode, _ = strconv.Atoi(name[m[4]:m[5]])
+		} else {
+			// Season pack
+			episode = -1
+		}
+		end = m[0]
+	}
+	if m := releaseTagsRe.FindStringIndex(name); m != nil && m[0] > 0 && m[0] < end {
+		end = m[0]
+	}
+	// Title can contain a number, like a year, so the last one is taken
+	for _, m := range releaseYearRe.FindAllStringSubmatchIndex(name[:end], -1) {
+		year, _ = strconv.Atoi(name[m[2]:m[3]])
+		end = m[0]
+	}
+
+	// Release group in brackets at the start is not a part of the title
+	start := 0
+	if m := releaseGroupRe.FindStringIndex(name[:end]); m != nil {
+		start = m[1]
+	}
+
+	title = strings.NewReplacer(".", " ", "_", " ").Replace(name[start:end])
+	title = strings.TrimSpace(strings.Trim(title, " -[]()"))
+	return
+}
@@ -0,0 +1,33 @@
+package bittorrent
+
+import "testing"
+
+func TestParseReleaseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		year    int
+		season  int
+		episode int
+	}{
+		{"Some.Show.S01E02.1080p.WEB-DL.x264", "Some Show", 0, 1, 2},
+		{"Some Show - s2e113 [720p]", "Some Show", 0, 2, 113},
+		{"Some_Show_S03_E04_HDTV", "Some Show", 0, 3, 4},
+		{"Some.Show.2019.S01.Complete.1080p", "Some Show", 2019, 1, -1},
+		{"Some Movie (2010) 720p BluRay", "Some Movie", 2010, 0, 0},
+		{"Blade.Runner.2049.2017.2160p.HEVC", "Blade Runner 2049", 2017, 0, 0},
+		{"Some.Movie.1080p.BDRip", "Some Movie", 0, 0, 0},
+		{"[Group] Some Movie [BluRay]", "Some Movie", 0, 0, 0},
+		{"[Group] Some Show - S01E05 [1080p]", "Some Show", 0, 1, 5},
+		{"Some_Show_2019_1080p", "Some Show", 2019, 0, 0},
+		{"Plain name", "Plain name", 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		title, year, season, episode := parseReleaseName(test.name)
+		if title != test.title || year != test.year || season != test.season || episode != test.episode {
+			t.Errorf("parseReleaseName(%q) = %q, %d, %d, %d, expected %q, %d, %d, %d",
+				test.name, title, year, season, episode, test.title, test.year, test.season, test.episode)
+		}
+	}
+}
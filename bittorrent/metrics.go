@@ -0,0 +1,92 @@
+package bittorrent
+
+import (
+	lt "github.com/ElementumOrg/libtorrent-go"
+
+	"github.com/bcrusher29/solaris/metrics"
+)
+
+var (
+	bufferingMetric  = metrics.NewHistogram("elementum_buffering_seconds", "Duration of buffering before playback", metrics.DurationBuckets, "result")
+	pieceWaitMetric  = metrics.NewHistogram("elementum_piece_wait_seconds", "Time, reader waited for a missing piece", metrics.DurationBuckets)
+	pieceStallMetric = metrics.NewCounter("elementum_piece_stalls_total", "Reads, that had to wait for a missing piece")
+
+	sessionRateMetric     = metrics.NewGauge("elementum_session_rate_bytes", "Payload rate of all torrents", "direction")
+	sessionPeersMetric    = metrics.NewGauge("elementum_session_peers", "Connected peers of all torrents")
+	sessionDHTMetric      = metrics.NewGauge("elementum_session_dht_nodes", "Nodes in DHT routing table")
+	sessionDiskMetric     = metrics.NewGauge("elementum_session_disk_queue_jobs", "Jobs, waiting in disk queue", "direction")
+	sessionPausedMetric   = metrics.NewGauge("elementum_session_paused", "Whether session is paused")
+	sessionTorrentsMetric = metrics.NewGauge("elementum_session_torrents", "Torrents in the session")
+	sessionPlayersMetric  = metrics.NewGauge("elementum_session_players", "Active players")
+	memoryMetric          = metrics.NewGauge("elementum_memory_bytes", "System memory, used for memory storage limits", "kind")
+
+	torrentProgressMetric = metrics.NewGauge("elementum_torrent_progress_ratio", "Download progress of a torrent", "info_hash", "name")
+	torrentRateMetric     = metrics.NewGauge("elementum_torrent_rate_bytes", "Payload rate of a torrent", "info_hash", "name", "direction")
+	torrentPeersMetric    = metrics.NewGauge("elementum_torrent_peers", "Connected peers of a torrent", "info_hash", "name")
+	torrentSeedsMetric    = metrics.NewGauge("elementum_torrent_seeds", "Connected seeds of a torrent", "info_hash", "name")
+	torrentMemoryMetric   = metrics.NewGauge("elementum_torrent_memory_bytes", "Memory storage size of a torrent", "info_hash", "name")
+)
+
+// CollectMetrics updates session and torrent gauges before metrics are written
+func (s *Service) CollectMetrics() {
+	if s.Closer.IsSet() || s.Session == nil {
+		return
+	}
+
+	handle := s.Session.GetHandle()
+	if handle.IsPaused() {
+		sessionPausedMetric.Set(1)
+	} else {
+		sessionPausedMetric.Set(0)
+	}
+
+	status := handle.Status()
+	sessionDHTMetric.Set(float64(status.GetDhtNodes()))
+	sessionDiskMetric.Set(float64(status.GetDiskReadQueue()), "read")
+	sessionDiskMetric.Set(float64(status.GetDiskWriteQueue()), "write")
+	lt.DeleteSessionStatus(status)
+
+	total, free := s.GetMemoryStats()
+	memoryMetric.Set(float64(total), "total")
+	memoryMetric.Set(float64(free), "free")
+
+	s.mu.Lock()
+	sessionPlayersMetric.Set(float64(len(s.Players)))
+	s.mu.Unlock()
+
+	for _, m := range []*metrics.Vec{torrentProgressMetric, torrentRateMetric, torrentPeersMetric, torrentSeedsMetric, torrentMemoryMetric} {
+		m.Reset()
+	}
+
+	torrents := s.q.All()
+	sessionTorrentsMetric.Set(float64(len(torrents)))
+
+	var downloadRate, uploadRate float64
+	var peers int
+	for _, t := range torrents {
+		if t.th == nil || !t.th.IsValid() {
+			continue
+		}
+
+		infoHash, name := t.InfoHash(), t.Name()
+		ts := t.th.Status()
+		download, upload := float64(ts.GetDownloadPayloadRate()), float64(ts.GetUploadPayloadRate())
+		downloadRate += download
+		uploadRate += upload
+		peers += ts.GetNumPeers()
+
+		torrentProgressMetric.Set(float64(ts.GetProgress()), infoHash, name)
+		torrentRateMetric.Set(download, infoHash, name, "download")
+		torrentRateMetric.Set(upload, infoHash, name, "upload")
+		torrentPeersMetric.Set(float64(ts.GetNumPeers()-ts.GetNumSeeds()), infoHash, name)
+		torrentSeedsMetric.Set(float64(ts.GetNumSeeds()), infoHash, name)
+		if s.IsMemoryStorage() {
+			torrentMemoryMetric.Set(float64(t.MemorySize), infoHash, name)
+		}
+		lt.DeleteTorrentStatus(ts)
+	}
+
+	sessionRateMetric.Set(downloadRate, "download")
+	sessionRateMetric.Set(uploadRate, "upload")
+	sessionPeersMetric.Set(float64(peers))
+}
@@ -0,0 +1,247 @@
+package bittorrent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bcrusher29/solaris/database"
+)
+
+// Playback session end reasons
+const (
+	PlaybackEndStopped = "stopped"
+	PlaybackEndFailed  = "buffering_failed"
+	PlaybackEndTimeout = "playback_timeout"
+	PlaybackEndClosed  = "closed"
+)
+
+const (
+	playbackPeersInterval = 30 * time.Second
+	// Changes, that are not written yet, over that number are dropped
+	playbackWriteQueue = 100
+)
+
+// playbackLog records a playback session and its events into the database,
+// so bad streams can be matched with releases and providers.
+// Changes are written in background, since they are recorded by readers,
+// that wait for pieces. All methods can be called on nil log.
+type playbackLog struct {
+	mu        sync.Mutex
+	session   *database.PlaybackSession
+	lastPeers time.Time
+	writes    chan func()
+	ended     bool
+}
+
+func newPlaybackLog(btp *Player) *playbackLog {
+	infoHash := btp.t.InfoHash()
+	session := &database.PlaybackSession{
+		InfoHash:    infoHash,
+		Name:        btp.t.Name(),
+		Provider:    torrentProvider(infoHash),
+		ContentType: btp.p.ContentType,
+		TMDBId:      btp.p.TMDBId,
+		ShowID:      btp.p.ShowID,
+		Season:      btp.p.Season,
+		Episode:     btp.p.Episode,
+		Started:     time.Now(),
+	}
+	if err := database.Get().AddPlaybackSession(session); err != nil {
+		log.Warningf("Could not store playback session: %s", err)
+		return nil
+	}
+
+	l := &playbackLog{
+		session: session,
+		writes:  make(chan func(), playbackWriteQueue),
+	}
+	go l.writer()
+
+	return l
+}
+
+// torrentProvider returns provider of the torrent from torrent history
+func torrentProvider(infoHash string) string {
+	var b []byte
+	database.Get().QueryRow(`SELECT metainfo FROM torrent_history WHERE infohash = ?`, infoHash).Scan(&b)
+	if len(b) == 0 {
+		return ""
+	}
+
+	torrent := &TorrentFile{}
+	if b[0] == '{' {
+		torrent.UnmarshalJSON(b)
+	} else {
+		torrent.LoadFromBytes(b)
+	}
+	return torrent.Provider
+}
+
+// Buffered records chosen file and buffering duration
+func (l *playbackLog) Buffered(f *File, duration time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if f != nil {
+		l.session.File = f.Path
+		l.session.Size = f.Size
+	}
+	l.session.BufferTime = duration.Seconds()
+	l.updateSession()
+}
+
+// FirstByte records time till the first data is sent to the player
+func (l *playbackLog) FirstByte() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session.FirstByteTime > 0 {
+		return
+	}
+	l.session.FirstByteTime = time.Since(l.session.Started).Seconds()
+	l.updateSession()
+}
+
+// Stall records reader's wait for a missing piece
+func (l *playbackLog) Stall(piece int, duration time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.session.Stalls++
+	l.session.StallTime += duration.Seconds()
+	l.addEvent(&database.PlaybackEvent{
+		Type:     database.PlaybackEventStall,
+		Piece:    piece,
+		Duration: duration.Seconds(),
+	})
+	l.updateSession()
+}
+
+// Seek records seek to a position in seconds
+func (l *playbackLog) Seek(position float64) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.session.Seeks++
+	l.addEvent(&database.PlaybackEvent{
+		Type:  database.PlaybackEventSeek,
+		Value: position,
+	})
+	l.updateSession()
+}
+
+// Peers records connected peers, not more often than once in a sampling interval
+func (l *playbackLog) Peers(t *Torrent) {
+	if l == nil || t == nil || t.th == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Since(l.lastPeers) < playbackPeersInterval {
+		return
+	}
+	l.lastPeers = time.Now()
+
+	seeds, _, peers, _ := t.GetConnections()
+	l.addEvent(&database.PlaybackEvent{
+		Type:  database.PlaybackEventPeers,
+		Value: float64(seeds + peers),
+	})
+}
+
+// End records end of the session, only the first reason is kept
+func (l *playbackLog) End(reason string, err error, watchedPercent int) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.ended {
+		return
+	}
+
+	l.session.Ended = time.Now()
+	l.session.EndReason = reason
+	l.session.WatchedPercent = watchedPercent
+	if err != nil {
+		l.session.Error = err.Error()
+	}
+
+	// Final state is never dropped, writer exits after storing it
+	session := *l.session
+	l.writes <- func() {
+		database.Get().UpdatePlaybackSession(&session)
+	}
+	close(l.writes)
+	l.ended = true
+}
+
+// writer stores queued changes, until the session ends
+func (l *playbackLog) writer() {
+	for write := range l.writes {
+		write()
+	}
+}
+
+// queue adds a write to the queue, dropping it, when the database can't keep up.
+// Should be called with log locked.
+func (l *playbackLog) queue(write func()) {
+	if l.ended {
+		return
+	}
+
+	select {
+	case l.writes <- write:
+	default:
+		log.Debugf("Playback log queue is full, dropping a change of session %d", l.session.ID)
+	}
+}
+
+// updateSession queues a write of current session state
+func (l *playbackLog) updateSession() {
+	session := *l.session
+	l.queue(func() {
+		database.Get().UpdatePlaybackSession(&session)
+	})
+}
+
+func (l *playbackLog) addEvent(e *database.PlaybackEvent) {
+	e.Created = time.Now()
+	sessionID := l.session.ID
+	l.queue(func() {
+		if err := database.Get().AddPlaybackEvent(sessionID, e); err != nil {
+			log.Warningf("Could not store playback event: %s", err)
+		}
+	})
+}
+
+// playbackLog returns log of a player, that plays the torrent
+func (t *Torrent) playbackLog() *playbackLog {
+	t.Service.mu.Lock()
+	defer t.Service.mu.Unlock()
+
+	if p, ok := t.Service.Players[t.InfoHash()]; ok {
+		return p.playback
+	}
+	return nil
+}
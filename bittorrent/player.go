@@ -26,6 +26,7 @@ import (
 	"github.com/bcrusher29/solaris/diskusage"
 	"github.com/bcrusher29/solaris/library"
 	"github.com/bcrusher29/solaris/osdb"
+	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/tmdb"
 	"github.com/bcrusher29/solaris/trakt"
 	"github.com/bcrusher29/solaris/util"
@@ -63,6 +64,7 @@ type Player struct {
 	isDownloading            bool
 	notEnoughSpace           bool
 	bufferEvents             *broadcast.Broadcaster
+	playback                 *playbackLog
 	bufferPiecesProgress     map[int]float64
 	bufferPiecesProgressLock sync.RWMutex
 
@@ -152,7 +154,7 @@ func (btp *Player) SetTorrent(t *Torrent) {
 
 func (btp *Player) addTorrent() error {
 	if btp.t == nil {
-		torrent, err := btp.s.AddTorrent(btp.p.URI, false)
+		torrent, err := btp.s.AddTorrent(btp.p.URI, false, "")
 		if err != nil {
 			log.Errorf("Error adding torrent to player: %s", err)
 			return err
@@ -196,6 +198,7 @@ func (btp *Player) PlayURL() string {
 
 // Buffer ...
 func (btp *Player) Buffer() error {
+	start := time.Now()
 	if btp.p.ResumeHash != "" {
 		if err := btp.resumeTorrent(); err != nil {
 			log.Errorf("Error resuming torrent: %#v", err)
@@ -208,6 +211,8 @@ func (btp *Player) Buffer() error {
 		}
 	}
 
+	btp.playback = newPlaybackLog(btp)
+
 	go btp.processMetadata()
 
 	btp.t.IsBuffering = true
@@ -228,11 +233,18 @@ func (btp *Player) Buffer() error {
 	go btp.s.AttachPlayer(btp)
 
 	if err := <-buffered; err != nil {
+		bufferingMetric.ObserveSince(start, "error")
+		btp.playback.End(PlaybackEndFailed, err.(error), 0)
 		return err.(error)
 	} else if !btp.HasChosenFile() {
-		return errors.New("File not chosen")
+		err := errors.New("File not chosen")
+		bufferingMetric.ObserveSince(start, "error")
+		btp.playback.End(PlaybackEndFailed, err, 0)
+		return err
 	}
 
+	bufferingMetric.ObserveSince(start, "ok")
+	btp.playback.Buffered(btp.chosenFile, time.Since(start))
 	return nil
 }
 
@@ -557,6 +569,7 @@ func (btp *Player) Close() {
 
 	btp.closed = true
 	close(btp.closing)
+	btp.playback.End(PlaybackEndClosed, nil, btp.p.WatchedProgress)
 
 	// Torrent was not initialized so just close and return
 	if btp.t == nil {
@@ -653,6 +666,8 @@ func (btp *Player) bufferDialog() {
 				return
 			}
 		case <-oneSecond.C:
+			btp.publishState(PlayerStateBuffering)
+
 			status := btp.t.GetStatus()
 			defer lt.DeleteTorrentStatus(status)
 
@@ -665,8 +680,8 @@ func (btp *Player) bufferDialog() {
 				}
 
 				if btp.t.IsRarArchive && progress >= 100 {
-					archivePath := filepath.Join(btp.s.config.DownloadPath, btp.chosenFile.Path)
-					destPath := filepath.Join(btp.s.config.DownloadPath, filepath.Dir(btp.chosenFile.Path), "extracted")
+					archivePath := filepath.Join(btp.t.savePath, btp.chosenFile.Path)
+					destPath := filepath.Join(btp.t.savePath, filepath.Dir(btp.chosenFile.Path), "extracted")
 
 					if _, err := os.Stat(destPath); err == nil {
 						btp.findExtracted(destPath)
@@ -782,6 +797,7 @@ func (btp *Player) playerLoop() {
 
 	if err := <-buffered; err != nil {
 		log.Errorf("Error buffering: %#v", err)
+		btp.publishState(PlayerStateError)
 		return
 	}
 
@@ -798,7 +814,10 @@ playbackWaitLoop:
 		select {
 		case <-playbackTimeout:
 			log.Warningf("Playback was unable to start after %d seconds. Aborting...", config.Get().BufferTimeout)
-			btp.bufferEvents.Broadcast(errors.New("Playback was unable to start before timeout"))
+			err := errors.New("Playback was unable to start before timeout")
+			btp.bufferEvents.Broadcast(err)
+			btp.publishState(PlayerStateError)
+			btp.playback.End(PlaybackEndTimeout, err, 0)
 			return
 		case <-oneSecond.C:
 		}
@@ -819,6 +838,7 @@ playbackWaitLoop:
 
 	playlistSize := xbmc.PlaylistSize()
 	btp.t.IsPlaying = true
+	btp.publishState(PlayerStatePlaying)
 
 	if config.Get().OSDBAutoLoad {
 		go btp.downloadSubtitles()
@@ -833,15 +853,18 @@ playbackLoop:
 		select {
 		case <-oneSecond.C:
 			btp.updateWatchTimes()
+			btp.playback.Peers(btp.t)
 
 			if btp.p.Seeked {
 				btp.p.Seeked = false
+				btp.playback.Seek(btp.p.WatchedTime)
 				if btp.scrobble {
 					trakt.Scrobble("start", btp.p.ContentType, btp.p.TMDBId, btp.p.WatchedTime, btp.p.VideoDuration)
 				}
 			} else if xbmc.PlayerIsPaused() {
 				if playing == true {
 					playing = false
+					btp.publishState(PlayerStatePaused)
 					if btp.scrobble {
 						trakt.Scrobble("pause", btp.p.ContentType, btp.p.TMDBId, btp.p.WatchedTime, btp.p.VideoDuration)
 					}
@@ -861,6 +884,7 @@ playbackLoop:
 			} else {
 				if playing == false {
 					playing = true
+					btp.publishState(PlayerStatePlaying)
 					if btp.scrobble {
 						trakt.Scrobble("start", btp.p.ContentType, btp.p.TMDBId, btp.p.WatchedTime, btp.p.VideoDuration)
 					}
@@ -880,6 +904,8 @@ playbackLoop:
 	}
 
 	log.Info("Stopped playback")
+	btp.publishState(PlayerStateStopped)
+	btp.playback.End(PlaybackEndStopped, nil, btp.p.WatchedProgress)
 	btp.SaveStoredResume()
 	btp.setRateLimiting(false)
 	go func() {
@@ -943,6 +969,12 @@ func (btp *Player) UpdateWatched() {
 			}
 		}
 
+		if btp.p.ContentType == movieType {
+			playcount.AddLocalPlay(playcount.MovieType, btp.p.TMDBId, 0, 0)
+		} else if btp.p.ContentType == episodeType {
+			playcount.AddLocalPlay(playcount.EpisodeType, btp.p.ShowID, btp.p.Season, btp.p.Episode)
+		}
+
 		// We set Trakt watched only if it's not in Kodi library
 		// to track items that are started from Elementum lists
 		// otherwise we will get Watched items set twice in Trakt
@@ -955,12 +987,15 @@ func (btp *Player) UpdateWatched() {
 			log.Debugf("Updating player resume from: %#v", btp.p.Resume)
 			btp.p.Resume.Position = btp.p.WatchedTime
 			btp.p.Resume.Total = btp.p.VideoDuration
+			btp.p.Resume.LastPlayed = time.Now()
 		}
 
 		if btp.p.ContentType == movieType {
 			xbmc.SetMovieProgress(btp.p.KodiID, int(btp.p.WatchedTime), int(btp.p.VideoDuration))
+			playcount.SetLocalProgress(playcount.MovieType, btp.p.TMDBId, 0, 0, btp.p.WatchedTime, btp.p.VideoDuration)
 		} else if btp.p.ContentType == episodeType {
 			xbmc.SetEpisodeProgress(btp.p.KodiID, int(btp.p.WatchedTime), int(btp.p.VideoDuration))
+			playcount.SetLocalProgress(playcount.EpisodeType, btp.p.ShowID, btp.p.Season, btp.p.Episode, btp.p.WatchedTime, btp.p.VideoDuration)
 		}
 	}
 	time.Sleep(200 * time.Millisecond)
@@ -1031,6 +1066,18 @@ func (btp *Player) GetIdent() {
 
 	if btp.p.KodiID == 0 {
 		log.Debugf("Can't find %s for these parameters: %+v", btp.p.ContentType, btp.p)
+
+		// Item is not in the library, so take resume point from local
+		// watch history or from Trakt, if any
+		if btp.p.ContentType == movieType {
+			if btp.p.Resume = library.GetLocalResume(playcount.MovieType, btp.p.TMDBId, 0, 0); btp.p.Resume == nil {
+				btp.p.Resume = library.GetTraktMovieResume(btp.p.TMDBId)
+			}
+		} else if btp.p.ContentType == episodeType {
+			if btp.p.Resume = library.GetLocalResume(playcount.EpisodeType, btp.p.ShowID, btp.p.Season, btp.p.Episode); btp.p.Resume == nil {
+				btp.p.Resume = library.GetTraktEpisodeResume(btp.p.ShowID, btp.p.Season, btp.p.Episode)
+			}
+		}
 	}
 }
 
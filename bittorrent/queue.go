@@ -1,23 +1,47 @@
 package bittorrent
 
-// Queue represents list of torrents inside of a session
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Queue actions
+const (
+	QueueUp     = "up"
+	QueueDown   = "down"
+	QueueTop    = "top"
+	QueueBottom = "bottom"
+)
+
+// Queue represents list of torrents inside of a session.
+// Order of downloads follows libtorrent queue: auto managed torrents are
+// started by their queue position, while active downloads and seeds limits
+// allow. Force started torrents and torrents of active players are not
+// managed, so they are never queued.
 type Queue struct {
 	s        *Service
+	mu       sync.RWMutex
 	torrents []*Torrent
 }
 
 // NewQueue contructor for empty Queue
 func NewQueue(s *Service) *Queue {
 	return &Queue{
-		s,
-		[]*Torrent{},
+		s:        s,
+		torrents: []*Torrent{},
 	}
 }
 
 // Add torrent to the queue
 func (q *Queue) Add(t *Torrent) bool {
-	if q.FindByHash(t.InfoHash()) != nil {
-		return false
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, ti := range q.torrents {
+		if ti.InfoHash() == t.InfoHash() {
+			return false
+		}
 	}
 
 	q.torrents = append(q.torrents, t)
@@ -26,6 +50,9 @@ func (q *Queue) Add(t *Torrent) bool {
 
 // Delete removes torrent from the queue
 func (q *Queue) Delete(t *Torrent) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	idx := -1
 	for i, ti := range q.torrents {
 		if ti.InfoHash() == t.InfoHash() {
@@ -44,11 +71,36 @@ func (q *Queue) Delete(t *Torrent) bool {
 
 // All returns all queue
 func (q *Queue) All() []*Torrent {
-	return q.torrents
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return append([]*Torrent{}, q.torrents...)
+}
+
+// Sorted returns torrents in the order of queue positions,
+// finished and not managed torrents go last
+func (q *Queue) Sorted() []*Torrent {
+	torrents := q.All()
+	positions := make(map[*Torrent]int, len(torrents))
+	for _, t := range torrents {
+		positions[t] = t.QueuePosition()
+	}
+
+	sort.SliceStable(torrents, func(i, j int) bool {
+		pi, pj := positions[torrents[i]], positions[torrents[j]]
+		if (pi < 0) != (pj < 0) {
+			return pi >= 0
+		}
+		return pi < pj
+	})
+	return torrents
 }
 
 // FindByHash checks if torrent with infohash is in the queue
 func (q *Queue) FindByHash(hash string) *Torrent {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
 	for _, t := range q.torrents {
 		if t.InfoHash() == hash {
 			return t
@@ -61,5 +113,98 @@ func (q *Queue) FindByHash(hash string) *Torrent {
 // Clean would cleanup torrents list,
 // should be used in case of a service reload
 func (q *Queue) Clean() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	q.torrents = []*Torrent{}
 }
+
+// Move changes queue position of a torrent
+func (q *Queue) Move(t *Torrent, action string) error {
+	if t.th == nil {
+		return fmt.Errorf("Torrent is not ready")
+	}
+
+	switch action {
+	case QueueUp:
+		t.th.QueuePositionUp()
+	case QueueDown:
+		t.th.QueuePositionDown()
+	case QueueTop:
+		t.th.QueuePositionTop()
+	case QueueBottom:
+		t.th.QueuePositionBottom()
+	default:
+		return fmt.Errorf("Unknown queue action: %s", action)
+	}
+
+	log.Infof("Moved %s %s in queue, position: %d", t.Name(), action, t.QueuePosition())
+	return nil
+}
+
+// ForceStart starts torrent regardless of queue limits,
+// disabling it returns torrent into the queue
+func (q *Queue) ForceStart(t *Torrent, force bool) error {
+	if t.th == nil {
+		return fmt.Errorf("Torrent is not ready")
+	}
+
+	t.forceStart = force
+	if force {
+		log.Infof("Force starting torrent: %s", t.Name())
+		t.th.AutoManaged(false)
+		t.th.Resume()
+		t.IsPaused = false
+	} else if !t.IsPaused && !t.hasPlayer {
+		t.th.AutoManaged(true)
+	}
+	return nil
+}
+
+// Prioritize takes torrent of an active player out of the queue,
+// and moves it to the top, so it is started first after the player stops
+func (q *Queue) Prioritize(t *Torrent) {
+	t.hasPlayer = true
+	if t.th == nil || q.s.IsMemoryStorage() {
+		return
+	}
+
+	t.th.AutoManaged(false)
+	t.th.QueuePositionTop()
+	if !t.IsPaused {
+		t.th.Resume()
+	}
+}
+
+// Release returns torrent into the queue, when its player stops
+func (q *Queue) Release(t *Torrent) {
+	t.hasPlayer = false
+	if t.th == nil || q.s.IsMemoryStorage() || t.forceStart || t.IsPaused {
+		return
+	}
+
+	t.th.AutoManaged(true)
+}
+
+// QueuePosition returns position of the torrent in download queue,
+// or -1 for finished and not managed torrents
+func (t *Torrent) QueuePosition() int {
+	if t.th == nil {
+		return -1
+	}
+	return t.th.QueuePosition()
+}
+
+// IsForceStarted ...
+func (t *Torrent) IsForceStarted() bool {
+	return t.forceStart
+}
+
+// queueLimit converts active torrents limit from settings into libtorrent's,
+// where -1 is unlimited
+func queueLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
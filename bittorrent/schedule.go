@@ -0,0 +1,356 @@
+package bittorrent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Bandwidth schedule is a list of windows, separated by ';'. Each window is
+//   <days> <HH:MM>-<HH:MM> <action>
+// where days are "*", "mon-fri" or "sat,sun", and action is "pause"
+// or "<download>/<upload>" limits in KB/s, with 0 meaning unlimited.
+// Window, that ends before it starts, lasts till the next day.
+// Example: "mon-fri 09:00-18:00 pause; * 23:00-07:00 0/100"
+
+const scheduleInterval = 30 * time.Second
+
+var weekDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ScheduleWindow is a period of time with alternate bandwidth limits
+type ScheduleWindow struct {
+	Days          [7]bool `json:"-"`
+	Spec          string  `json:"spec"`
+	Start         int     `json:"start"`
+	End           int     `json:"end"`
+	Pause         bool    `json:"pause"`
+	DownloadLimit int     `json:"download_limit"`
+	UploadLimit   int     `json:"upload_limit"`
+}
+
+// SessionStatus describes global session state
+type SessionStatus struct {
+	Paused         bool            `json:"paused"`
+	PausedManually bool            `json:"paused_manually"`
+	Streaming      bool            `json:"streaming"`
+	DownloadLimit  int             `json:"download_limit"`
+	UploadLimit    int             `json:"upload_limit"`
+	Window         *ScheduleWindow `json:"window"`
+}
+
+// ParseSchedule parses bandwidth schedule string
+func ParseSchedule(spec string) (windows []*ScheduleWindow, err error) {
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		w, err := parseScheduleWindow(part)
+		if err != nil {
+			return nil, fmt.Errorf("Bad schedule window '%s': %s", part, err)
+		}
+		windows = append(windows, w)
+	}
+
+	return
+}
+
+func parseScheduleWindow(spec string) (*ScheduleWindow, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("expected '<days> <HH:MM>-<HH:MM> <action>'")
+	}
+
+	w := &ScheduleWindow{Spec: spec}
+	if err := parseScheduleDays(fields[0], &w.Days); err != nil {
+		return nil, err
+	}
+
+	times := strings.Split(fields[1], "-")
+	if len(times) != 2 {
+		return nil, fmt.Errorf("bad time range '%s'", fields[1])
+	}
+	var err error
+	if w.Start, err = parseScheduleTime(times[0]); err != nil {
+		return nil, err
+	}
+	if w.End, err = parseScheduleTime(times[1]); err != nil {
+		return nil, err
+	}
+
+	if fields[2] == "pause" {
+		w.Pause = true
+		return w, nil
+	}
+
+	limits := strings.Split(fields[2], "/")
+	if len(limits) != 2 {
+		return nil, fmt.Errorf("bad action '%s'", fields[2])
+	}
+	down, errDown := strconv.Atoi(limits[0])
+	up, errUp := strconv.Atoi(limits[1])
+	if errDown != nil || errUp != nil || down < 0 || up < 0 {
+		return nil, fmt.Errorf("bad limits '%s'", fields[2])
+	}
+	w.DownloadLimit = down * 1024
+	w.UploadLimit = up * 1024
+
+	return w, nil
+}
+
+func parseScheduleDays(spec string, days *[7]bool) error {
+	if spec == "*" {
+		for i := range days {
+			days[i] = true
+		}
+		return nil
+	}
+
+	for _, d := range strings.Split(spec, ",") {
+		bounds := strings.Split(d, "-")
+		from, ok := weekDays[bounds[0]]
+		if !ok {
+			return fmt.Errorf("unknown day '%s'", bounds[0])
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, ok = weekDays[bounds[1]]; !ok {
+				return fmt.Errorf("unknown day '%s'", bounds[1])
+			}
+		} else if len(bounds) > 2 {
+			return fmt.Errorf("bad days range '%s'", d)
+		}
+
+		for i := from; ; i = (i + 1) % 7 {
+			days[i] = true
+			if i == to {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseScheduleTime returns minutes since midnight
+func parseScheduleTime(spec string) (int, error) {
+	t, err := time.Parse("15:04", spec)
+	if err != nil {
+		return 0, fmt.Errorf("bad time '%s'", spec)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// IsActive checks whether window covers specified time
+func (w *ScheduleWindow) IsActive(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	if w.Start <= w.End {
+		return w.Days[now.Weekday()] && minute >= w.Start && minute < w.End
+	}
+
+	// Window lasts till the next day, so the part after midnight
+	// belongs to the previous day
+	if minute >= w.Start {
+		return w.Days[now.Weekday()]
+	}
+	return minute < w.End && w.Days[(now.Weekday()+6)%7]
+}
+
+func (w *ScheduleWindow) String() string {
+	if w == nil {
+		return "none"
+	}
+	return w.Spec
+}
+
+// activeScheduleWindow returns first window, that covers current time
+func (s *Service) activeScheduleWindow() *ScheduleWindow {
+	if !s.config.BandwidthScheduleEnabled {
+		return nil
+	}
+
+	now := time.Now()
+	for _, w := range s.schedule {
+		if w.IsActive(now) {
+			return w
+		}
+	}
+	return nil
+}
+
+// loadSchedule parses schedule from current configuration
+func (s *Service) loadSchedule() {
+	s.muSchedule.Lock()
+	defer s.muSchedule.Unlock()
+
+	s.schedule = nil
+	if !s.config.BandwidthScheduleEnabled {
+		return
+	}
+
+	windows, err := ParseSchedule(s.config.BandwidthSchedule)
+	if err != nil {
+		log.Warningf("Could not parse bandwidth schedule: %s", err)
+		return
+	}
+	s.schedule = windows
+}
+
+// hasPlayers checks whether anything is buffering or playing
+func (s *Service) hasPlayers() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.Players) > 0
+}
+
+// scheduleLoop applies schedule windows, when they start or end
+func (s *Service) scheduleLoop() {
+	closing := s.Closer.C()
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	s.applySchedule(false)
+	for {
+		select {
+		case <-closing:
+			return
+		case <-ticker.C:
+			s.applySchedule(false)
+		}
+	}
+}
+
+// applySchedule sets session pause state and bandwidth limits, according
+// to the active schedule window. Schedule is ignored while something is
+// buffered or played, so streaming is never throttled by it, and limits are
+// left to the player and LimitAfterBuffering logic.
+func (s *Service) applySchedule(force bool) {
+	if s.Closer.IsSet() || s.Session == nil {
+		return
+	}
+
+	s.muSchedule.Lock()
+	defer s.muSchedule.Unlock()
+
+	window := s.activeScheduleWindow()
+	streaming := s.hasPlayers()
+	if !force && window == s.scheduleWindow && streaming == s.scheduleStreaming {
+		return
+	}
+
+	if window != s.scheduleWindow {
+		log.Infof("Bandwidth schedule window changed from '%s' to '%s'", s.scheduleWindow, window)
+		// Manual resume overrides only the window it was made in
+		s.scheduleSkip = nil
+	}
+	s.scheduleWindow = window
+	s.scheduleStreaming = streaming
+
+	s.applySessionPause()
+	if streaming {
+		// Window limits, set before playback started, should not throttle it
+		s.restoreDefaultLimits()
+		return
+	}
+
+	if window != nil && window != s.scheduleSkip && !window.Pause {
+		log.Infof("Bandwidth schedule limits download to %s and upload to %s", humanizeLimit(window.DownloadLimit), humanizeLimit(window.UploadLimit))
+		s.SetDownloadLimit(window.DownloadLimit)
+		s.SetUploadLimit(window.UploadLimit)
+	} else {
+		s.restoreDefaultLimits()
+	}
+}
+
+// applySessionPause pauses or resumes the session, depending on manual pause,
+// schedule and players. Session is never paused while something is streamed,
+// pause is applied again, once playback ends. Should be called with schedule locked.
+func (s *Service) applySessionPause() {
+	pause := s.sessionPaused
+	if w := s.scheduleWindow; w != nil && w.Pause && w != s.scheduleSkip {
+		pause = true
+	}
+	if s.scheduleStreaming {
+		pause = false
+	}
+
+	handle := s.Session.GetHandle()
+	if pause && !handle.IsPaused() {
+		log.Info("Pausing session")
+		handle.Pause()
+	} else if !pause && handle.IsPaused() {
+		log.Info("Resuming session")
+		handle.Resume()
+	}
+}
+
+// restoreDefaultLimits sets limits, used outside of schedule windows
+func (s *Service) restoreDefaultLimits() {
+	if s.config.LimitAfterBuffering {
+		s.SetDownloadLimit(0)
+		s.SetUploadLimit(0)
+	} else {
+		s.RestoreLimits()
+	}
+}
+
+// PauseSession pauses all torrents in the session
+func (s *Service) PauseSession() {
+	s.muSchedule.Lock()
+	defer s.muSchedule.Unlock()
+
+	s.sessionPaused = true
+	s.applySessionPause()
+}
+
+// ResumeSession resumes the session. If it is paused by the schedule,
+// pause is skipped till the end of current window.
+func (s *Service) ResumeSession() {
+	s.muSchedule.Lock()
+	defer s.muSchedule.Unlock()
+
+	s.sessionPaused = false
+	if s.scheduleWindow != nil && s.scheduleWindow.Pause {
+		s.scheduleSkip = s.scheduleWindow
+	}
+	s.applySessionPause()
+}
+
+// GetSessionStatus returns pause state and limits of the session
+func (s *Service) GetSessionStatus() *SessionStatus {
+	s.muSchedule.Lock()
+	defer s.muSchedule.Unlock()
+
+	status := &SessionStatus{
+		Paused:         s.Session.GetHandle().IsPaused(),
+		PausedManually: s.sessionPaused,
+		Streaming:      s.scheduleStreaming,
+		DownloadLimit:  s.downloadLimit,
+		UploadLimit:    s.uploadLimit,
+	}
+	if s.scheduleWindow != nil && s.scheduleWindow != s.scheduleSkip {
+		status.Window = s.scheduleWindow
+	}
+
+	return status
+}
+
+func humanizeLimit(limit int) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+	return humanize.Bytes(uint64(limit)) + "/s"
+}
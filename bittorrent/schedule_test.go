@@ -0,0 +1,97 @@
+package bittorrent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		windows int
+		err     bool
+	}{
+		{"", 0, false},
+		{" ; ", 0, false},
+		{"* 09:00-18:00 pause", 1, false},
+		{"mon-fri 09:00-18:00 pause; sat,sun 23:00-07:00 0/100", 2, false},
+		{"fri-mon 01:00-02:00 100/0", 1, false},
+		{"* 09:00-18:00", 0, true},
+		{"xyz 09:00-18:00 pause", 0, true},
+		{"mon-tue-wed 09:00-18:00 pause", 0, true},
+		{"* 9-18 pause", 0, true},
+		{"* 25:00-18:00 pause", 0, true},
+		{"* 09:00-18:00 100", 0, true},
+		{"* 09:00-18:00 -1/100", 0, true},
+	}
+
+	for _, test := range tests {
+		windows, err := ParseSchedule(test.spec)
+		if (err != nil) != test.err {
+			t.Errorf("ParseSchedule(%q): unexpected error state: %v", test.spec, err)
+			continue
+		}
+		if len(windows) != test.windows {
+			t.Errorf("ParseSchedule(%q): expected %d windows, got %d", test.spec, test.windows, len(windows))
+		}
+	}
+}
+
+func TestParseScheduleWindow(t *testing.T) {
+	w, err := parseScheduleWindow("Fri-Mon 23:30-07:15 512/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	days := [7]bool{true, true, false, false, false, true, true}
+	if w.Days != days {
+		t.Errorf("unexpected days %v", w.Days)
+	}
+	if w.Start != 23*60+30 || w.End != 7*60+15 {
+		t.Errorf("unexpected range %d-%d", w.Start, w.End)
+	}
+	if w.Pause || w.DownloadLimit != 512*1024 || w.UploadLimit != 64*1024 {
+		t.Errorf("unexpected action %+v", w)
+	}
+}
+
+func TestScheduleWindowIsActive(t *testing.T) {
+	// 2020-01-03 is Friday, 2020-01-06 is Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, time.January, day, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		spec   string
+		time   time.Time
+		active bool
+	}{
+		{"* 09:00-18:00 pause", at(6, 9, 0), true},
+		{"* 09:00-18:00 pause", at(6, 17, 59), true},
+		{"* 09:00-18:00 pause", at(6, 18, 0), false},
+		{"* 09:00-18:00 pause", at(6, 8, 59), false},
+		{"mon-fri 09:00-18:00 pause", at(5, 12, 0), false},
+		{"mon-fri 09:00-18:00 pause", at(6, 12, 0), true},
+
+		// Part of a window after midnight belongs to the day it starts
+		{"fri 23:00-07:00 pause", at(3, 23, 30), true},
+		{"fri 23:00-07:00 pause", at(4, 6, 59), true},
+		{"fri 23:00-07:00 pause", at(4, 7, 0), false},
+		{"fri 23:00-07:00 pause", at(4, 23, 30), false},
+		{"fri 23:00-07:00 pause", at(3, 6, 0), false},
+		{"sun 23:00-07:00 pause", at(6, 3, 0), true},
+		{"sun 23:00-07:00 pause", at(6, 12, 0), false},
+		{"* 23:00-07:00 pause", at(6, 22, 59), false},
+		{"* 23:00-07:00 pause", at(6, 0, 0), true},
+	}
+
+	for _, test := range tests {
+		w, err := parseScheduleWindow(test.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := w.IsActive(test.time); got != test.active {
+			t.Errorf("%q at %s: expected %v, got %v", test.spec, test.time.Format("Mon 15:04"), test.active, got)
+		}
+	}
+}
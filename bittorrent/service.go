@@ -64,6 +64,21 @@ type Service struct {
 
 	MarkedToMove string
 
+	downloadLimit int
+	uploadLimit   int
+
+	muSchedule        sync.Mutex
+	schedule          []*ScheduleWindow
+	scheduleWindow    *ScheduleWindow
+	scheduleSkip      *ScheduleWindow
+	scheduleStreaming bool
+	sessionPaused     bool
+
+	muWatch         sync.Mutex
+	watchProcessing map[string]bool
+	watchAdding     map[string]bool
+	watchSkipped    map[string]time.Time
+
 	alertsBroadcaster *broadcast.Broadcaster
 	Closer            util.Event
 	isShutdown        bool
@@ -89,12 +104,17 @@ func NewService() *Service {
 		SpaceChecked: map[string]bool{},
 		Players:      map[string]*Player{},
 
+		watchProcessing: map[string]bool{},
+		watchAdding:     map[string]bool{},
+		watchSkipped:    map[string]time.Time{},
+
 		alertsBroadcaster: broadcast.NewBroadcaster(),
 	}
 
 	s.q = NewQueue(s)
 
 	s.configure()
+	s.loadSchedule()
 
 	go s.alertsConsumer()
 	go s.logAlerts()
@@ -111,6 +131,8 @@ func NewService() *Service {
 
 	go s.loadTorrentFiles()
 	go s.downloadProgress()
+	go s.scheduleLoop()
+	go s.watchFoldersLoop()
 
 	return s
 }
@@ -156,6 +178,9 @@ func (s *Service) Reconfigure() {
 
 	s.startServices()
 	s.loadTorrentFiles()
+
+	s.loadSchedule()
+	s.applySchedule(true)
 }
 
 func (s *Service) configure() {
@@ -361,6 +386,20 @@ func (s *Service) configure() {
 		settings.SetInt("alert_queue_size", 2500)
 	}
 
+	// Slow torrents are counted as active, so a big batch of torrents
+	// doesn't take bandwidth from streaming
+	if !s.IsMemoryStorage() {
+		activeLimit := -1
+		if s.config.MaxActiveDownloads > 0 && s.config.MaxActiveSeeds > 0 {
+			activeLimit = s.config.MaxActiveDownloads + s.config.MaxActiveSeeds
+		}
+
+		settings.SetInt("active_downloads", queueLimit(s.config.MaxActiveDownloads))
+		settings.SetInt("active_seeds", queueLimit(s.config.MaxActiveSeeds))
+		settings.SetInt("active_limit", activeLimit)
+		settings.SetBool("dont_count_slow_torrents", false)
+	}
+
 	log.Infof("DownloadStorage: %s", Storages[s.config.DownloadStorage])
 	if s.IsMemoryStorage() {
 		needSize := s.config.BufferSize + int(EndBufferSize) + 8*1024*1024
@@ -592,8 +631,33 @@ func (s *Service) checkAvailableSpace(t *Torrent) bool {
 	return true
 }
 
-// AddTorrent ...
-func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
+// AddTorrent adds torrent to the session. Category is optional,
+// without it torrent gets stored category or the one matched by trackers.
+func (s *Service) AddTorrent(uri string, paused bool, category string) (*Torrent, error) {
+	return s.addTorrent(uri, paused, category, "")
+}
+
+// addTorrent adds torrent, saving it into specified path, and waits for its metadata.
+// Without the path, torrent is saved into stored path, category path or download path.
+func (s *Service) addTorrent(uri string, paused bool, category string, savePath string) (*Torrent, error) {
+	t, err := s.startTorrent(uri, paused, category, savePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.HasMetadata() {
+		log.Infof("Waiting for information fetched for torrent: %s", t.InfoHash())
+		<-t.GotInfo()
+		log.Infof("Information fetched for torrent: %s", t.InfoHash())
+	}
+
+	s.onTorrentReady(t)
+	return t, nil
+}
+
+// startTorrent adds torrent into the session, without waiting for its metadata.
+// onTorrentReady should be called, once metadata is received.
+func (s *Service) startTorrent(uri string, paused bool, category string, savePath string) (*Torrent, error) {
 	// To make sure no spaces coming from Web UI
 	uri = strings.TrimSpace(uri)
 
@@ -617,6 +681,7 @@ func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
 	var err error
 	var th lt.TorrentHandle
 	var infoHash string
+	var trackers []string
 
 	// Dummy check if torrent file is a file containing a magnet link
 	if _, err := os.Stat(uri); err == nil {
@@ -647,6 +712,7 @@ func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
 
 		uri = torrent.URI
 		infoHash = torrent.InfoHash
+		trackers = torrent.Trackers
 	} else {
 		if strings.HasPrefix(uri, "http") {
 			torrent := NewTorrentFile(uri)
@@ -666,10 +732,28 @@ func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
 
 		shaHash := info.InfoHash().ToString()
 		infoHash = hex.EncodeToString([]byte(shaHash))
+		trackers = torrentFileTrackers(uri)
+	}
+
+	policy, err := s.resolvePolicy(infoHash, category, trackers)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.IsMemoryStorage() {
+		savePath = s.config.DownloadPath
+	} else if savePath != "" {
+		database.Get().UpdateBTItemSavePath(infoHash, savePath)
+	} else if item := database.Get().GetBTItem(infoHash); item != nil && item.SavePath != "" {
+		savePath = item.SavePath
+	} else if policy != nil && policy.SavePath != "" {
+		savePath = policy.SavePath
+	} else {
+		savePath = s.config.DownloadPath
 	}
 
-	log.Infof("Setting save path to %s", s.config.DownloadPath)
-	torrentParams.SetSavePath(s.config.DownloadPath)
+	log.Infof("Setting save path to %s", savePath)
+	torrentParams.SetSavePath(savePath)
 
 	if !s.IsMemoryStorage() {
 		log.Infof("Checking for fast resume data in %s.fastresume", infoHash)
@@ -709,6 +793,9 @@ func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
 
 	log.Infof("Adding new torrent item with url: %s", uri)
 	t := NewTorrent(s, th, th.TorrentFile(), uri)
+	t.savePath = savePath
+	t.policy = policy
+	t.applyPolicy()
 
 	if s.IsMemoryStorage() {
 		t.MemorySize = s.GetMemorySize()
@@ -717,18 +804,17 @@ func (s *Service) AddTorrent(uri string, paused bool) (*Torrent, error) {
 	t.addedTime = time.Now()
 	s.q.Add(t)
 
-	if !t.HasMetadata() {
-		log.Infof("Waiting for information fetched for torrent: %s", infoHash)
-		<-t.GotInfo()
-		log.Infof("Information fetched for torrent: %s", infoHash)
-	}
+	return t, nil
+}
 
+// onTorrentReady finishes adding of a torrent with received metadata
+func (s *Service) onTorrentReady(t *Torrent) {
 	// Saving torrent file
 	t.onMetadataReceived()
 
 	go t.Watch()
 
-	return t, nil
+	publishTorrent(broadcast.EventTorrentAdded, t)
 }
 
 // RemoveTorrent ...
@@ -745,6 +831,7 @@ func (s *Service) RemoveTorrent(torrent *Torrent, removeFiles bool) bool {
 	if t := s.q.FindByHash(torrent.InfoHash()); t != nil {
 		s.q.Delete(torrent)
 
+		publishTorrent(broadcast.EventTorrentRemoved, t)
 		t.Drop(removeFiles)
 		return true
 	}
@@ -753,12 +840,20 @@ func (s *Service) RemoveTorrent(torrent *Torrent, removeFiles bool) bool {
 }
 
 func (s *Service) onStateChanged(stateAlert lt.StateChangedAlert) {
+	torrentHandle := stateAlert.GetHandle()
+	torrentStatus := torrentHandle.Status(uint(lt.TorrentHandleQueryName))
+	defer lt.DeleteTorrentStatus(torrentStatus)
+
+	shaHash := torrentStatus.GetInfoHash().ToString()
+	infoHash := hex.EncodeToString([]byte(shaHash))
+	broadcast.Publish(broadcast.EventTorrentState, &TorrentEvent{
+		InfoHash: infoHash,
+		Name:     torrentStatus.GetName(),
+		State:    StatusStrings[int(stateAlert.GetState())],
+	})
+
 	switch stateAlert.GetState() {
 	case lt.TorrentStatusDownloading:
-		torrentHandle := stateAlert.GetHandle()
-		torrentStatus := torrentHandle.Status(uint(lt.TorrentHandleQueryName))
-		shaHash := torrentStatus.GetInfoHash().ToString()
-		infoHash := hex.EncodeToString([]byte(shaHash))
 		if spaceChecked, exists := s.SpaceChecked[infoHash]; exists {
 			if spaceChecked == false {
 				if t := s.GetTorrentByHash(infoHash); t != nil {
@@ -933,11 +1028,20 @@ func (s *Service) logAlerts() {
 			log.Errorf("%s: %s", alert.What, alert.Message)
 		} else if alert.Category&int(lt.AlertDebugNotification) != 0 {
 			log.Debugf("%s: %s", alert.What, alert.Message)
+			continue
 		} else if alert.Category&int(lt.AlertPerformanceWarning) != 0 {
 			log.Warningf("%s: %s", alert.What, alert.Message)
 		} else {
 			log.Noticef("%s: %s", alert.What, alert.Message)
 		}
+
+		broadcast.Publish(broadcast.EventAlert, &AlertEvent{
+			Type:     alert.Type,
+			Category: alert.Category,
+			What:     alert.What,
+			Message:  alert.Message,
+			InfoHash: alert.InfoHash,
+		})
 	}
 }
 
@@ -973,14 +1077,16 @@ func (s *Service) loadTorrentFiles() {
 		torrentParams := lt.NewAddTorrentParams()
 		defer lt.DeleteAddTorrentParams(torrentParams)
 
-		t, _ := s.AddTorrent(filePath, s.config.AutoloadTorrentsPaused)
+		t, _ := s.AddTorrent(filePath, s.config.AutoloadTorrentsPaused, "")
 		if t != nil {
 			i := database.Get().GetBTItem(t.InfoHash())
 			if i != nil {
 				t.DBItem = i
 
-				for _, p := range i.Files {
-					if f := t.GetFileByPath(p); f != nil {
+				for _, entry := range i.Files {
+					path, priority := parseFileEntry(entry)
+					if f := t.GetFileByPath(path); f != nil {
+						f.Priority = priority
 						t.DownloadFile(f)
 					}
 				}
@@ -1067,8 +1173,12 @@ func (s *Service) downloadProgress() {
 				status := StatusStrings[int(ts.GetState())]
 				isPaused := ts.GetPaused()
 
+				policy := s.defaultPolicy()
+				savePath := s.config.DownloadPath
 				if t := s.GetTorrentByHash(infoHash); t != nil {
 					status = t.GetStateString()
+					policy = t.Policy()
+					savePath = t.savePath
 				}
 
 				downloadRate := float64(ts.GetDownloadPayloadRate())
@@ -1096,8 +1206,8 @@ func (s *Service) downloadProgress() {
 					seedingTime = finishedTime
 				}
 
-				if !s.IsMemoryStorage() && s.config.SeedTimeLimit > 0 {
-					if seedingTime >= s.config.SeedTimeLimit {
+				if !s.IsMemoryStorage() && policy.SeedTimeLimit > 0 {
+					if seedingTime >= policy.SeedTimeLimit {
 						if !isPaused {
 							log.Warningf("Seeding time limit reached, pausing %s", torrentName)
 							torrentHandle.AutoManaged(false)
@@ -1107,13 +1217,13 @@ func (s *Service) downloadProgress() {
 						status = "Seeded"
 					}
 				}
-				if !s.IsMemoryStorage() && s.config.SeedTimeRatioLimit > 0 {
+				if !s.IsMemoryStorage() && policy.SeedTimeRatioLimit > 0 {
 					timeRatio := 0
 					downloadTime := ts.GetActiveTime() - seedingTime
 					if downloadTime > 1 {
 						timeRatio = seedingTime * 100 / downloadTime
 					}
-					if timeRatio >= s.config.SeedTimeRatioLimit {
+					if timeRatio >= policy.SeedTimeRatioLimit {
 						if !isPaused {
 							log.Warningf("Seeding time ratio reached, pausing %s", torrentName)
 							torrentHandle.AutoManaged(false)
@@ -1123,13 +1233,13 @@ func (s *Service) downloadProgress() {
 						status = "Seeded"
 					}
 				}
-				if !s.IsMemoryStorage() && s.config.ShareRatioLimit > 0 {
+				if !s.IsMemoryStorage() && policy.ShareRatioLimit > 0 {
 					ratio := int64(0)
 					allTimeDownload := ts.GetAllTimeDownload()
 					if allTimeDownload > 0 {
 						ratio = ts.GetAllTimeUpload() * 100 / allTimeDownload
 					}
-					if ratio >= int64(s.config.ShareRatioLimit) {
+					if ratio >= int64(policy.ShareRatioLimit) {
 						if !isPaused {
 							log.Warningf("Share ratio reached, pausing %s", torrentName)
 							torrentHandle.AutoManaged(false)
@@ -1147,7 +1257,7 @@ func (s *Service) downloadProgress() {
 				//
 				// Handle moving completed downloads
 				//
-				if !s.config.CompletedMove || status != "Seeded" || s.anyPlayerIsPlaying() {
+				if !(s.config.CompletedMove || policy.MovePath != "") || status != "Seeded" || s.anyPlayerIsPlaying() {
 					continue
 				}
 				if xbmc.PlayerIsPlaying() {
@@ -1166,14 +1276,21 @@ func (s *Service) downloadProgress() {
 					}
 
 					errMsg := fmt.Sprintf("Missing item type to move files to completed folder for %s", torrentName)
-					if item.Type == "" {
+					if item.Type == "" && policy.MovePath == "" {
+						warnedMissing[infoHash] = true
 						log.Error(errMsg)
 						return errors.New(errMsg)
 					}
 					log.Warning(torrentName, "finished seeding, moving files...")
 
 					// Check paths are valid and writable, and only once
-					if _, exists := pathChecked[item.Type]; !exists {
+					if policy.MovePath != "" {
+						if err := config.IsWritablePath(policy.MovePath); err != nil {
+							warnedMissing[infoHash] = true
+							log.Error(err)
+							return err
+						}
+					} else if _, exists := pathChecked[item.Type]; !exists {
 						if item.Type == "movie" {
 							if err := config.IsWritablePath(s.config.CompletedMoviesPath); err != nil {
 								warnedMissing[infoHash] = true
@@ -1198,7 +1315,7 @@ func (s *Service) downloadProgress() {
 					s.RemoveTorrent(t, false)
 
 					// Delete leftover .parts file if any
-					partsFile := filepath.Join(config.Get().DownloadPath, fmt.Sprintf(".%s.parts", infoHash))
+					partsFile := filepath.Join(savePath, fmt.Sprintf(".%s.parts", infoHash))
 					os.Remove(partsFile)
 
 					// Delete fast resume data
@@ -1226,7 +1343,8 @@ func (s *Service) downloadProgress() {
 					}
 
 					torrentInfo := torrentHandle.TorrentFile()
-					for _, fp := range item.Files {
+					for _, entry := range item.Files {
+						fp, _ := parseFileEntry(entry)
 						f := t.GetFileByPath(fp)
 
 						filePath := torrentInfo.Files().FilePath(f.Index)
@@ -1235,7 +1353,7 @@ func (s *Service) downloadProgress() {
 						extracted := ""
 						re := regexp.MustCompile("(?i).*\\.rar")
 						if re.MatchString(fileName) {
-							extractedPath := filepath.Join(s.config.DownloadPath, filepath.Dir(filePath), "extracted")
+							extractedPath := filepath.Join(savePath, filepath.Dir(filePath), "extracted")
 							files, err := ioutil.ReadDir(extractedPath)
 							if err != nil {
 								return err
@@ -1260,7 +1378,9 @@ func (s *Service) downloadProgress() {
 						}
 
 						var dstPath string
-						if item.Type == "movie" {
+						if policy.MovePath != "" {
+							dstPath = policy.MovePath
+						} else if item.Type == "movie" {
 							dstPath = filepath.Dir(s.config.CompletedMoviesPath)
 						} else {
 							dstPath = filepath.Dir(s.config.CompletedShowsPath)
@@ -1280,7 +1400,7 @@ func (s *Service) downloadProgress() {
 
 						go func() {
 							log.Infof("Moving %s to %s", fileName, dstPath)
-							srcPath := filepath.Join(s.config.DownloadPath, filePath)
+							srcPath := filepath.Join(savePath, filePath)
 							if dst, err := util.Move(srcPath, dstPath); err != nil {
 								log.Error(err)
 							} else {
@@ -1289,7 +1409,7 @@ func (s *Service) downloadProgress() {
 									os.RemoveAll(filepath.Dir(srcPath))
 									if extracted != "" {
 										parentPath := filepath.Clean(filepath.Join(filepath.Dir(srcPath), ".."))
-										if parentPath != "." && parentPath != s.config.DownloadPath {
+										if parentPath != "." && parentPath != savePath {
 											os.RemoveAll(parentPath)
 										}
 									}
@@ -1338,6 +1458,8 @@ func (s *Service) downloadProgress() {
 
 // SetDownloadLimit ...
 func (s *Service) SetDownloadLimit(i int) {
+	s.downloadLimit = i
+
 	settings := s.PackSettings
 	settings.SetInt("download_rate_limit", i)
 
@@ -1346,6 +1468,8 @@ func (s *Service) SetDownloadLimit(i int) {
 
 // SetUploadLimit ...
 func (s *Service) SetUploadLimit(i int) {
+	s.uploadLimit = i
+
 	settings := s.PackSettings
 
 	settings.SetInt("upload_rate_limit", i)
@@ -1446,6 +1570,9 @@ func (s *Service) AttachPlayer(p *Player) {
 		return
 	}
 
+	// Schedule is suspended while something is played
+	defer s.applySchedule(false)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -1454,6 +1581,7 @@ func (s *Service) AttachPlayer(p *Player) {
 	}
 
 	s.Players[p.t.InfoHash()] = p
+	s.q.Prioritize(p.t)
 }
 
 // DetachPlayer removes Player instance
@@ -1462,10 +1590,14 @@ func (s *Service) DetachPlayer(p *Player) {
 		return
 	}
 
+	// Schedule is suspended while something is played
+	defer s.applySchedule(false)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	delete(s.Players, p.t.InfoHash())
+	s.q.Release(p.t)
 }
 
 // GetPlayer searches for player with desired TMDB id
@@ -1640,9 +1772,20 @@ func (s *Service) GetTorrentByFakeID(query string) *Torrent {
 	return nil
 }
 
-// GetTorrents return all active torrents
+// MoveInQueue changes queue position of a torrent
+func (s *Service) MoveInQueue(t *Torrent, action string) error {
+	return s.q.Move(t, action)
+}
+
+// ForceStart starts torrent regardless of queue limits,
+// or returns it into the queue
+func (s *Service) ForceStart(t *Torrent, force bool) error {
+	return s.q.ForceStart(t, force)
+}
+
+// GetTorrents return all active torrents, in the order of queue positions
 func (s *Service) GetTorrents() []*Torrent {
-	return s.q.All()
+	return s.q.Sorted()
 }
 
 // GetListenIP returns calculated IP for TCP/TCP6
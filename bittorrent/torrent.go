@@ -48,6 +48,12 @@ type Torrent struct {
 	ChosenFiles []*File
 	TorrentPath string
 
+	savePath string
+	policy   *Category
+
+	forceStart bool
+	hasPlayer  bool
+
 	Service *Service
 
 	BufferLength           int64
@@ -687,6 +693,9 @@ func (t *Torrent) GetStateString() string {
 	} else if torrentStatus.GetPaused() && state != StatusFinished && state != StatusFinding {
 		if progress == 100 {
 			return StatusStrings[StatusFinished]
+		} else if torrentStatus.GetAutoManaged() {
+			// Paused auto managed torrent waits for a free slot
+			return StatusStrings[StatusQueued]
 		}
 
 		return StatusStrings[StatusPaused]
@@ -780,58 +789,46 @@ func (t *Torrent) GetProgress() float64 {
 
 // DownloadAllFiles ...
 func (t *Torrent) DownloadAllFiles() {
-	selected := []string{}
 	for _, f := range t.files {
 		t.DownloadFile(f)
-		selected = append(selected, f.Path)
 	}
 
-	database.Get().UpdateBTItemFiles(t.infoHash, selected)
-	t.FetchDBItem()
+	t.SaveFiles()
 }
 
 // UnDownloadAllFiles ...
 func (t *Torrent) UnDownloadAllFiles() {
-	selected := []string{}
-	for _, f := range t.ChosenFiles {
+	for _, f := range append([]*File{}, t.ChosenFiles...) {
 		t.UnDownloadFile(f)
 	}
 
-	database.Get().UpdateBTItemFiles(t.infoHash, selected)
-	t.FetchDBItem()
+	t.SaveFiles()
 }
 
 // DownloadFile ...
 func (t *Torrent) DownloadFile(addFile *File) {
 	addFile.Selected = true
-	t.ChosenFiles = append(t.ChosenFiles, addFile)
+	if addFile.Priority == FilePrioritySkip {
+		addFile.Priority = FilePriorityNormal
+	}
+	if t.chosenFileIndex(addFile) == -1 {
+		t.ChosenFiles = append(t.ChosenFiles, addFile)
+	}
 
 	if t.Service.IsMemoryStorage() {
 		return
 	}
 
-	for _, f := range t.files {
-		if f != addFile {
-			continue
-		}
-
-		log.Debugf("Choosing file for download: %s", f.Path)
-		t.th.FilePriority(f.Index, 1)
-	}
+	log.Debugf("Choosing file for download: %s", addFile.Path)
+	t.applyFilePriorities()
 }
 
 // UnDownloadFile ...
 func (t *Torrent) UnDownloadFile(addFile *File) bool {
 	addFile.Selected = false
+	addFile.Priority = FilePrioritySkip
 
-	idx := -1
-	for i, f := range t.ChosenFiles {
-		if f.Index == addFile.Index {
-			idx = i
-			break
-		}
-	}
-
+	idx := t.chosenFileIndex(addFile)
 	if idx == -1 {
 		return false
 	}
@@ -844,9 +841,104 @@ func (t *Torrent) UnDownloadFile(addFile *File) bool {
 	}
 
 	t.th.FilePriority(addFile.Index, 0)
+	t.applyFilePriorities()
 	return true
 }
 
+// SetFilePriority changes priority of a file, skip priority removes it
+// from the download sequence
+func (t *Torrent) SetFilePriority(f *File, priority int) {
+	if priority == FilePrioritySkip {
+		t.UnDownloadFile(f)
+	} else {
+		f.Priority = priority
+		t.DownloadFile(f)
+	}
+
+	t.SaveFiles()
+}
+
+// MoveFile moves chosen file to a position in the download sequence
+func (t *Torrent) MoveFile(f *File, position int) error {
+	idx := t.chosenFileIndex(f)
+	if idx == -1 {
+		return fmt.Errorf("File %s is not chosen for download", f.Path)
+	}
+
+	position = util.Max(0, util.Min(position, len(t.ChosenFiles)-1))
+	t.ChosenFiles = append(t.ChosenFiles[:idx], t.ChosenFiles[idx+1:]...)
+	t.ChosenFiles = append(t.ChosenFiles[:position], append([]*File{f}, t.ChosenFiles[position:]...)...)
+
+	if !t.Service.IsMemoryStorage() {
+		t.applyFilePriorities()
+	}
+
+	t.SaveFiles()
+	return nil
+}
+
+// SaveFiles stores chosen files, their priorities and order in BTItem
+func (t *Torrent) SaveFiles() {
+	files := make([]string, 0, len(t.ChosenFiles))
+	for _, f := range t.ChosenFiles {
+		files = append(files, f.dbEntry())
+	}
+
+	database.Get().UpdateBTItemFiles(t.infoHash, files)
+	t.FetchDBItem()
+}
+
+// GetFilePosition returns position of a file in the download sequence, or -1
+func (t *Torrent) GetFilePosition(f *File) int {
+	return t.chosenFileIndex(f)
+}
+
+// GetFileProgress returns percent of downloaded pieces of a file
+func (t *Torrent) GetFileProgress(f *File) float64 {
+	if f.Size == 0 || f.PieceEnd < f.PieceStart {
+		return 0
+	}
+
+	completed := 0
+	for i := f.PieceStart; i <= f.PieceEnd; i++ {
+		if t.hasPiece(i) {
+			completed++
+		}
+	}
+
+	return float64(completed) / float64(f.PieceEnd-f.PieceStart+1) * 100
+}
+
+func (t *Torrent) chosenFileIndex(f *File) int {
+	for i, cf := range t.ChosenFiles {
+		if cf.Index == f.Index {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyFilePriorities sets libtorrent priorities of chosen files,
+// according to their priority and position in the download sequence
+func (t *Torrent) applyFilePriorities() {
+	counts := make([]int, len(filePriorityBands))
+	for _, f := range t.ChosenFiles {
+		if f.Priority > FilePrioritySkip && f.Priority < len(filePriorityBands) {
+			counts[f.Priority]++
+		}
+	}
+
+	positions := make([]int, len(filePriorityBands))
+	for _, f := range t.ChosenFiles {
+		if f.Priority <= FilePrioritySkip || f.Priority >= len(filePriorityBands) {
+			continue
+		}
+
+		t.th.FilePriority(f.Index, bandPriority(f.Priority, positions[f.Priority], counts[f.Priority]))
+		positions[f.Priority]++
+	}
+}
+
 // InfoHash ...
 func (t *Torrent) InfoHash() string {
 	if t.th == nil {
@@ -941,7 +1033,7 @@ func (t *Torrent) Pause() {
 func (t *Torrent) Resume() {
 	log.Infof("Resuming torrent: %s", t.InfoHash())
 
-	t.th.AutoManaged(true)
+	t.th.AutoManaged(!t.forceStart && !t.hasPlayer)
 	t.th.Resume()
 
 	t.IsPaused = false
@@ -1111,6 +1203,11 @@ func (t *Torrent) MakeFiles() {
 	}
 }
 
+// Files returns all files of the torrent
+func (t *Torrent) Files() []*File {
+	return t.files
+}
+
 // GetFileByPath ...
 func (t *Torrent) GetFileByPath(q string) *File {
 	for _, f := range t.files {
@@ -1198,7 +1295,7 @@ func (t *Torrent) onMetadataReceived() {
 	// Reset fastResumeFile
 	infoHash := t.InfoHash()
 	t.fastResumeFile = filepath.Join(t.Service.config.TorrentsPath, fmt.Sprintf("%s.fastresume", infoHash))
-	t.partsFile = filepath.Join(t.Service.config.DownloadPath, fmt.Sprintf(".%s.parts", infoHash))
+	t.partsFile = filepath.Join(t.savePath, fmt.Sprintf(".%s.parts", infoHash))
 
 	go t.SaveMetainfo(t.Service.config.TorrentsPath)
 }
@@ -1244,7 +1341,7 @@ func (t *Torrent) HasAvailableFiles() bool {
 
 // GetFilePieces ...
 func (t *Torrent) GetFilePieces(files lt.FileStorage, idx int) (ret PieceRange) {
-	ret.Begin, ret.End = t.byteRegionPieces(files.FileOffset(idx), files.FileOffset(idx)+files.FileSize(idx))
+	ret.Begin, ret.End = t.byteRegionPieces(files.FileOffset(idx), files.FileSize(idx))
 	return
 }
 
@@ -50,6 +50,7 @@ type TorrentFSEntry struct {
 
 	lastUsed time.Time
 	isActive bool
+	wasRead  bool
 }
 
 // PieceRange ...
@@ -72,8 +73,27 @@ func (tfs *TorrentFS) Open(uname string) (http.File, error) {
 	var file http.File
 	var err error
 
+	// Torrents from categories can be saved outside of download path
+	var torrent *Torrent
+	var torrentFile *File
+	dir := string(tfs.Dir)
+	for _, t := range tfs.s.q.All() {
+		for _, f := range t.files {
+			if name[1:] == f.Path {
+				torrent, torrentFile = t, f
+				if t.savePath != "" {
+					dir = t.savePath
+				}
+				break
+			}
+		}
+		if torrent != nil {
+			break
+		}
+	}
+
 	if tfs.s.config.DownloadStorage == StorageFile {
-		file, err = os.Open(filepath.Join(string(tfs.Dir), name))
+		file, err = os.Open(filepath.Join(dir, name))
 		if err != nil {
 			return nil, err
 		}
@@ -87,13 +107,9 @@ func (tfs *TorrentFS) Open(uname string) (http.File, error) {
 
 	log.Infof("Opening %s", name)
 
-	for _, t := range tfs.s.q.All() {
-		for _, f := range t.files {
-			if name[1:] == f.Path {
-				log.Noticef("%s belongs to torrent %s", name, t.Name())
-				return NewTorrentFSEntry(file, tfs, t, f, name)
-			}
-		}
+	if torrent != nil {
+		log.Noticef("%s belongs to torrent %s", name, torrent.Name())
+		return NewTorrentFSEntry(file, tfs, torrent, torrentFile, name)
 	}
 
 	return file, fmt.Errorf("Could not open file: %s", name)
@@ -235,6 +251,11 @@ func (tf *TorrentFSEntry) Read(data []byte) (n int, err error) {
 			}
 			return
 		} else if n1 > 0 {
+			if !tf.wasRead {
+				tf.wasRead = true
+				tf.t.playbackLog().FirstByte()
+			}
+
 			n += n1
 			left -= n1
 			pos += n1
@@ -295,8 +316,11 @@ func (tf *TorrentFSEntry) waitForPiece(piece int) error {
 
 	defer perf.ScopeTimer()()
 	log.Warningf("Waiting for piece %d", piece)
+	pieceStallMetric.Inc()
 	now := time.Now()
 	defer func() {
+		pieceWaitMetric.ObserveSince(now)
+		tf.t.playbackLog().Stall(piece, time.Since(now))
 		log.Warningf("Waiting for piece %d finished in %s", piece, time.Since(now))
 	}()
 
@@ -0,0 +1,332 @@
+package bittorrent
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/bencode"
+)
+
+// Watch folders are defined as a list, separated by ';'. Each folder is
+//   <path> | <key>=<value>, <key>=<value>, ...
+// with keys:
+//   category - category of added torrents
+//   paused   - add torrents paused, "true" or "false"
+//   path     - save path of added torrents
+// Dropped .torrent files, and .magnet or .txt files with magnet links, one per
+// line, are added and moved into .added or .failed subfolders. Text files
+// without magnet links are left alone, and not read again until they change.
+// Torrents, that are already added, are not added again.
+// Example: "/home/user/Downloads; /srv/watch/tv | category=tv, paused=true"
+
+const (
+	watchInterval   = 10 * time.Second
+	watchSettleTime = 3 * time.Second
+	watchAddedDir   = ".added"
+	watchFailedDir  = ".failed"
+	// Text files bigger than that are not magnet lists
+	watchMaxListSize = 1024 * 1024
+)
+
+// WatchFolder is a folder, that is checked for new torrent and magnet files
+type WatchFolder struct {
+	Path     string `json:"path"`
+	Category string `json:"category"`
+	Paused   bool   `json:"paused"`
+	SavePath string `json:"save_path"`
+}
+
+// ParseWatchFolders parses watch folders string
+func ParseWatchFolders(spec string) (folders []*WatchFolder, err error) {
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		f, err := parseWatchFolder(part)
+		if err != nil {
+			return nil, fmt.Errorf("Bad watch folder '%s': %s", part, err)
+		}
+		folders = append(folders, f)
+	}
+
+	return
+}
+
+func parseWatchFolder(spec string) (*WatchFolder, error) {
+	parts := strings.SplitN(spec, "|", 2)
+	f := &WatchFolder{Path: strings.TrimSpace(parts[0])}
+	if f.Path == "" {
+		return nil, fmt.Errorf("missing path")
+	} else if len(parts) == 1 {
+		return f, nil
+	}
+
+	for _, option := range strings.Split(parts[1], ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("expected '<key>=<value>' in '%s'", option)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+
+		switch key {
+		case "category":
+			f.Category = value
+		case "paused":
+			paused, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("bad value '%s' for '%s'", value, key)
+			}
+			f.Paused = paused
+		case "path":
+			f.SavePath = value
+		default:
+			return nil, fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+
+	return f, nil
+}
+
+// GetWatchFolders returns watch folders from current configuration
+func (s *Service) GetWatchFolders() []*WatchFolder {
+	folders, err := ParseWatchFolders(s.config.WatchFolders)
+	if err != nil {
+		log.Warningf("Could not parse watch folders: %s", err)
+	}
+	return folders
+}
+
+// watchFoldersLoop polls watch folders for new files
+func (s *Service) watchFoldersLoop() {
+	closing := s.Closer.C()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closing:
+			return
+		case <-ticker.C:
+			s.scanWatchFolders()
+		}
+	}
+}
+
+func (s *Service) scanWatchFolders() {
+	if s.Closer.IsSet() || s.Session == nil || s.IsMemoryStorage() || s.config.WatchFolders == "" {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, folder := range s.GetWatchFolders() {
+		s.scanWatchFolder(folder, seen)
+	}
+
+	// Skipped files, that are removed, are forgotten
+	s.muWatch.Lock()
+	for path := range s.watchSkipped {
+		if !seen[path] {
+			delete(s.watchSkipped, path)
+		}
+	}
+	s.muWatch.Unlock()
+}
+
+// scanWatchFolder starts adding of files, that are not written anymore.
+// Each file is added in background, since magnet links can wait
+// for metadata for a long time.
+func (s *Service) scanWatchFolder(folder *WatchFolder, seen map[string]bool) {
+	files, err := ioutil.ReadDir(folder.Path)
+	if err != nil {
+		log.Warningf("Could not read watch folder %s: %s", folder.Path, err)
+		return
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !isWatchedFile(f) || time.Since(f.ModTime()) < watchSettleTime {
+			continue
+		}
+
+		path := filepath.Join(folder.Path, f.Name())
+		seen[path] = true
+
+		s.muWatch.Lock()
+		if skipped, ok := s.watchSkipped[path]; s.watchProcessing[path] || (ok && skipped.Equal(f.ModTime())) {
+			s.muWatch.Unlock()
+			continue
+		}
+		s.watchProcessing[path] = true
+		s.muWatch.Unlock()
+
+		go s.addWatchedFile(folder, path, f.ModTime())
+	}
+}
+
+func (s *Service) addWatchedFile(folder *WatchFolder, path string, modTime time.Time) {
+	defer func() {
+		s.muWatch.Lock()
+		delete(s.watchProcessing, path)
+		s.muWatch.Unlock()
+	}()
+
+	uris := []string{path}
+	if !strings.HasSuffix(strings.ToLower(path), ".torrent") {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Warningf("Could not read %s: %s", path, err)
+			return
+		}
+		uris = magnetLinks(data)
+		if len(uris) == 0 && strings.HasSuffix(strings.ToLower(path), ".txt") {
+			s.muWatch.Lock()
+			s.watchSkipped[path] = modTime
+			s.muWatch.Unlock()
+			return
+		}
+	}
+
+	failed := 0
+	for _, uri := range uris {
+		if err := s.addWatchedURI(folder, uri); err != nil {
+			log.Warningf("Could not add %s from watch folder: %s", uri, err)
+			failed++
+		}
+	}
+
+	dir := watchAddedDir
+	if failed > 0 || len(uris) == 0 {
+		dir = watchFailedDir
+	}
+	if err := moveWatchedFile(path, filepath.Join(folder.Path, dir)); err != nil {
+		log.Warningf("Could not move %s into %s: %s", path, dir, err)
+	}
+}
+
+// addWatchedURI adds a torrent, unless it is already added
+// or is being added from another file
+func (s *Service) addWatchedURI(folder *WatchFolder, uri string) error {
+	infoHash := watchedInfoHash(uri)
+	if infoHash == "" {
+		return fmt.Errorf("Could not read infohash")
+	}
+
+	s.muWatch.Lock()
+	if s.watchAdding[infoHash] || s.GetTorrentByHash(infoHash) != nil {
+		s.muWatch.Unlock()
+		log.Infof("Torrent %s from %s is already added", infoHash, uri)
+		return nil
+	}
+	s.watchAdding[infoHash] = true
+	s.muWatch.Unlock()
+
+	defer func() {
+		s.muWatch.Lock()
+		delete(s.watchAdding, infoHash)
+		s.muWatch.Unlock()
+	}()
+
+	log.Infof("Adding %s from watch folder %s", uri, folder.Path)
+	t, err := s.startTorrent(uri, folder.Paused, folder.Category, folder.SavePath)
+	if err != nil {
+		return err
+	}
+
+	// Magnet links can wait for metadata for a long time,
+	// so the file is moved without waiting for it
+	go s.downloadWhenReady(t)
+	return nil
+}
+
+// watchedInfoHash returns infohash of a magnet link or a torrent file
+func watchedInfoHash(uri string) string {
+	if strings.HasPrefix(uri, "magnet:") {
+		return NewTorrentFile(uri).InfoHash
+	}
+
+	data, err := ioutil.ReadFile(uri)
+	if err != nil {
+		return ""
+	}
+	// Torrent files can contain a magnet link, like in startTorrent
+	if bytes.HasPrefix(data, []byte("magnet:")) {
+		return NewTorrentFile(strings.TrimSpace(string(data))).InfoHash
+	}
+
+	var raw *TorrentFileRaw
+	if err := bencode.DecodeBytes(data, &raw); err != nil || raw == nil || raw.Info == nil {
+		return ""
+	}
+	hasher := sha1.New()
+	bencode.NewEncoder(hasher).Encode(raw.Info)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// downloadWhenReady waits for torrent metadata and selects all files for download
+func (s *Service) downloadWhenReady(t *Torrent) {
+	if !t.HasMetadata() {
+		select {
+		case <-t.GotInfo():
+		case <-s.Closer.C():
+			return
+		}
+	}
+
+	s.onTorrentReady(t)
+	t.DownloadAllFiles()
+}
+
+func isWatchedFile(f os.FileInfo) bool {
+	if strings.HasPrefix(f.Name(), ".") {
+		return false
+	}
+
+	switch strings.ToLower(filepath.Ext(f.Name())) {
+	case ".torrent", ".magnet":
+		return true
+	case ".txt":
+		return f.Size() <= watchMaxListSize
+	}
+	return false
+}
+
+// magnetLinks returns magnet links, written one per line
+func magnetLinks(data []byte) (links []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "magnet:") {
+			links = append(links, line)
+		}
+	}
+	return
+}
+
+// moveWatchedFile moves processed file into a subfolder, keeping
+// previous files with the same name
+func moveWatchedFile(path string, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	target := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(path)
+		target = filepath.Join(dir, fmt.Sprintf("%s.%d%s", strings.TrimSuffix(filepath.Base(path), ext), time.Now().Unix(), ext))
+	}
+	return os.Rename(path, target)
+}
@@ -0,0 +1,66 @@
+package broadcast
+
+import "sync"
+
+// Event types of the state events stream
+const (
+	EventTorrentAdded   = "torrent_added"
+	EventTorrentRemoved = "torrent_removed"
+	EventTorrentState   = "torrent_state"
+	EventTorrents       = "torrents"
+	EventAlert          = "alert"
+	EventPlayer         = "player"
+	EventLibrary        = "library"
+)
+
+// Event is a state change of torrents, players or library
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// EventsBuffer is a number of events, queued for a client. Client, that falls
+// behind by more events, is dropped, so it can't hold memory of the process.
+const EventsBuffer = 256
+
+var (
+	subscribersMu sync.Mutex
+	subscribers   = map[chan *Event]bool{}
+)
+
+// Subscribe returns a channel of published events, and a function, that
+// stops them. Channel is closed, when the client is dropped for falling behind.
+func Subscribe() (<-chan *Event, func()) {
+	c := make(chan *Event, EventsBuffer)
+
+	subscribersMu.Lock()
+	subscribers[c] = true
+	subscribersMu.Unlock()
+
+	return c, func() {
+		subscribersMu.Lock()
+		defer subscribersMu.Unlock()
+
+		if subscribers[c] {
+			delete(subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// Publish sends an event to all event stream clients, without waiting for them
+func Publish(eventType string, data interface{}) {
+	e := &Event{Type: eventType, Data: data}
+
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	for c := range subscribers {
+		select {
+		case c <- e:
+		default:
+			delete(subscribers, c)
+			close(c)
+		}
+	}
+}
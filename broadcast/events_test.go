@@ -0,0 +1,51 @@
+package broadcast
+
+import "testing"
+
+func TestSubscribe(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	other, unsubscribeOther := Subscribe()
+	defer unsubscribeOther()
+
+	Publish(EventLibrary, "update")
+	for _, c := range []<-chan *Event{events, other} {
+		if e := <-c; e.Type != EventLibrary || e.Data != "update" {
+			t.Fatalf("received wrong event %+v", e)
+		}
+	}
+
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Fatal("channel is not closed after unsubscribe")
+	}
+	// Second call does nothing
+	unsubscribe()
+
+	Publish(EventLibrary, "second")
+	if e := <-other; e.Data != "second" {
+		t.Fatalf("received wrong event %+v", e)
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= EventsBuffer; i++ {
+		Publish(EventTorrents, i)
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	if received != EventsBuffer {
+		t.Fatalf("expected %d buffered events before drop, received %d", EventsBuffer, received)
+	}
+
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	if len(subscribers) != 0 {
+		t.Fatalf("dropped subscriber is still registered")
+	}
+}
@@ -125,6 +125,7 @@ type Configuration struct {
 	TraktSyncUserlists             bool
 	TraktSyncWatched               bool
 	TraktSyncWatchedBack           bool
+	TraktSyncPlayback              bool
 	TraktSyncAddedMovies           bool
 	TraktSyncAddedMoviesLocation   int
 	TraktSyncAddedMoviesList       int
@@ -200,6 +201,33 @@ type Configuration struct {
 	CompletedShowsPath  string
 
 	LocalOnlyClient bool
+
+	BandwidthScheduleEnabled bool
+	BandwidthSchedule        string
+
+	APIToken          string
+	APIUsername       string
+	APIPassword       string
+	APIAllowedOrigins string
+
+	TorrentCategories string
+
+	MaxActiveDownloads int
+	MaxActiveSeeds     int
+
+	WatchFolders string
+
+	TVDBApiKey string
+	TVDBPin    string
+
+	// LanguageFallbacks are comma separated languages, that metadata fields
+	// are taken from, when they are not translated into Language
+	LanguageFallbacks string
+
+	// ImageCache serves list item art from a local cache,
+	// that keeps up to ImageCacheSize megabytes
+	ImageCache     bool
+	ImageCacheSize int
 }
 
 // Addon ...
@@ -528,6 +556,7 @@ func Reload() *Configuration {
 		TraktSyncUserlists:             settings["trakt_sync_userlists"].(bool),
 		TraktSyncWatched:               settings["trakt_sync_watched"].(bool),
 		TraktSyncWatchedBack:           settings["trakt_sync_watchedback"].(bool),
+		TraktSyncPlayback:              settings["trakt_sync_playback"].(bool),
 		TraktSyncAddedMovies:           settings["trakt_sync_added_movies"].(bool),
 		TraktSyncAddedMoviesLocation:   settings["trakt_sync_added_movies_location"].(int),
 		TraktSyncAddedMoviesList:       settings["trakt_sync_added_movies_list"].(int),
@@ -602,6 +631,29 @@ func Reload() *Configuration {
 		CompletedShowsPath:  settings["completed_shows_path"].(string),
 
 		LocalOnlyClient: settings["local_only_client"].(bool),
+
+		BandwidthScheduleEnabled: settings["bandwidth_schedule_enabled"].(bool),
+		BandwidthSchedule:        settings["bandwidth_schedule"].(string),
+
+		APIToken:          settings["api_token"].(string),
+		APIUsername:       settings["api_username"].(string),
+		APIPassword:       settings["api_password"].(string),
+		APIAllowedOrigins: settings["api_allowed_origins"].(string),
+
+		TorrentCategories: settings["torrent_categories"].(string),
+
+		MaxActiveDownloads: settings["max_active_downloads"].(int),
+		MaxActiveSeeds:     settings["max_active_seeds"].(int),
+
+		WatchFolders: settings["watch_folders"].(string),
+
+		TVDBApiKey: settings["tvdb_api_key"].(string),
+		TVDBPin:    settings["tvdb_pin"].(string),
+
+		LanguageFallbacks: settings["language_fallbacks"].(string),
+
+		ImageCache:     settings["image_cache"].(bool),
+		ImageCacheSize: settings["image_cache_size"].(int),
 	}
 
 	// Fallback for old configuration with additional storage variants
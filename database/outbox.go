@@ -0,0 +1,82 @@
+package database
+
+import (
+	"time"
+)
+
+// Trakt outbox handlers
+
+// AddOutboxItem stores Trakt change for a later replay.
+// Pending item with the same dedup key is replaced, so only the latest
+// change for the same object is sent.
+func (d *SqliteDatabase) AddOutboxItem(kind, endPoint, payload, dedup string, lastError string) error {
+	if dedup != "" {
+		d.Exec(`DELETE FROM trakt_outbox WHERE dedup = ? AND state = ?`, dedup, OutboxPending)
+	}
+
+	now := time.Now().Unix()
+	_, err := d.Exec(`INSERT INTO trakt_outbox (kind, endpoint, payload, dedup, state, attempts, error, created, updated) VALUES (?, ?, ?, ?, ?, 0, ?, ?, ?)`, kind, endPoint, payload, dedup, OutboxPending, lastError, now, now)
+	if err != nil {
+		log.Warningf("Could not add item to Trakt outbox: %s", err)
+	}
+	return err
+}
+
+// GetOutboxItems returns items with specified state, oldest first
+func (d *SqliteDatabase) GetOutboxItems(state int, limit int) (items []*OutboxItem) {
+	rows, err := d.Query(`SELECT rowid, kind, endpoint, payload, dedup, state, attempts, error, created, updated FROM trakt_outbox WHERE state = ? ORDER BY created, rowid LIMIT ?`, state, limit)
+	if err != nil {
+		log.Debugf("Could not get Trakt outbox items: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	var created, updated int64
+	for rows.Next() {
+		item := &OutboxItem{}
+		if err := rows.Scan(&item.ID, &item.Kind, &item.EndPoint, &item.Payload, &item.Dedup, &item.State, &item.Attempts, &item.Error, &created, &updated); err != nil {
+			continue
+		}
+		item.Created = time.Unix(created, 0)
+		item.Updated = time.Unix(updated, 0)
+		items = append(items, item)
+	}
+
+	return
+}
+
+// GetOutboxCount returns number of items with specified state
+func (d *SqliteDatabase) GetOutboxCount(state int) (count int) {
+	d.QueryRow(`SELECT COUNT(*) FROM trakt_outbox WHERE state = ?`, state).Scan(&count)
+	return
+}
+
+// UpdateOutboxItem saves result of a replay attempt
+func (d *SqliteDatabase) UpdateOutboxItem(id int64, state int, attempts int, lastError string) error {
+	_, err := d.Exec(`UPDATE trakt_outbox SET state = ?, attempts = ?, error = ?, updated = ? WHERE rowid = ?`, state, attempts, lastError, time.Now().Unix(), id)
+	return err
+}
+
+// RetryOutboxItem moves failed item back to the pending state,
+// zero id means all failed items
+func (d *SqliteDatabase) RetryOutboxItem(id int64) error {
+	if id == 0 {
+		_, err := d.Exec(`UPDATE trakt_outbox SET state = ?, attempts = 0 WHERE state = ?`, OutboxPending, OutboxFailed)
+		return err
+	}
+
+	_, err := d.Exec(`UPDATE trakt_outbox SET state = ?, attempts = 0 WHERE rowid = ?`, OutboxPending, id)
+	return err
+}
+
+// DeleteOutboxItem ...
+func (d *SqliteDatabase) DeleteOutboxItem(id int64) error {
+	_, err := d.Exec(`DELETE FROM trakt_outbox WHERE rowid = ?`, id)
+	return err
+}
+
+// ClearOutbox removes all items with specified state
+func (d *SqliteDatabase) ClearOutbox(state int) error {
+	_, err := d.Exec(`DELETE FROM trakt_outbox WHERE state = ?`, state)
+	return err
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"time"
+)
+
+// Playback sessions log handlers
+
+// playbackRetention is how long playback sessions are kept
+const playbackRetention = 90 * 24 * time.Hour
+
+const playbackSessionColumns = `id, infohash, name, file, size, provider, contentType, tmdb, showId, season, episode, started, ended, bufferTime, firstByteTime, stalls, stallTime, seeks, endReason, error, watched`
+
+// AddPlaybackSession stores new session and sets its ID.
+// Sessions, older than retention period, are removed.
+func (d *SqliteDatabase) AddPlaybackSession(s *PlaybackSession) error {
+	d.cleanPlaybackSessions()
+
+	res, err := d.Exec(`INSERT INTO playback_sessions (infohash, name, provider, contentType, tmdb, showId, season, episode, started) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		s.InfoHash, s.Name, s.Provider, s.ContentType, s.TMDBId, s.ShowID, s.Season, s.Episode, s.Started.Unix())
+	if err != nil {
+		return err
+	}
+
+	s.ID, err = res.LastInsertId()
+	return err
+}
+
+// UpdatePlaybackSession stores stats of the session
+func (d *SqliteDatabase) UpdatePlaybackSession(s *PlaybackSession) error {
+	var ended int64
+	if !s.Ended.IsZero() {
+		ended = s.Ended.Unix()
+	}
+
+	_, err := d.Exec(`UPDATE playback_sessions SET file = ?, size = ?, ended = ?, bufferTime = ?, firstByteTime = ?, stalls = ?, stallTime = ?, seeks = ?, endReason = ?, error = ?, watched = ? WHERE id = ?`,
+		s.File, s.Size, ended, s.BufferTime, s.FirstByteTime, s.Stalls, s.StallTime, s.Seeks, s.EndReason, s.Error, s.WatchedPercent, s.ID)
+	if err != nil {
+		log.Warningf("Could not save playback session: %s", err)
+	}
+	return err
+}
+
+// AddPlaybackEvent stores an event of the session
+func (d *SqliteDatabase) AddPlaybackEvent(sessionID int64, e *PlaybackEvent) error {
+	_, err := d.Exec(`INSERT INTO playback_events (sessionId, created, type, piece, duration, value) VALUES (?, ?, ?, ?, ?, ?)`,
+		sessionID, e.Created.Unix(), e.Type, e.Piece, e.Duration, e.Value)
+	return err
+}
+
+// GetPlaybackSessions returns sessions, recent first. Sessions can be
+// filtered by infohash and provider, empty filters are ignored.
+func (d *SqliteDatabase) GetPlaybackSessions(infoHash, provider string, limit, offset int) []*PlaybackSession {
+	return d.queryPlaybackSessions(`SELECT `+playbackSessionColumns+` FROM playback_sessions WHERE (? = '' OR infohash = ?) AND (? = '' OR provider = ?) ORDER BY started DESC, id DESC LIMIT ? OFFSET ?`,
+		infoHash, infoHash, provider, provider, limit, offset)
+}
+
+// GetPlaybackSession returns session with its events, or nil
+func (d *SqliteDatabase) GetPlaybackSession(id int64) *PlaybackSession {
+	sessions := d.queryPlaybackSessions(`SELECT `+playbackSessionColumns+` FROM playback_sessions WHERE id = ?`, id)
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	s := sessions[0]
+	s.Events = []*PlaybackEvent{}
+	rows, err := d.Query(`SELECT created, type, piece, duration, value FROM playback_events WHERE sessionId = ? ORDER BY rowid`, id)
+	if err != nil {
+		log.Debugf("Could not get playback events: %s", err)
+		return s
+	}
+	defer rows.Close()
+
+	var created int64
+	for rows.Next() {
+		e := &PlaybackEvent{}
+		if err := rows.Scan(&created, &e.Type, &e.Piece, &e.Duration, &e.Value); err != nil {
+			continue
+		}
+		e.Created = time.Unix(created, 0)
+		s.Events = append(s.Events, e)
+	}
+
+	return s
+}
+
+func (d *SqliteDatabase) queryPlaybackSessions(query string, args ...interface{}) (sessions []*PlaybackSession) {
+	rows, err := d.Query(query, args...)
+	if err != nil {
+		log.Debugf("Could not get playback sessions: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	var started, ended int64
+	for rows.Next() {
+		s := &PlaybackSession{}
+		if err := rows.Scan(&s.ID, &s.InfoHash, &s.Name, &s.File, &s.Size, &s.Provider, &s.ContentType, &s.TMDBId, &s.ShowID, &s.Season, &s.Episode,
+			&started, &ended, &s.BufferTime, &s.FirstByteTime, &s.Stalls, &s.StallTime, &s.Seeks, &s.EndReason, &s.Error, &s.WatchedPercent); err != nil {
+			continue
+		}
+		s.Started = time.Unix(started, 0)
+		if ended > 0 {
+			s.Ended = time.Unix(ended, 0)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return
+}
+
+func (d *SqliteDatabase) cleanPlaybackSessions() {
+	before := time.Now().Add(-playbackRetention).Unix()
+	d.Exec(`DELETE FROM playback_events WHERE sessionId IN (SELECT id FROM playback_sessions WHERE started < ?)`, before)
+	d.Exec(`DELETE FROM playback_sessions WHERE started < ?`, before)
+}
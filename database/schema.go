@@ -1,14 +1,25 @@
 package database
 
+import (
+	"fmt"
+	"strings"
+)
+
 var schemaChanges = []schemaChange{
 	schemaV1,
 	schemaV2,
+	schemaV3,
+	schemaV4,
+	schemaV5,
+	schemaV6,
+	schemaV7,
 }
 
 func schemaV1(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
 	version := 1
 
 	if *previousVersion > version {
+		success = true
 		return
 	}
 
@@ -95,6 +106,7 @@ func schemaV2(previousVersion *int, db *SqliteDatabase) (success bool, err error
 	version := 2
 
 	if *previousVersion > version {
+		success = true
 		return
 	}
 
@@ -121,3 +133,187 @@ CREATE INDEX IF NOT EXISTS torrent_history_idx2 ON torrent_history (infohash);
 
 	return
 }
+
+func schemaV3(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
+	version := 3
+
+	if *previousVersion > version {
+		success = true
+		return
+	}
+
+	sql := `
+
+-- Table stores Trakt changes, that could not be sent and wait for replay
+CREATE TABLE IF NOT EXISTS trakt_outbox (
+  kind TEXT NOT NULL DEFAULT "",
+  endpoint TEXT NOT NULL DEFAULT "",
+  payload TEXT NOT NULL DEFAULT "",
+  dedup TEXT NOT NULL DEFAULT "",
+  state INT NOT NULL DEFAULT 0,
+  attempts INT NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT "",
+  created INT NOT NULL DEFAULT 0,
+  updated INT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS trakt_outbox_idx1 ON trakt_outbox (state, created);
+CREATE INDEX IF NOT EXISTS trakt_outbox_idx2 ON trakt_outbox (dedup, state);
+
+`
+
+	// Just run an a bunch of statements
+	// If everything is fine - return success so we won't get in there again
+	if _, err = db.Exec(sql); err == nil {
+		*previousVersion = version
+		success = true
+	}
+
+	return
+}
+
+func schemaV4(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
+	version := 4
+
+	if *previousVersion > version {
+		success = true
+		return
+	}
+
+	sql := `
+
+-- Table stores local watch history, independent of Kodi library and Trakt
+CREATE TABLE IF NOT EXISTS watched_history (
+  mediaType INT NOT NULL DEFAULT 0,
+  tmdb INT NOT NULL DEFAULT 0,
+  season INT NOT NULL DEFAULT 0,
+  episode INT NOT NULL DEFAULT 0,
+  plays INT NOT NULL DEFAULT 0,
+  watched INT NOT NULL DEFAULT 0,
+  lastWatched INT NOT NULL DEFAULT 0,
+  position REAL NOT NULL DEFAULT 0,
+  total REAL NOT NULL DEFAULT 0,
+  updated INT NOT NULL DEFAULT 0,
+  UNIQUE(mediaType, tmdb, season, episode)
+);
+CREATE INDEX IF NOT EXISTS watched_history_idx1 ON watched_history (mediaType, updated DESC);
+
+`
+
+	// Just run an a bunch of statements
+	// If everything is fine - return success so we won't get in there again
+	if _, err = db.Exec(sql); err == nil {
+		*previousVersion = version
+		success = true
+	}
+
+	return
+}
+
+func schemaV5(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
+	version := 5
+
+	if *previousVersion >= version {
+		success = true
+		return
+	}
+
+	// Torrent category and seeding policy, resolved from the category
+	if err = addColumns(db, "tinfo",
+		`category TEXT NOT NULL DEFAULT ""`,
+		`policy TEXT NOT NULL DEFAULT ""`,
+	); err == nil {
+		*previousVersion = version
+		success = true
+	}
+
+	return
+}
+
+func schemaV6(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
+	version := 6
+
+	if *previousVersion >= version {
+		success = true
+		return
+	}
+
+	// Save path of torrents, imported with existing data outside of download path
+	if err = addColumns(db, "tinfo", `savePath TEXT NOT NULL DEFAULT ""`); err == nil {
+		*previousVersion = version
+		success = true
+	}
+
+	return
+}
+
+func schemaV7(previousVersion *int, db *SqliteDatabase) (success bool, err error) {
+	version := 7
+
+	if *previousVersion >= version {
+		success = true
+		return
+	}
+
+	sql := `
+
+-- Table stores playback sessions with buffering and streaming stats
+CREATE TABLE IF NOT EXISTS playback_sessions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  infohash TEXT NOT NULL DEFAULT "",
+  name TEXT NOT NULL DEFAULT "",
+  file TEXT NOT NULL DEFAULT "",
+  size INT NOT NULL DEFAULT 0,
+  provider TEXT NOT NULL DEFAULT "",
+  contentType TEXT NOT NULL DEFAULT "",
+  tmdb INT NOT NULL DEFAULT 0,
+  showId INT NOT NULL DEFAULT 0,
+  season INT NOT NULL DEFAULT 0,
+  episode INT NOT NULL DEFAULT 0,
+  started INT NOT NULL DEFAULT 0,
+  ended INT NOT NULL DEFAULT 0,
+  bufferTime REAL NOT NULL DEFAULT 0,
+  firstByteTime REAL NOT NULL DEFAULT 0,
+  stalls INT NOT NULL DEFAULT 0,
+  stallTime REAL NOT NULL DEFAULT 0,
+  seeks INT NOT NULL DEFAULT 0,
+  endReason TEXT NOT NULL DEFAULT "",
+  error TEXT NOT NULL DEFAULT "",
+  watched INT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS playback_sessions_idx1 ON playback_sessions (started DESC);
+CREATE INDEX IF NOT EXISTS playback_sessions_idx2 ON playback_sessions (infohash);
+
+-- Table stores stalls, seeks and peers samples of playback sessions
+CREATE TABLE IF NOT EXISTS playback_events (
+  sessionId INT NOT NULL DEFAULT 0,
+  created INT NOT NULL DEFAULT 0,
+  type TEXT NOT NULL DEFAULT "",
+  piece INT NOT NULL DEFAULT 0,
+  duration REAL NOT NULL DEFAULT 0,
+  value REAL NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS playback_events_idx1 ON playback_events (sessionId);
+
+`
+
+	// Just run an a bunch of statements
+	// If everything is fine - return success so we won't get in there again
+	if _, err = db.Exec(sql); err == nil {
+		*previousVersion = version
+		success = true
+	}
+
+	return
+}
+
+// addColumns adds columns to a table, one statement per column, as SQLite
+// can't add several at once. Existing columns are skipped, so the change
+// can run again after it failed half way.
+func addColumns(db *SqliteDatabase, table string, columns ...string) error {
+	for _, column := range columns {
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, column)); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
+	}
+	return nil
+}
@@ -225,7 +225,7 @@ func (d *SqliteDatabase) GetBTItem(infoHash string) *BTItem {
 	fileStr := ""
 	infoStr := ""
 
-	d.QueryRow(`SELECT rowid, state, mediaID, mediaType, files, infos FROM tinfo WHERE infohash = ?`, infoHash).Scan(&rowid, &item.State, &item.ID, &item.Type, &fileStr, &infoStr)
+	d.QueryRow(`SELECT rowid, state, mediaID, mediaType, files, infos, category, policy, savePath FROM tinfo WHERE infohash = ?`, infoHash).Scan(&rowid, &item.State, &item.ID, &item.Type, &fileStr, &infoStr, &item.Category, &item.Policy, &item.SavePath)
 	if rowid == 0 {
 		return nil
 	}
@@ -275,7 +275,9 @@ func (d *SqliteDatabase) UpdateBTItem(infoHash string, mediaID int, mediaType st
 	}
 	infoStr += query
 
-	_, err := d.Exec(`INSERT OR REPLACE INTO tinfo (infohash, state, mediaID, mediaType, files, infos) VALUES (?, ?, ?, ?, ?, ?)`, infoHash, StatusActive, mediaID, mediaType, fileStr, infoStr)
+	// Row is updated in place to keep torrent's category and policy
+	d.ensureBTItem(infoHash)
+	_, err := d.Exec(`UPDATE tinfo SET state = ?, mediaID = ?, mediaType = ?, files = ?, infos = ? WHERE infohash = ?`, StatusActive, mediaID, mediaType, fileStr, infoStr, infoHash)
 	if err != nil {
 		log.Debugf("UpdateBTItem failed: %s", err)
 	}
@@ -302,6 +304,30 @@ func (d *SqliteDatabase) UpdateBTItemFiles(infoHash string, files []string) erro
 	return err
 }
 
+// UpdateBTItemCategory stores torrent's category and its seeding policy
+func (d *SqliteDatabase) UpdateBTItemCategory(infoHash string, category string, policy string) error {
+	d.ensureBTItem(infoHash)
+	_, err := d.Exec(`UPDATE tinfo SET category = ?, policy = ? WHERE infohash = ?`, category, policy, infoHash)
+	if err != nil {
+		log.Debugf("UpdateBTItemCategory failed: %s", err)
+	}
+	return err
+}
+
+// UpdateBTItemSavePath stores save path of a torrent with data outside of download path
+func (d *SqliteDatabase) UpdateBTItemSavePath(infoHash string, savePath string) error {
+	d.ensureBTItem(infoHash)
+	_, err := d.Exec(`UPDATE tinfo SET savePath = ? WHERE infohash = ?`, savePath, infoHash)
+	if err != nil {
+		log.Debugf("UpdateBTItemSavePath failed: %s", err)
+	}
+	return err
+}
+
+func (d *SqliteDatabase) ensureBTItem(infoHash string) {
+	d.Exec(`INSERT OR IGNORE INTO tinfo (infohash, state) VALUES (?, ?)`, infoHash, StatusActive)
+}
+
 // DeleteBTItem ...
 func (d *SqliteDatabase) DeleteBTItem(infoHash string) error {
 	_, err := d.Exec(`DELETE FROM tinfo WHERE infohash = ?`, infoHash)
@@ -331,3 +357,14 @@ func (d *SqliteDatabase) AddTorrentHistory(infoHash, name string, b []byte) {
 
 	d.Exec(`DELETE FROM torrent_history WHERE rowid NOT IN (SELECT rowid FROM torrent_history ORDER BY dt DESC LIMIT ?)`, config.Get().TorrentHistorySize)
 }
+
+// FileSizes returns sizes of database files by their names
+func FileSizes() map[string]int64 {
+	sizes := map[string]int64{}
+	for _, fileName := range []string{sqliteFileName, boltFileName, cacheFileName} {
+		if fi, err := os.Stat(filepath.Join(config.Get().Info.Profile, fileName)); err == nil {
+			sizes[fileName] = fi.Size()
+		}
+	}
+	return sizes
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"sync"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/op/go-logging"
@@ -36,6 +37,87 @@ type DBWriter struct {
 	database *BoltDatabase
 }
 
+// OutboxItem is a Trakt change, waiting to be sent
+type OutboxItem struct {
+	ID       int64     `json:"id"`
+	Kind     string    `json:"kind"`
+	EndPoint string    `json:"endpoint"`
+	Payload  string    `json:"payload"`
+	Dedup    string    `json:"dedup"`
+	State    int       `json:"state"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+// WatchedItem is a locally stored watch state of a movie or an episode,
+// episodes are stored by show's TMDB id
+type WatchedItem struct {
+	MediaType   int       `json:"media_type"`
+	TMDB        int       `json:"tmdb"`
+	Season      int       `json:"season"`
+	Episode     int       `json:"episode"`
+	Plays       int       `json:"plays"`
+	State       int       `json:"state"`
+	LastWatched time.Time `json:"last_watched"`
+	Position    float64   `json:"position"`
+	Total       float64   `json:"total"`
+	Updated     time.Time `json:"updated"`
+}
+
+// Playback event types
+const (
+	PlaybackEventStall = "stall"
+	PlaybackEventSeek  = "seek"
+	PlaybackEventPeers = "peers"
+)
+
+// PlaybackSession is a stored playback of a torrent file with its streaming stats.
+// Times are in seconds.
+type PlaybackSession struct {
+	ID             int64            `json:"id"`
+	InfoHash       string           `json:"info_hash"`
+	Name           string           `json:"name"`
+	File           string           `json:"file"`
+	Size           int64            `json:"size"`
+	Provider       string           `json:"provider"`
+	ContentType    string           `json:"content_type"`
+	TMDBId         int              `json:"tmdb_id"`
+	ShowID         int              `json:"show_id"`
+	Season         int              `json:"season"`
+	Episode        int              `json:"episode"`
+	Started        time.Time        `json:"started"`
+	Ended          time.Time        `json:"ended"`
+	BufferTime     float64          `json:"buffer_time"`
+	FirstByteTime  float64          `json:"first_byte_time"`
+	Stalls         int              `json:"stalls"`
+	StallTime      float64          `json:"stall_time"`
+	Seeks          int              `json:"seeks"`
+	EndReason      string           `json:"end_reason"`
+	Error          string           `json:"error"`
+	WatchedPercent int              `json:"watched_percent"`
+	Events         []*PlaybackEvent `json:"events,omitempty"`
+}
+
+// PlaybackEvent is a stall, seek or peers sample of a playback session.
+// Piece is set for stalls, value is a position for seeks and peers count for samples.
+type PlaybackEvent struct {
+	Created  time.Time `json:"created"`
+	Type     string    `json:"type" enum:"stall,seek,peers"`
+	Piece    int       `json:"piece"`
+	Duration float64   `json:"duration"`
+	Value    float64   `json:"value"`
+}
+
+// Progress returns playback progress in percents
+func (w *WatchedItem) Progress() float64 {
+	if w.Total <= 0 {
+		return 0
+	}
+	return w.Position / w.Total * 100
+}
+
 // BTItem ...
 type BTItem struct {
 	ID      int      `json:"id"`
@@ -46,6 +128,10 @@ type BTItem struct {
 	Season  int      `json:"season"`
 	Episode int      `json:"episode"`
 	Query   string   `json:"query"`
+
+	Category string `json:"category"`
+	Policy   string `json:"policy"`
+	SavePath string `json:"save_path"`
 }
 
 var (
@@ -72,6 +158,22 @@ const (
 	StatusActive
 )
 
+const (
+	// OutboxPending ...
+	OutboxPending = iota
+	// OutboxFailed ...
+	OutboxFailed
+)
+
+const (
+	// WatchedUnset means that only resume point is stored for the item
+	WatchedUnset = iota
+	// WatchedYes ...
+	WatchedYes
+	// WatchedNo means that item is explicitly marked as unwatched
+	WatchedNo
+)
+
 const (
 	historyMaxSize = 50
 )
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"time"
+)
+
+// Local watch history handlers
+
+// AddWatchedPlay marks item as watched, increments plays counter
+// and clears stored resume point
+func (d *SqliteDatabase) AddWatchedPlay(mediaType, tmdbID, season, episode int, watchedAt time.Time) error {
+	d.ensureWatchedItem(mediaType, tmdbID, season, episode)
+	_, err := d.Exec(`UPDATE watched_history SET plays = plays + 1, watched = ?, lastWatched = ?, position = 0, total = 0, updated = ? WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`,
+		WatchedYes, watchedAt.Unix(), time.Now().Unix(), mediaType, tmdbID, season, episode)
+	if err != nil {
+		log.Warningf("Could not save watched state: %s", err)
+	}
+	return err
+}
+
+// SetWatchedProgress stores resume point of an item
+func (d *SqliteDatabase) SetWatchedProgress(mediaType, tmdbID, season, episode int, position, total float64) error {
+	d.ensureWatchedItem(mediaType, tmdbID, season, episode)
+	_, err := d.Exec(`UPDATE watched_history SET position = ?, total = ?, updated = ? WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`,
+		position, total, time.Now().Unix(), mediaType, tmdbID, season, episode)
+	if err != nil {
+		log.Warningf("Could not save playback progress: %s", err)
+	}
+	return err
+}
+
+// SetWatchedState sets watched state without touching plays counter,
+// unwatched state is kept to override Kodi and Trakt states
+func (d *SqliteDatabase) SetWatchedState(mediaType, tmdbID, season, episode int, state int) error {
+	d.ensureWatchedItem(mediaType, tmdbID, season, episode)
+	_, err := d.Exec(`UPDATE watched_history SET watched = ?, position = 0, total = 0, updated = ? WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`,
+		state, time.Now().Unix(), mediaType, tmdbID, season, episode)
+	return err
+}
+
+// ImportWatchedItem stores item from an external history,
+// keeping the bigger plays counter and the latest watch date
+func (d *SqliteDatabase) ImportWatchedItem(item *WatchedItem) error {
+	d.ensureWatchedItem(item.MediaType, item.TMDB, item.Season, item.Episode)
+	_, err := d.Exec(`UPDATE watched_history SET plays = MAX(plays, ?), watched = ?, lastWatched = MAX(lastWatched, ?), updated = ? WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`,
+		item.Plays, WatchedYes, item.LastWatched.Unix(), time.Now().Unix(), item.MediaType, item.TMDB, item.Season, item.Episode)
+	return err
+}
+
+func (d *SqliteDatabase) ensureWatchedItem(mediaType, tmdbID, season, episode int) {
+	d.Exec(`INSERT OR IGNORE INTO watched_history (mediaType, tmdb, season, episode) VALUES (?, ?, ?, ?)`, mediaType, tmdbID, season, episode)
+}
+
+// GetWatchedItem returns stored state of a single item, or nil
+func (d *SqliteDatabase) GetWatchedItem(mediaType, tmdbID, season, episode int) *WatchedItem {
+	items := d.queryWatchedItems(`SELECT mediaType, tmdb, season, episode, plays, watched, lastWatched, position, total, updated FROM watched_history WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`, mediaType, tmdbID, season, episode)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[0]
+}
+
+// GetWatchedItems returns all stored items
+func (d *SqliteDatabase) GetWatchedItems() []*WatchedItem {
+	return d.queryWatchedItems(`SELECT mediaType, tmdb, season, episode, plays, watched, lastWatched, position, total, updated FROM watched_history ORDER BY mediaType, tmdb, season, episode`)
+}
+
+// GetInProgressItems returns items of specified type with a stored resume point,
+// recently played first
+func (d *SqliteDatabase) GetInProgressItems(mediaType int) []*WatchedItem {
+	return d.queryWatchedItems(`SELECT mediaType, tmdb, season, episode, plays, watched, lastWatched, position, total, updated FROM watched_history WHERE mediaType = ? AND position > 0 AND total > 0 ORDER BY updated DESC`, mediaType)
+}
+
+// DeleteWatchedItem ...
+func (d *SqliteDatabase) DeleteWatchedItem(mediaType, tmdbID, season, episode int) error {
+	_, err := d.Exec(`DELETE FROM watched_history WHERE mediaType = ? AND tmdb = ? AND season = ? AND episode = ?`, mediaType, tmdbID, season, episode)
+	return err
+}
+
+func (d *SqliteDatabase) queryWatchedItems(query string, args ...interface{}) (items []*WatchedItem) {
+	rows, err := d.Query(query, args...)
+	if err != nil {
+		log.Debugf("Could not get watch history: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	var lastWatched, updated int64
+	for rows.Next() {
+		item := &WatchedItem{}
+		if err := rows.Scan(&item.MediaType, &item.TMDB, &item.Season, &item.Episode, &item.Plays, &item.State, &lastWatched, &item.Position, &item.Total, &updated); err != nil {
+			continue
+		}
+		if lastWatched > 0 {
+			item.LastWatched = time.Unix(lastWatched, 0)
+		}
+		item.Updated = time.Unix(updated, 0)
+		items = append(items, item)
+	}
+
+	return
+}
@@ -8,7 +8,7 @@ import (
 	"time"
 
 	"github.com/bcrusher29/solaris/cache"
-	"github.com/bcrusher29/solaris/util"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/xbmc"
 	"github.com/jmcvetta/napping"
 	logging "github.com/op/go-logging"
@@ -28,14 +28,19 @@ const (
 var log = logging.MustGetLogger("fanart")
 
 var (
-	retriesLeft             = 3
 	burstRate               = 50
 	burstTime               = 10 * time.Second
 	simultaneousConnections = 25
 	cacheExpiration         = 14 * 24 * time.Hour
 )
 
-var rl = util.NewRateLimiter(burstRate, burstTime, simultaneousConnections)
+var client = httpclient.New(httpclient.Config{
+	Name:         "fanart",
+	BaseURL:      APIURL,
+	RateLimit:    burstRate,
+	RateInterval: burstTime,
+	Parallel:     simultaneousConnections,
+})
 
 // Movie ...
 type Movie struct {
@@ -96,35 +101,19 @@ type Disk struct {
 }
 
 // Get ...
-func Get(endPoint string, params url.Values) (resp *napping.Response, err error) {
+func Get(endPoint string, params url.Values) (resp *httpclient.Response, err error) {
 	header := http.Header{
 		"Content-type": []string{"application/json"},
 		"api-key":      []string{ClientID},
 		"api-version":  []string{APIVersion},
 	}
 
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s/%s", APIURL, APIVersion, endPoint),
+	return client.Do(httpclient.Background(), &httpclient.Request{
 		Method: "GET",
-		Params: &params,
-		Header: &header,
-	}
-
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-		if err != nil {
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting %s, cooling down...", endPoint)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			resp, err = Get(endPoint, params)
-		}
-
-		return nil
+		URL:    fmt.Sprintf("%s/%s", APIVersion, endPoint),
+		Params: params,
+		Header: header,
 	})
-	return
 }
 
 // GetMovie ...
@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker is a simple per-host circuit breaker. After threshold consecutive
+// failures it stops letting requests through for resetTimeout, then lets
+// a single probe request decide whether to close again.
+type breaker struct {
+	mu           sync.Mutex
+	state        int
+	failures     int
+	threshold    int
+	resetTimeout time.Duration
+	openedAt     time.Time
+	probing      bool
+}
+
+func newBreaker(threshold int, resetTimeout time.Duration) *breaker {
+	return &breaker{
+		threshold:    threshold,
+		resetTimeout: resetTimeout,
+	}
+}
+
+// Allow reports whether a request may be sent now, and whether it is
+// the probe, that must end with Success, Failure or release
+func (b *breaker) Allow() (allowed bool, probe bool) {
+	if b.threshold <= 0 {
+		return true, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.resetTimeout {
+			return false, false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true, true
+	case breakerHalfOpen:
+		if b.probing {
+			return false, false
+		}
+		b.probing = true
+		return true, true
+	}
+
+	return true, false
+}
+
+// Success records a request that reached the host and got a sane answer
+func (b *breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a failed request and opens the breaker if needed
+func (b *breaker) Failure() (opened bool) {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		opened = b.state != breakerOpen
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+	return
+}
+
+// release gives up the probe, that ended without an answer from the host,
+// so the next request can probe instead
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.probing = false
+	}
+}
+
+// IsOpen reports whether the breaker currently rejects requests
+func (b *breaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state == breakerOpen && time.Since(b.openedAt) < b.resetTimeout
+}
@@ -0,0 +1,443 @@
+// Package httpclient is a shared HTTP layer for metadata providers.
+// Every Client applies a per-host rate limit and circuit breaker, retries
+// failed requests with exponential backoff and jitter, respects Retry-After
+// headers and returns typed errors for bad responses.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/op/go-logging"
+
+	"github.com/bcrusher29/solaris/metrics"
+	"github.com/bcrusher29/solaris/util"
+)
+
+var log = logging.MustGetLogger("httpclient")
+
+var (
+	requestsMetric    = metrics.NewCounter("elementum_http_requests_total", "Requests of metadata clients by response status", "client", "status")
+	rateLimitedMetric = metrics.NewCounter("elementum_http_rate_limited_total", "Responses, asking metadata clients to cool down", "client")
+	cooldownMetric    = metrics.NewCounter("elementum_http_cooldown_seconds_total", "Time, metadata clients spent in rate limit cooldowns", "client")
+	circuitMetric     = metrics.NewCounter("elementum_http_circuit_open_total", "Requests, rejected by open circuit breaker", "client")
+)
+
+var defaultHTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// shutdownCtx is cancelled on shutdown, so requests, waiting for a rate limit
+// or a retry, don't hold the process
+var shutdownCtx, shutdownCancel = context.WithCancel(context.Background())
+
+// Background returns the context for requests, that don't belong to
+// an incoming request, it is cancelled by Shutdown
+func Background() context.Context {
+	return shutdownCtx
+}
+
+// Shutdown cancels all requests, made with Background context
+func Shutdown() {
+	shutdownCancel()
+}
+
+// Config describes Client behaviour, zero values are replaced with defaults
+type Config struct {
+	// Name is used in logs and errors
+	Name string
+	// BaseURL is prepended to request URLs that are not absolute
+	BaseURL string
+	// Header is added to every request
+	Header http.Header
+
+	// RateLimit requests are allowed per RateInterval for each host,
+	// with not more than Parallel requests running at once
+	RateLimit    int
+	RateInterval time.Duration
+	Parallel     int
+
+	// Retries is a number of additional attempts for failed requests
+	Retries int
+	// RetryAll allows retrying non-idempotent requests on network errors
+	// and server errors, by default only 429 and 503 responses are retried
+	// for these, as the server did not process them
+	RetryAll      bool
+	MinBackoff    time.Duration
+	MaxBackoff    time.Duration
+	MaxRetryAfter time.Duration
+
+	// BreakerThreshold consecutive failures open the circuit for a host
+	// for BreakerTimeout. Negative threshold disables the breaker.
+	BreakerThreshold int
+	BreakerTimeout   time.Duration
+
+	// HTTPClient returns the client to send requests with,
+	// it is called for every request, so it can follow settings changes
+	HTTPClient func() *http.Client
+}
+
+// Client ...
+type Client struct {
+	config Config
+
+	mu    sync.Mutex
+	hosts map[string]*host
+}
+
+type host struct {
+	limiter *util.RateLimiter
+	breaker *breaker
+}
+
+// Request ...
+type Request struct {
+	Method string
+	URL    string
+	Params url.Values
+	Header http.Header
+	Body   []byte
+	// Result, if set, receives JSON decoded body of a successful response
+	Result interface{}
+}
+
+// New creates a Client for the config
+func New(config Config) *Client {
+	if config.Name == "" {
+		config.Name = "http"
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = 10
+	}
+	if config.RateInterval <= 0 {
+		config.RateInterval = time.Second
+	}
+	if config.Parallel <= 0 {
+		config.Parallel = 10
+	}
+	if config.Retries < 0 {
+		config.Retries = 0
+	} else if config.Retries == 0 {
+		config.Retries = 3
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = 500 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = time.Minute
+	}
+	if config.BreakerThreshold == 0 {
+		config.BreakerThreshold = 5
+	}
+	if config.BreakerTimeout <= 0 {
+		config.BreakerTimeout = 30 * time.Second
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = func() *http.Client {
+			return defaultHTTPClient
+		}
+	}
+
+	return &Client{
+		config: config,
+		hosts:  map[string]*host{},
+	}
+}
+
+// Get sends GET request and decodes JSON response into result
+func (c *Client) Get(ctx context.Context, u string, params url.Values, result interface{}) (*Response, error) {
+	return c.Do(ctx, &Request{
+		Method: http.MethodGet,
+		URL:    u,
+		Params: params,
+		Result: result,
+	})
+}
+
+// Do sends the request, retrying it if needed. Response is returned whenever
+// the server has answered, even if error is not nil, so callers can inspect it.
+func (c *Client) Do(ctx context.Context, r *Request) (*Response, error) {
+	if ctx == nil {
+		ctx = shutdownCtx
+	}
+
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	u := r.URL
+	if c.config.BaseURL != "" && !strings.Contains(u, "://") {
+		u = strings.TrimRight(c.config.BaseURL, "/") + "/" + strings.TrimLeft(u, "/")
+	}
+	if len(r.Params) > 0 {
+		if strings.Contains(u, "?") {
+			u += "&" + r.Params.Encode()
+		} else {
+			u += "?" + r.Params.Encode()
+		}
+	}
+
+	var body io.Reader
+	if r.Body != nil {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, &RequestError{Client: c.config.Name, Method: method, URL: u, Err: err}
+	}
+	req = req.WithContext(ctx)
+	for k, v := range c.config.Header {
+		req.Header[k] = v
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+
+	resp, err := c.execute(req, func(req *http.Request) (*http.Response, error) {
+		return c.config.HTTPClient().Do(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &RequestError{Client: c.config.Name, Method: method, URL: u, Err: err}
+	}
+
+	ret := &Response{resp: resp, body: b}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return ret, &StatusError{Client: c.config.Name, Method: method, URL: u, StatusCode: resp.StatusCode}
+	}
+
+	if r.Result != nil && len(b) > 0 {
+		if err := ret.Unmarshal(r.Result); err != nil {
+			return ret, &RequestError{Client: c.config.Name, Method: method, URL: u, Err: err}
+		}
+	}
+
+	return ret, nil
+}
+
+// Transport returns RoundTripper that sends requests through this client,
+// to be used with libraries that build requests themselves.
+// Bad statuses are not converted to errors in this mode.
+func (c *Client) Transport() http.RoundTripper {
+	return &transport{c}
+}
+
+type transport struct {
+	c *Client
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.c.config.HTTPClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return t.c.execute(req, base.RoundTrip)
+}
+
+// IsAvailable reports whether requests to the host are currently allowed
+// by the circuit breaker
+func (c *Client) IsAvailable(hostname string) bool {
+	return !c.getHost(hostname).breaker.IsOpen()
+}
+
+func (c *Client) getHost(hostname string) *host {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if h, ok := c.hosts[hostname]; ok {
+		return h
+	}
+
+	h := &host{
+		limiter: util.NewRateLimiter(c.config.RateLimit, c.config.RateInterval, c.config.Parallel),
+		breaker: newBreaker(c.config.BreakerThreshold, c.config.BreakerTimeout),
+	}
+	c.hosts[hostname] = h
+	return h
+}
+
+// execute runs send for the request until it succeeds, fails with
+// a non-retryable result or all retries are used
+func (c *Client) execute(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	h := c.getHost(req.URL.Host)
+	retryAll := c.config.RetryAll || isIdempotent(req.Method)
+
+	newError := func(err error) error {
+		return &RequestError{Client: c.config.Name, Method: req.Method, URL: req.URL.String(), Err: err}
+	}
+
+	// Probe, that ends without Success or Failure (cancelled context,
+	// broken body), is released on return, or breaker stays half-open forever
+	probe := false
+	defer func() {
+		if probe {
+			h.breaker.release()
+		}
+	}()
+	success := func() {
+		probe = false
+		h.breaker.Success()
+	}
+	failure := func() {
+		probe = false
+		if h.breaker.Failure() {
+			log.Warningf("Too many failures for %s requests to %s, pausing them for %s", c.config.Name, req.URL.Host, c.config.BreakerTimeout)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		allowed, isProbe := h.breaker.Allow()
+		if !allowed {
+			circuitMetric.Inc(c.config.Name)
+			return nil, newError(ErrCircuitOpen)
+		}
+		probe = isProbe
+
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, newError(io.ErrUnexpectedEOF)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, newError(err)
+			}
+			attemptReq = req.WithContext(ctx)
+			attemptReq.Body = body
+		}
+
+		if err := h.limiter.EnterContext(ctx); err != nil {
+			return nil, newError(err)
+		}
+		if err := h.limiter.WaitContext(ctx); err != nil {
+			h.limiter.Leave()
+			return nil, newError(err)
+		}
+		resp, err := send(attemptReq)
+		h.limiter.Leave()
+
+		if err != nil {
+			requestsMetric.Inc(c.config.Name, "error")
+		} else {
+			requestsMetric.Inc(c.config.Name, strconv.Itoa(resp.StatusCode))
+		}
+
+		var wait time.Duration
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, newError(ctx.Err())
+			}
+			failure()
+			if !retryAll || attempt >= c.config.Retries {
+				return nil, newError(err)
+			}
+
+			wait = c.backoff(attempt)
+			log.Debugf("Failed %s request to %s: %s. Retrying in %s", c.config.Name, req.URL, err, wait)
+		} else if isRetryable(resp.StatusCode, retryAll) {
+			if resp.StatusCode >= 500 {
+				failure()
+			} else {
+				success()
+			}
+			if attempt >= c.config.Retries {
+				return resp, nil
+			}
+
+			wait = retryAfter(resp.Header)
+			if wait > c.config.MaxRetryAfter {
+				log.Warningf("Server asked to wait %s for %s request to %s, giving up", wait, c.config.Name, req.URL)
+				return resp, nil
+			} else if wait <= 0 {
+				wait = c.backoff(attempt)
+			}
+
+			if resp.StatusCode == http.StatusTooManyRequests {
+				rateLimitedMetric.Inc(c.config.Name)
+				cooldownMetric.Add(wait.Seconds(), c.config.Name)
+				log.Warningf("Rate limit exceeded getting %s, cooling down for %s...", req.URL, wait)
+			} else {
+				log.Debugf("Bad status %d for %s request to %s. Retrying in %s", resp.StatusCode, c.config.Name, req.URL, wait)
+			}
+
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			success()
+			return resp, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, newError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns exponential delay for the attempt with a random jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.config.MinBackoff << uint(attempt)
+	if d <= 0 || d > c.config.MaxBackoff {
+		d = c.config.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses Retry-After header, both seconds and HTTP-date forms
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds == 0 {
+			return 300 * time.Millisecond
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryable(code int, retryAll bool) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return retryAll
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(retries int, threshold int) *Client {
+	return New(Config{
+		Name:             "test",
+		Retries:          retries,
+		MinBackoff:       time.Millisecond,
+		MaxBackoff:       2 * time.Millisecond,
+		BreakerThreshold: threshold,
+		BreakerTimeout:   50 * time.Millisecond,
+	})
+}
+
+// statusServer answers with statuses in order, repeating the last one
+func statusServer(statuses ...int) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&hits, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		if statuses[i] == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(statuses[i])
+		w.Write([]byte(`{"name":"ok"}`))
+	}))
+	return srv, &hits
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		retries  int
+		statuses []int
+		hits     int32
+		err      error
+	}{
+		{"success", http.MethodGet, 3, []int{200}, 1, nil},
+		{"unavailable then success", http.MethodGet, 3, []int{503, 503, 200}, 3, nil},
+		{"rate limited then success", http.MethodGet, 3, []int{429, 200}, 2, nil},
+		{"server error retried for GET", http.MethodGet, 2, []int{500}, 3, ErrServer},
+		{"server error not retried for POST", http.MethodPost, 3, []int{500}, 1, ErrServer},
+		{"rate limit retried for POST", http.MethodPost, 3, []int{429, 200}, 2, nil},
+		{"not found is final", http.MethodGet, 3, []int{404}, 1, ErrNotFound},
+		{"unauthorized is final", http.MethodGet, 3, []int{401}, 1, ErrUnauthorized},
+		{"rate limited after all retries", http.MethodGet, 1, []int{429}, 2, ErrRateLimited},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv, hits := statusServer(test.statuses...)
+			defer srv.Close()
+
+			var result struct {
+				Name string `json:"name"`
+			}
+			_, err := newTestClient(test.retries, -1).Do(context.Background(), &Request{Method: test.method, URL: srv.URL, Result: &result})
+
+			if test.err == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+			if got := atomic.LoadInt32(hits); got != test.hits {
+				t.Errorf("expected %d requests, got %d", test.hits, got)
+			}
+			if test.err == nil && result.Name != "ok" {
+				t.Errorf("result is not decoded: %+v", result)
+			}
+		})
+	}
+}
+
+func TestClientBaseURL(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.RequestURI()
+	}))
+	defer srv.Close()
+
+	c := New(Config{Name: "test", BaseURL: srv.URL + "/3/"})
+	if _, err := c.Get(context.Background(), "/movie/1", map[string][]string{"language": {"en"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/3/movie/1?language=en" {
+		t.Errorf("unexpected request path %s", path)
+	}
+}
+
+func TestClientBreaker(t *testing.T) {
+	srv, hits := statusServer(500, 500, 200)
+	defer srv.Close()
+
+	c := newTestClient(-1, 2)
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), srv.URL, nil, nil); !errors.Is(err, ErrServer) {
+			t.Fatalf("request %d: expected server error, got %v", i, err)
+		}
+	}
+
+	if _, err := c.Get(context.Background(), srv.URL, nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Fatalf("open circuit let a request through, %d requests sent", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.Get(context.Background(), srv.URL, nil, nil); err != nil {
+		t.Fatalf("probe request failed: %s", err)
+	}
+	if _, err := c.Get(context.Background(), srv.URL, nil, nil); err != nil {
+		t.Fatalf("circuit is not closed after successful probe: %s", err)
+	}
+}
+
+func TestClientBreakerCancelledProbe(t *testing.T) {
+	srv, hits := statusServer(500, 200)
+	defer srv.Close()
+
+	c := newTestClient(-1, 1)
+	if _, err := c.Get(context.Background(), srv.URL, nil, nil); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx, srv.URL, nil, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected cancelled probe, got %v", err)
+	}
+
+	if _, err := c.Get(context.Background(), srv.URL, nil, nil); err != nil {
+		t.Fatalf("cancelled probe blocks next requests: %s", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Fatalf("expected 2 requests sent, got %d", got)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b := newBreaker(1, 10*time.Millisecond)
+	if !b.Failure() || !b.IsOpen() {
+		t.Fatal("breaker is not opened after reaching threshold")
+	}
+	if allowed, _ := b.Allow(); allowed {
+		t.Fatal("open breaker allows requests")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if allowed, probe := b.Allow(); !allowed || !probe {
+		t.Fatal("probe is not allowed after timeout")
+	}
+	if allowed, _ := b.Allow(); allowed {
+		t.Fatal("second request is allowed while probing")
+	}
+
+	b.release()
+	if allowed, probe := b.Allow(); !allowed || !probe {
+		t.Fatal("released probe does not let next probe through")
+	}
+
+	b.Failure()
+	if !b.IsOpen() {
+		t.Fatal("failed probe does not open breaker again")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{"", 0, 0},
+		{"garbage", 0, 0},
+		{"0", 300 * time.Millisecond, 300 * time.Millisecond},
+		{"5", 5 * time.Second, 5 * time.Second},
+		{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 8 * time.Second, 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		header := http.Header{}
+		if test.value != "" {
+			header.Set("Retry-After", test.value)
+		}
+		if got := retryAfter(header); got < test.min || got > test.max {
+			t.Errorf("retryAfter(%q) = %s, expected between %s and %s", test.value, got, test.min, test.max)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNotFound is matched by errors for 404 responses
+	ErrNotFound = errors.New("Not Found")
+	// ErrUnauthorized is matched by errors for 401 and 403 responses
+	ErrUnauthorized = errors.New("Unauthorized")
+	// ErrRateLimited is matched by errors for 429 responses that are still
+	// limited after all retries are used
+	ErrRateLimited = errors.New("Rate-Limit Exceeded")
+	// ErrServer is matched by errors for 5xx responses
+	ErrServer = errors.New("Server error")
+	// ErrHTTP is matched by errors for any other non-2xx response
+	ErrHTTP = errors.New("HTTP error")
+	// ErrCircuitOpen is returned when too many requests to a host have failed
+	// and the host is given time to recover
+	ErrCircuitOpen = errors.New("Circuit open")
+)
+
+// StatusError is returned for responses with a non-2xx status code
+type StatusError struct {
+	Client     string
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s %s: bad status %d", e.Client, e.Method, e.URL, e.StatusCode)
+}
+
+// Is allows matching StatusError against the package level errors
+func (e *StatusError) Is(target error) bool {
+	return kindOf(e.StatusCode) == target
+}
+
+// RequestError is returned when a request could not be completed at all
+type RequestError struct {
+	Client string
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s: %s %s: %s", e.Client, e.Method, e.URL, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound checks whether err was caused by a 404 response
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsStatus checks whether err was caused by a response with specific status code
+func IsStatus(err error, code int) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.StatusCode == code
+}
+
+func kindOf(code int) error {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrUnauthorized
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= 500:
+		return ErrServer
+	}
+	return ErrHTTP
+}
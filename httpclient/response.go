@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+var errNoResponse = errors.New("No response")
+
+// Response keeps the received response along with the fully read body,
+// so it can be decoded any number of times. All methods are safe to call
+// on a nil Response.
+type Response struct {
+	resp *http.Response
+	body []byte
+}
+
+// Status returns response status code, or 0 if there was no response
+func (r *Response) Status() int {
+	if r == nil || r.resp == nil {
+		return 0
+	}
+	return r.resp.StatusCode
+}
+
+// Header returns response headers
+func (r *Response) Header() http.Header {
+	if r == nil || r.resp == nil {
+		return http.Header{}
+	}
+	return r.resp.Header
+}
+
+// HTTPResponse returns the underlying response, with body already consumed
+func (r *Response) HTTPResponse() *http.Response {
+	if r == nil {
+		return nil
+	}
+	return r.resp
+}
+
+// Bytes returns response body
+func (r *Response) Bytes() []byte {
+	if r == nil {
+		return nil
+	}
+	return r.body
+}
+
+// RawText returns response body as a string
+func (r *Response) RawText() string {
+	return string(r.Bytes())
+}
+
+// Unmarshal decodes JSON response body into v
+func (r *Response) Unmarshal(v interface{}) error {
+	if r == nil {
+		return errNoResponse
+	}
+	return json.Unmarshal(r.body, v)
+}
@@ -0,0 +1,288 @@
+// Package imagecache keeps remote artwork on disk and serves it locally,
+// resized to the size list items need, so menus don't download images
+// on every render and keep working offline. Least recently used files
+// are evicted, when the cache grows over configured size.
+package imagecache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// Formats, that artwork sites use
+	_ "image/gif"
+
+	"github.com/op/go-logging"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
+	"github.com/bcrusher29/solaris/util"
+)
+
+var log = logging.MustGetLogger("imagecache")
+
+const (
+	// Cache size in megabytes, when it is not configured
+	defaultCacheSize = 500
+	// Eviction frees some space more than needed, to not run on each download
+	evictRatio = 0.9
+	// Cache size is checked at most once in that interval, files used
+	// within it are never evicted
+	evictInterval = time.Minute
+	jpegQuality   = 90
+)
+
+// widths of resized variants by art kind, logos and clear art keep original size
+var widths = map[string]int{
+	"icon":         300,
+	"thumb":        300,
+	"poster":       500,
+	"tvshowposter": 500,
+	"banner":       1000,
+	"fanart":       1280,
+	"landscape":    1280,
+}
+
+// hosts are artwork sites, that images are proxied from,
+// so the proxy can't be used to request other addresses
+var hosts = map[string]bool{
+	"image.tmdb.org":       true,
+	"assets.fanart.tv":     true,
+	"artworks.thetvdb.com": true,
+}
+
+var client = httpclient.New(httpclient.Config{
+	Name:     "images",
+	Parallel: 10,
+})
+
+var (
+	// Same images are requested by several list items at once,
+	// so downloads of an image are serialized
+	locks [64]sync.Mutex
+
+	evictMu   sync.Mutex
+	evicting  bool
+	lastEvict time.Time
+)
+
+// ProxyURL returns URL of a remote image in the local cache, when the cache is enabled
+// and the image is on one of artwork sites, other URLs are returned as is
+func ProxyURL(uri string, kind string) string {
+	if !config.Get().ImageCache || !isAllowed(uri) {
+		return uri
+	}
+	return util.GetHTTPHost() + "/image?" + url.Values{"url": {uri}, "kind": {kind}}.Encode()
+}
+
+// Get returns path of a cached image, resized for an art kind,
+// the image is downloaded first, if it is not cached yet
+func Get(ctx context.Context, uri string, kind string) (string, error) {
+	if !isAllowed(uri) {
+		return "", fmt.Errorf("Unsupported image URL: %s", uri)
+	}
+
+	sum := sha1.Sum([]byte(uri))
+	original := filepath.Join(cachePath(), fmt.Sprintf("%x", sum))
+	variant := original
+	width := widths[kind]
+	if width > 0 {
+		variant = fmt.Sprintf("%s_%d", original, width)
+	}
+
+	lock := imageLock(sum[0])
+	lock.Lock()
+	defer lock.Unlock()
+
+	if touch(variant) {
+		return variant, nil
+	}
+	if !touch(original) {
+		if err := download(ctx, uri, original); err != nil {
+			return "", err
+		}
+		scheduleEviction()
+	}
+	if variant == original {
+		return original, nil
+	}
+
+	if err := writeResized(original, variant, width); err != nil {
+		log.Warningf("Could not resize %s: %s", uri, err)
+		return original, nil
+	}
+	return variant, nil
+}
+
+// imageLock returns the lock of an image and its variants by first byte of URL hash
+func imageLock(b byte) *sync.Mutex {
+	return &locks[int(b)%len(locks)]
+}
+
+func isAllowed(uri string) bool {
+	u, err := url.Parse(uri)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && hosts[strings.ToLower(u.Hostname())]
+}
+
+func cachePath() string {
+	return filepath.Join(config.Get().ProfilePath, "images")
+}
+
+// touch marks a file as recently used, it reports whether the file exists
+func touch(path string) bool {
+	now := time.Now()
+	return os.Chtimes(path, now, now) == nil
+}
+
+func download(ctx context.Context, uri string, path string) error {
+	resp, err := client.Get(ctx, uri, nil, nil)
+	if err != nil {
+		return err
+	}
+	// Error pages are not cached, only what decodes as an image
+	if _, _, err := image.DecodeConfig(bytes.NewReader(resp.Bytes())); err != nil {
+		return fmt.Errorf("Not an image: %s: %s", uri, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, resp.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writeResized stores a variant of an image, that is not wider than width,
+// images, that are narrow enough, are copied as is
+func writeResized(original string, variant string, width int) error {
+	data, err := ioutil.ReadFile(original)
+	if err != nil {
+		return err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if img.Bounds().Dx() > width {
+		var b bytes.Buffer
+		if format == "png" {
+			err = png.Encode(&b, resize(img, width))
+		} else {
+			err = jpeg.Encode(&b, resize(img, width), &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return err
+		}
+		data = b.Bytes()
+	}
+
+	tmp := variant + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, variant)
+}
+
+// scheduleEviction starts eviction in background, unless it is running
+// or it was run recently
+func scheduleEviction() {
+	evictMu.Lock()
+	defer evictMu.Unlock()
+
+	if evicting || time.Since(lastEvict) < evictInterval {
+		return
+	}
+	evicting = true
+	lastEvict = time.Now()
+
+	go func() {
+		evict()
+
+		evictMu.Lock()
+		evicting = false
+		evictMu.Unlock()
+	}()
+}
+
+// evict removes least recently used files, until the cache fits its size
+func evict() {
+	limit := int64(config.Get().ImageCacheSize)
+	if limit <= 0 {
+		limit = defaultCacheSize
+	}
+	limit *= 1024 * 1024
+
+	files, err := ioutil.ReadDir(cachePath())
+	if err != nil {
+		log.Warningf("Could not list image cache: %s", err)
+		return
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.Size()
+	}
+	if total <= limit {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	removed := 0
+	for _, f := range files {
+		if total <= int64(float64(limit)*evictRatio) {
+			break
+		}
+		if f.IsDir() || strings.HasSuffix(f.Name(), ".tmp") || time.Since(f.ModTime()) < evictInterval {
+			continue
+		}
+		if !removeUnused(filepath.Join(cachePath(), f.Name()), f.ModTime()) {
+			continue
+		}
+		total -= f.Size()
+		removed++
+	}
+
+	log.Infof("Evicted %d images from cache", removed)
+}
+
+// removeUnused removes a cached file under its image lock, unless it was
+// used after it was listed
+func removeUnused(path string, modTime time.Time) bool {
+	name := filepath.Base(path)
+	if len(name) < 2 {
+		return false
+	}
+	b, err := strconv.ParseUint(name[:2], 16, 8)
+	if err != nil {
+		return false
+	}
+
+	lock := imageLock(byte(b))
+	lock.Lock()
+	defer lock.Unlock()
+
+	if fi, err := os.Stat(path); err != nil || !fi.ModTime().Equal(modTime) {
+		return false
+	}
+	return os.Remove(path) == nil
+}
@@ -0,0 +1,63 @@
+package imagecache
+
+import (
+	"image"
+	"image/color"
+)
+
+// resize scales an image down to width, keeping aspect ratio. Each pixel
+// is an average of source pixels it covers, which keeps downscaled posters
+// sharp enough without external dependencies. Narrower images are returned as is.
+func resize(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if width <= 0 || width >= b.Dx() {
+		return src
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(b.Min.Y, b.Dy(), height, y)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(b.Min.X, b.Dx(), width, x)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+
+			// Colors are alpha-premultiplied, so alpha sum divides them back
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r * 0xff / a),
+				G: uint8(g * 0xff / a),
+				B: uint8(bl * 0xff / a),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+// span returns source range, that destination pixel i covers
+func span(min, size, scaled, i int) (int, int) {
+	from := min + i*size/scaled
+	to := min + (i+1)*size/scaled
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
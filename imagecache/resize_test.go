@@ -0,0 +1,57 @@
+package imagecache
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name   string
+		bounds image.Rectangle
+		width  int
+		size   image.Point
+	}{
+		{"narrower", image.Rect(0, 0, 1000, 1500), 500, image.Pt(500, 750)},
+		{"odd ratio", image.Rect(0, 0, 1920, 1080), 1280, image.Pt(1280, 720)},
+		{"shifted bounds", image.Rect(10, 20, 410, 220), 100, image.Pt(100, 50)},
+		{"thin strip keeps one row", image.Rect(0, 0, 1000, 1), 300, image.Pt(300, 1)},
+		{"already narrow", image.Rect(0, 0, 300, 450), 500, image.Pt(300, 450)},
+		{"same width", image.Rect(0, 0, 500, 750), 500, image.Pt(500, 750)},
+		{"no width", image.Rect(0, 0, 500, 750), 0, image.Pt(500, 750)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := image.NewNRGBA(test.bounds)
+			if size := resize(src, test.width).Bounds().Size(); size != test.size {
+				t.Errorf("expected size %v, got %v", test.size, size)
+			}
+		})
+	}
+}
+
+func TestResizeColors(t *testing.T) {
+	// Left half is opaque red, right half is transparent
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: 0xff})
+		}
+	}
+
+	dst := resize(src, 2).(*image.NRGBA)
+	if c := dst.NRGBAAt(0, 0); c != (color.NRGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("opaque pixel has color %v", c)
+	}
+	if c := dst.NRGBAAt(1, 0); c.A != 0 {
+		t.Errorf("transparent pixel has color %v", c)
+	}
+
+	// Half transparent average keeps color, not darkened by transparent pixels
+	dst = resize(src, 1).(*image.NRGBA)
+	if c := dst.NRGBAAt(0, 0); c.R != 0xff || c.G != 0 || c.A < 0x7f || c.A > 0x80 {
+		t.Errorf("mixed pixel has color %v", c)
+	}
+}
@@ -0,0 +1,160 @@
+package library
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/trakt"
+	"github.com/bcrusher29/solaris/util"
+)
+
+const (
+	// calendarRefresh is how long calendar trusts cached show and season data,
+	// TMDB cache keeps it for days, but new episodes get air dates often
+	calendarRefresh = 6 * time.Hour
+	// calendarParallel limits shows, that are requested at once
+	calendarParallel = 5
+)
+
+// CalendarEpisode is an episode of a library show, airing within calendar range
+type CalendarEpisode struct {
+	Show    *tmdb.Show
+	Season  *tmdb.Season
+	Episode *tmdb.Episode
+	Aired   time.Time
+	// AllDay is set when show's air time is unknown and only air date is reliable
+	AllDay bool
+}
+
+// Calendar returns episodes of library shows, airing between from and to,
+// sorted by air time. It is built from TMDB seasons, so it works without Trakt.
+func Calendar(from, to time.Time) []*CalendarEpisode {
+	l.mu.Shows.Lock()
+	ids := make([]int, 0, len(l.Shows))
+	for _, s := range l.Shows {
+		if s != nil && s.UIDs != nil && s.UIDs.TMDB != 0 {
+			ids = append(ids, s.UIDs.TMDB)
+		}
+	}
+	l.mu.Shows.Unlock()
+
+	language := config.Get().Language
+	results := make(chan []*CalendarEpisode, len(ids))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, calendarParallel)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results <- showCalendar(id, from, to, language)
+		}(id)
+	}
+	wg.Wait()
+	close(results)
+
+	ret := []*CalendarEpisode{}
+	for r := range results {
+		ret = append(ret, r...)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Aired.Equal(ret[j].Aired) {
+			if ret[i].Show.ID == ret[j].Show.ID {
+				return ret[i].Episode.EpisodeNumber < ret[j].Episode.EpisodeNumber
+			}
+			return ret[i].Show.Name < ret[j].Show.Name
+		}
+		return ret[i].Aired.Before(ret[j].Aired)
+	})
+
+	return ret
+}
+
+func showCalendar(showID int, from, to time.Time, language string) []*CalendarEpisode {
+	// Same as for strm files, show and its current seasons are requested again,
+	// so new episodes and air dates are not missed
+	stale := isCalendarStale(showID)
+	if stale {
+		cacheStore.Delete(fmt.Sprintf("com.tmdb.show.%d.%s", showID, tmdb.LanguageKey(language)))
+	}
+
+	show := tmdb.GetShow(showID, language)
+	if show == nil || len(show.Seasons) == 0 {
+		return nil
+	}
+
+	// Ended shows, that stopped airing before the range, have nothing to add
+	if show.NextEpisodeToAir == nil && show.LastAirDate != "" && util.AirTime(show.LastAirDate, "", show.Timezone()).Before(from) {
+		return nil
+	}
+
+	seasons := []int{show.Seasons[len(show.Seasons)-1].Season}
+	for _, e := range []*tmdb.Episode{show.LastEpisodeToAir, show.NextEpisodeToAir} {
+		if e != nil && !containsInt(seasons, e.SeasonNumber) {
+			seasons = append(seasons, e.SeasonNumber)
+		}
+	}
+
+	clock, timezone := trakt.ShowAirs(show)
+	ret := []*CalendarEpisode{}
+	for _, number := range seasons {
+		if stale {
+			cacheStore.Delete(fmt.Sprintf("com.tmdb.season.%d.%d.%s", show.ID, number, tmdb.LanguageKey(language)))
+		}
+
+		season := tmdb.GetSeason(show.ID, number, language)
+		if season == nil {
+			continue
+		}
+
+		for _, episode := range season.Episodes {
+			if episode == nil || episode.AirDate == "" {
+				continue
+			}
+
+			aired := util.AirTime(episode.AirDate, clock, timezone)
+			if aired.Before(from) || aired.After(to) {
+				continue
+			}
+
+			ret = append(ret, &CalendarEpisode{
+				Show:    show,
+				Season:  season,
+				Episode: episode,
+				Aired:   aired,
+				AllDay:  clock == "",
+			})
+		}
+	}
+
+	return ret
+}
+
+// isCalendarStale reports whether cached data of a show is older than
+// calendarRefresh, the show is marked as refreshed then
+func isCalendarStale(showID int) bool {
+	key := fmt.Sprintf("com.library.calendar.%d", showID)
+
+	var refreshed int64
+	if err := cacheStore.Get(key, &refreshed); err == nil {
+		return false
+	}
+	cacheStore.Set(key, time.Now().Unix(), calendarRefresh)
+	return true
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package library
 
 import (
 	"errors"
+
+	"github.com/bcrusher29/solaris/tmdb"
 )
 
 //
@@ -135,3 +137,14 @@ func (s *Show) GetEpisode(season, episode int) *Episode {
 
 	return nil
 }
+
+// GetMissingFromCollection returns TMDB IDs of collection movies, that are not in the library
+func GetMissingFromCollection(collection *tmdb.Collection) []int {
+	ids := []int{}
+	for _, id := range collection.MovieIDs() {
+		if _, err := GetMovieByTMDB(id); err != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -3,6 +3,7 @@ package library
 import (
 	"errors"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/cespare/xxhash"
 	"github.com/op/go-logging"
 
+	"github.com/bcrusher29/solaris/broadcast"
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/database"
@@ -32,6 +34,10 @@ const (
 	trueType  = "true"
 	falseType = "false"
 
+	libraryAdded   = "added"
+	libraryRemoved = "removed"
+	libraryUpdated = "updated"
+
 	resolveExpiration     = 7 * 24 * time.Hour
 	resolveFileExpiration = 60 * 24 * time.Hour
 )
@@ -430,9 +436,18 @@ func doUpdateLibrary() error {
 	}
 
 	log.Noticef("Library updated in %s", time.Since(begin))
+	publishLibrary(libraryUpdated, showType, 0)
 	return nil
 }
 
+func publishLibrary(action, mediaType string, tmdbID int) {
+	broadcast.Publish(broadcast.EventLibrary, &Event{
+		Action:    action,
+		MediaType: mediaType,
+		TMDBId:    tmdbID,
+	})
+}
+
 //
 // Path checks
 //
@@ -540,16 +555,23 @@ func writeMovieNFO(m *tmdb.Movie, p string) error {
 	<uniqueid type="elementum" default="false">%v</uniqueid>
 	<uniqueid type="tmdb" default="true">%v</uniqueid>
 	<uniqueid type="imdb" default="false">%v</uniqueid>
-	<uniqueid type="tvdb" default="false">%v</uniqueid>
+	<uniqueid type="tvdb" default="false">%v</uniqueid>%v
 </movie>
 https://www.themoviedb.org/movie/%v
 `
+	set := ""
+	if m.Collection != nil && m.Collection.Name != "" {
+		set = fmt.Sprintf("\n\t<set>\n\t\t<name>%s</name>\n\t\t<overview>%s</overview>\n\t</set>",
+			html.EscapeString(m.Collection.Name), html.EscapeString(m.Collection.Overview))
+	}
+
 	out = fmt.Sprintf(out,
 		m.ID,
 		m.ID,
 		m.ID,
 		m.ExternalIDs.IMDBId,
 		m.ExternalIDs.TVDBID,
+		set,
 		m.ID,
 	)
 
@@ -592,7 +614,7 @@ func writeShowStrm(showID int, adding, force bool) (*tmdb.Show, error) {
 		writeShowNFO(show, filepath.Join(showPath, "tvshow.nfo"))
 	}
 
-	now := util.UTCBod()
+	airsClock, airsTimezone := trakt.ShowAirs(show)
 	addSpecials := config.Get().AddSpecials
 
 	for i, season := range show.Seasons {
@@ -600,8 +622,7 @@ func writeShowStrm(showID int, adding, force bool) (*tmdb.Show, error) {
 			continue
 		}
 		if config.Get().ShowUnairedSeasons == false {
-			firstAired, _ := time.Parse("2006-01-02", show.FirstAirDate)
-			if firstAired.After(now) || firstAired.Equal(now) {
+			if util.IsUnaired(util.AirTime(show.FirstAirDate, airsClock, airsTimezone)) {
 				continue
 			}
 		}
@@ -612,7 +633,7 @@ func writeShowStrm(showID int, adding, force bool) (*tmdb.Show, error) {
 		// Deleting last season from cache to always get the up-to-date data
 		//  about last episodes
 		if i == len(show.Seasons)-1 {
-			cacheStore.Delete(fmt.Sprintf("com.tmdb.season.%d.%d.%s", showID, season.Season, config.Get().Language))
+			cacheStore.Delete(fmt.Sprintf("com.tmdb.season.%d.%d.%s", showID, season.Season, tmdb.LanguageKey(config.Get().Language)))
 		}
 
 		seasonTMDB := tmdb.GetSeason(showID, season.Season, config.Get().Language)
@@ -631,8 +652,7 @@ func writeShowStrm(showID int, adding, force bool) (*tmdb.Show, error) {
 				if episode.AirDate == "" {
 					continue
 				}
-				firstAired, _ := time.Parse("2006-01-02", episode.AirDate)
-				if firstAired.After(now) || firstAired.Equal(now) {
+				if util.IsUnaired(util.AirTime(episode.AirDate, airsClock, airsTimezone)) {
 					continue
 				}
 			}
@@ -747,6 +767,7 @@ func RemoveMovie(tmdbID int) (*tmdb.Movie, error) {
 	}
 
 	log.Warningf("%s removed from library", movie.Title)
+	publishLibrary(libraryRemoved, movieType, tmdbID)
 	return movie, nil
 }
 
@@ -788,6 +809,7 @@ func RemoveShow(tmdbID string) (*tmdb.Show, error) {
 	}
 
 	log.Warningf("%s removed from library", show.Name)
+	publishLibrary(libraryRemoved, showType, ID)
 
 	return show, nil
 }
@@ -1088,6 +1110,12 @@ func RefreshTrakt() error {
 		Refresh()
 		xbmc.Refresh()
 	}
+	log.Debugf("TraktSync: Playback")
+	if changes, err := SyncTraktPlayback(); err != nil {
+		log.Debugf("TraktSync: Got error from SyncTraktPlayback: %#v", err)
+	} else if changes {
+		xbmc.Refresh()
+	}
 	if config.Get().TraktSyncWatchlist {
 		log.Debugf("TraktSync: Movies Watchlist")
 		if err := SyncMoviesList("watchlist", true); err != nil {
@@ -1560,6 +1588,7 @@ func AddMovie(tmdbID string, force bool) (*tmdb.Movie, error) {
 	}
 
 	log.Noticef("%s added to library", movie.Title)
+	publishLibrary(libraryAdded, movieType, ID)
 	return movie, nil
 }
 
@@ -1587,9 +1616,40 @@ func AddShow(tmdbID string, force bool) (*tmdb.Show, error) {
 		return show, err
 	}
 
+	publishLibrary(libraryAdded, showType, ID)
 	return show, nil
 }
 
+// AddCollection is adding all released movies of TMDB collection to the library,
+// skipping movies that are already there
+func AddCollection(collectionID int) (*tmdb.Collection, []*tmdb.Movie, error) {
+	if err := checkMoviesPath(); err != nil {
+		return nil, nil, err
+	}
+
+	collection := tmdb.GetCollection(collectionID, config.Get().Language)
+	if collection == nil {
+		return nil, nil, fmt.Errorf("Collection with TMDB %d not found", collectionID)
+	}
+
+	added := []*tmdb.Movie{}
+	for _, part := range collection.Parts {
+		tmdbID := strconv.Itoa(part.ID)
+		if part.ReleaseDate == "" || IsDuplicateMovie(tmdbID) != nil {
+			continue
+		}
+
+		movie, err := AddMovie(tmdbID, false)
+		if err != nil {
+			log.Warningf("Could not add %s from collection %s: %s", tmdbID, collection.Name, err)
+			continue
+		}
+		added = append(added, movie)
+	}
+
+	return collection, added, nil
+}
+
 // GetMovieResume returns Resume info for kodi id
 func GetMovieResume(kodiID int) *Resume {
 	l.mu.Movies.Lock()
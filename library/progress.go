@@ -0,0 +1,282 @@
+package library
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/trakt"
+	"github.com/bcrusher29/solaris/xbmc"
+)
+
+const (
+	// Progress difference, in percents, that is not worth syncing
+	progressTolerance = 1.0
+	// Resume points closer to the start are not synced, same as in player
+	progressMinPosition = 180
+	// Pushed resume points are remembered for a month, one key per library item
+	progressSyncedExpiration = 30 * 24 * 60 * 60
+)
+
+type progressUpdate struct {
+	kodiID   int
+	tmdbID   int
+	season   int
+	episode  int
+	position float64
+	total    float64
+	date     time.Time
+}
+
+// SyncTraktPlayback syncs resume points between Kodi library and Trakt.
+// Newer resume point wins: Trakt progress is written into Kodi,
+// while local progress, that is newer, is pushed to Trakt.
+func SyncTraktPlayback() (haveChanges bool, err error) {
+	if config.Get().TraktToken == "" || !config.Get().TraktSyncPlayback {
+		return
+	}
+
+	started := time.Now()
+	defer func() {
+		log.Debugf("Trakt sync playback finished in %s", time.Since(started))
+	}()
+
+	movies, errMovies := trakt.PlaybackMovies()
+	if errMovies != nil {
+		return false, errMovies
+	}
+
+	remoteMovies := map[int]*trakt.PlaybackProgress{}
+	for _, p := range movies {
+		if p.Movie == nil || p.Movie.IDs == nil || p.Movie.IDs.TMDB == 0 {
+			continue
+		}
+		if e, ok := remoteMovies[p.Movie.IDs.TMDB]; !ok || p.PausedAt.After(e.PausedAt) {
+			remoteMovies[p.Movie.IDs.TMDB] = p
+		}
+	}
+
+	pullMovies := []*progressUpdate{}
+	pushMovies := []*progressUpdate{}
+
+	l.mu.Movies.Lock()
+	for _, m := range l.Movies {
+		if m.UIDs.TMDB == 0 || m.Resume == nil {
+			continue
+		}
+
+		if pull, push := resolveProgress(m.Resume, remoteMovies[m.UIDs.TMDB]); pull != nil {
+			pull.kodiID = m.UIDs.Kodi
+			pullMovies = append(pullMovies, pull)
+		} else if push != nil {
+			push.kodiID = m.UIDs.Kodi
+			push.tmdbID = m.UIDs.TMDB
+			pushMovies = append(pushMovies, push)
+		}
+	}
+	l.mu.Movies.Unlock()
+
+	episodes, errEpisodes := trakt.PlaybackEpisodes()
+	if errEpisodes != nil {
+		return false, errEpisodes
+	}
+
+	remoteEpisodes := map[string]*trakt.PlaybackProgress{}
+	for _, p := range episodes {
+		if p.Show == nil || p.Show.IDs == nil || p.Show.IDs.TMDB == 0 || p.Episode == nil {
+			continue
+		}
+		key := fmt.Sprintf("%d_%d_%d", p.Show.IDs.TMDB, p.Episode.Season, p.Episode.Number)
+		if e, ok := remoteEpisodes[key]; !ok || p.PausedAt.After(e.PausedAt) {
+			remoteEpisodes[key] = p
+		}
+	}
+
+	pullEpisodes := []*progressUpdate{}
+	pushEpisodes := []*progressUpdate{}
+
+	l.mu.Shows.Lock()
+	for _, s := range l.Shows {
+		if s.UIDs.TMDB == 0 {
+			continue
+		}
+
+		for _, e := range s.Episodes {
+			if e.Resume == nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%d_%d_%d", s.UIDs.TMDB, e.Season, e.Episode)
+			if pull, push := resolveProgress(e.Resume, remoteEpisodes[key]); pull != nil {
+				pull.kodiID = e.UIDs.Kodi
+				pullEpisodes = append(pullEpisodes, pull)
+			} else if push != nil {
+				push.kodiID = e.UIDs.Kodi
+				push.tmdbID = s.UIDs.TMDB
+				push.season = e.Season
+				push.episode = e.Episode
+				pushEpisodes = append(pushEpisodes, push)
+			}
+		}
+	}
+	l.mu.Shows.Unlock()
+
+	if len(pullMovies) > 0 || len(pullEpisodes) > 0 {
+		log.Infof("Updating resume points from Trakt for %d movies and %d episodes", len(pullMovies), len(pullEpisodes))
+		haveChanges = true
+	}
+
+	for _, u := range pullMovies {
+		xbmc.SetMovieProgressWithDate(u.kodiID, int(u.position), int(u.total), u.date.Local())
+	}
+	for _, u := range pullEpisodes {
+		xbmc.SetEpisodeProgressWithDate(u.kodiID, int(u.position), int(u.total), u.date.Local())
+	}
+
+	for _, u := range pushMovies {
+		if isProgressSynced(MovieType, u) {
+			continue
+		}
+
+		if err := trakt.SetMoviePlayback(u.tmdbID, u.position/u.total*100); err != nil {
+			log.Warningf("Could not push resume point for movie %d to Trakt: %s", u.tmdbID, err)
+			continue
+		}
+		setProgressSynced(MovieType, u)
+	}
+	for _, u := range pushEpisodes {
+		if isProgressSynced(EpisodeType, u) {
+			continue
+		}
+
+		if err := trakt.SetEpisodePlayback(u.tmdbID, u.season, u.episode, u.position/u.total*100); err != nil {
+			log.Warningf("Could not push resume point for episode %d_%d_%d to Trakt: %s", u.tmdbID, u.season, u.episode, err)
+			continue
+		}
+		setProgressSynced(EpisodeType, u)
+	}
+
+	return
+}
+
+// isProgressSynced reports whether a resume point has already been pushed to Trakt.
+// Only the last pushed point of an item is kept, so keys don't pile up.
+func isProgressSynced(mediaType int, u *progressUpdate) bool {
+	synced, err := database.GetCache().GetCached(database.CommonBucket, progressSyncedKey(mediaType, u.kodiID))
+	return err == nil && synced == strconv.FormatInt(u.date.Unix(), 10)
+}
+
+func setProgressSynced(mediaType int, u *progressUpdate) {
+	database.GetCache().SetCached(database.CommonBucket, progressSyncedExpiration, progressSyncedKey(mediaType, u.kodiID), strconv.FormatInt(u.date.Unix(), 10))
+}
+
+func progressSyncedKey(mediaType int, kodiID int) string {
+	return fmt.Sprintf("Synced_progress_%d_%d", mediaType, kodiID)
+}
+
+// resolveProgress compares local resume point with Trakt's one and decides
+// which side should be updated. Local Resume is updated in place when
+// Trakt is newer, so it should be called with library locked.
+func resolveProgress(r *Resume, p *trakt.PlaybackProgress) (pull *progressUpdate, push *progressUpdate) {
+	if p != nil && p.PausedAt.After(r.LastPlayed) {
+		total := r.Total
+		if total <= 0 {
+			total = p.Runtime()
+		}
+		if total <= 0 {
+			return
+		}
+
+		position := p.Position(total)
+		if r.Total > 0 && math.Abs(position-r.Position)/total*100 < progressTolerance {
+			// Same point, most likely pushed by us, just remember the date
+			r.LastPlayed = p.PausedAt
+			return
+		}
+
+		r.Position = position
+		r.Total = total
+		r.LastPlayed = p.PausedAt
+
+		return &progressUpdate{position: position, total: total, date: p.PausedAt}, nil
+	}
+
+	if r.Position < progressMinPosition || r.Total <= 0 || r.LastPlayed.IsZero() {
+		return
+	}
+	if p != nil && math.Abs(r.Position/r.Total*100-p.Progress) < progressTolerance {
+		return
+	}
+
+	return nil, &progressUpdate{position: r.Position, total: r.Total, date: r.LastPlayed}
+}
+
+// GetTraktMovieResume returns resume point, stored on Trakt, for a movie
+func GetTraktMovieResume(tmdbID int) *Resume {
+	if config.Get().TraktToken == "" || !config.Get().TraktSyncPlayback {
+		return nil
+	}
+
+	movies, err := trakt.PlaybackMovies()
+	if err != nil {
+		return nil
+	}
+
+	for _, p := range movies {
+		if p.Movie != nil && p.Movie.IDs != nil && p.Movie.IDs.TMDB == tmdbID {
+			return newTraktResume(p)
+		}
+	}
+
+	return nil
+}
+
+// GetTraktEpisodeResume returns resume point, stored on Trakt, for an episode
+func GetTraktEpisodeResume(showID, season, episode int) *Resume {
+	if config.Get().TraktToken == "" || !config.Get().TraktSyncPlayback {
+		return nil
+	}
+
+	episodes, err := trakt.PlaybackEpisodes()
+	if err != nil {
+		return nil
+	}
+
+	for _, p := range episodes {
+		if p.Show != nil && p.Show.IDs != nil && p.Show.IDs.TMDB == showID && p.Episode != nil && p.Episode.Season == season && p.Episode.Number == episode {
+			return newTraktResume(p)
+		}
+	}
+
+	return nil
+}
+
+func newTraktResume(p *trakt.PlaybackProgress) *Resume {
+	total := p.Runtime()
+	if total <= 0 {
+		return nil
+	}
+
+	return &Resume{
+		Position:   p.Position(total),
+		Total:      total,
+		LastPlayed: p.PausedAt,
+	}
+}
+
+// GetLocalResume returns resume point, stored in local watch history
+func GetLocalResume(mediaType, tmdbID, season, episode int) *Resume {
+	item := database.Get().GetWatchedItem(mediaType, tmdbID, season, episode)
+	if item == nil || item.Position <= 0 || item.Total <= 0 {
+		return nil
+	}
+
+	return &Resume{
+		Position:   item.Position,
+		Total:      item.Total,
+		LastPlayed: item.Updated,
+	}
+}
@@ -0,0 +1,97 @@
+package library
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bcrusher29/solaris/trakt"
+)
+
+func TestResolveProgress(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	movie := &trakt.Movie{Runtime: 100}
+
+	tests := []struct {
+		name   string
+		resume Resume
+		remote *trakt.PlaybackProgress
+		pull   bool
+		push   bool
+		// Resume point, expected after the call
+		position float64
+		played   time.Time
+	}{
+		{
+			name:     "nothing on Trakt",
+			resume:   Resume{Position: 1200, Total: 6000, LastPlayed: hourAgo},
+			push:     true,
+			position: 1200,
+			played:   hourAgo,
+		},
+		{
+			name:     "local point is too close to start",
+			resume:   Resume{Position: 60, Total: 6000, LastPlayed: hourAgo},
+			position: 60,
+			played:   hourAgo,
+		},
+		{
+			name:     "Trakt is newer",
+			resume:   Resume{Position: 1200, Total: 6000, LastPlayed: hourAgo},
+			remote:   &trakt.PlaybackProgress{Progress: 50, PausedAt: now, Movie: movie},
+			pull:     true,
+			position: 3000,
+			played:   now,
+		},
+		{
+			name:     "Trakt is newer without local total",
+			resume:   Resume{LastPlayed: hourAgo},
+			remote:   &trakt.PlaybackProgress{Progress: 25, PausedAt: now, Movie: movie},
+			pull:     true,
+			position: 1500,
+			played:   now,
+		},
+		{
+			name:     "Trakt is newer with the same point",
+			resume:   Resume{Position: 3000, Total: 6000, LastPlayed: hourAgo},
+			remote:   &trakt.PlaybackProgress{Progress: 50.5, PausedAt: now, Movie: movie},
+			position: 3000,
+			played:   now,
+		},
+		{
+			name:     "local is newer",
+			resume:   Resume{Position: 3000, Total: 6000, LastPlayed: now},
+			remote:   &trakt.PlaybackProgress{Progress: 10, PausedAt: hourAgo, Movie: movie},
+			push:     true,
+			position: 3000,
+			played:   now,
+		},
+		{
+			name:     "local is newer with the same point",
+			resume:   Resume{Position: 3000, Total: 6000, LastPlayed: now},
+			remote:   &trakt.PlaybackProgress{Progress: 50, PausedAt: hourAgo, Movie: movie},
+			position: 3000,
+			played:   now,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := test.resume
+			pull, push := resolveProgress(&r, test.remote)
+
+			if (pull != nil) != test.pull || (push != nil) != test.push {
+				t.Fatalf("expected pull=%v push=%v, got pull=%+v push=%+v", test.pull, test.push, pull, push)
+			}
+			if pull != nil && pull.position != test.position {
+				t.Errorf("expected pulled position %f, got %f", test.position, pull.position)
+			}
+			if push != nil && push.position != test.position {
+				t.Errorf("expected pushed position %f, got %f", test.position, push.position)
+			}
+			if r.Position != test.position || !r.LastPlayed.Equal(test.played) {
+				t.Errorf("unexpected resume point after sync: %+v", r)
+			}
+		})
+	}
+}
@@ -89,8 +89,16 @@ type Episode struct {
 
 // Resume shows watched progress information
 type Resume struct {
-	Position float64 `json:"position"`
-	Total    float64 `json:"total"`
+	Position   float64   `json:"position"`
+	Total      float64   `json:"total"`
+	LastPlayed time.Time `json:"lastplayed"`
+}
+
+// Event describes a change of library items
+type Event struct {
+	Action    string `json:"action"`
+	MediaType string `json:"media_type"`
+	TMDBId    int    `json:"tmdb_id,omitempty"`
 }
 
 // DBItem ...
@@ -127,4 +135,5 @@ func (r *Resume) Reset() {
 	log.Debugf("Resetting stored resume position")
 	r.Position = 0
 	r.Total = 0
+	r.LastPlayed = time.Time{}
 }
@@ -17,12 +17,15 @@ import (
 	"github.com/anacrolix/tagflag"
 	"github.com/op/go-logging"
 
+	"github.com/bcrusher29/solaris/anime"
 	"github.com/bcrusher29/solaris/api"
 	"github.com/bcrusher29/solaris/bittorrent"
 	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/library"
 	"github.com/bcrusher29/solaris/lockfile"
+	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/trakt"
 	"github.com/bcrusher29/solaris/util"
 	"github.com/bcrusher29/solaris/xbmc"
@@ -107,6 +110,8 @@ func main() {
 	// Do database migration if needed
 	migrateDB()
 
+	playcount.LoadLocal()
+
 	s := bittorrent.NewService()
 
 	var shutdown = func(fromSignal bool) {
@@ -117,6 +122,7 @@ func main() {
 		s.Closer.Set()
 
 		log.Info("Shutting down...")
+		httpclient.Shutdown()
 		library.CloseLibrary()
 		s.Close(true)
 
@@ -205,7 +211,9 @@ func main() {
 	}()
 	
 	go library.Init()
+	go anime.Init()
 	go trakt.TokenRefreshHandler()
+	go trakt.OutboxHandler()
 	go db.MaintenanceRefreshHandler()
 	go cacheDb.MaintenanceRefreshHandler()
 
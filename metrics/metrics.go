@@ -0,0 +1,222 @@
+// Package metrics keeps counters, gauges and histograms, labeled by
+// a fixed set of label names, and writes them in Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// DurationBuckets are histogram buckets for durations in seconds,
+// from a quick network request to a long buffering
+var DurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+var (
+	mu       sync.Mutex
+	registry = map[string]*Vec{}
+)
+
+// Vec is a metric with values for each combination of label values
+type Vec struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	values map[string]*value
+}
+
+type value struct {
+	labels []string
+	value  float64
+	counts []uint64
+	count  uint64
+}
+
+// NewCounter registers a counter, that only goes up
+func NewCounter(name, help string, labels ...string) *Vec {
+	return register(&Vec{name: name, help: help, kind: counterType, labels: labels})
+}
+
+// NewGauge registers a gauge, that is set to current value
+func NewGauge(name, help string, labels ...string) *Vec {
+	return register(&Vec{name: name, help: help, kind: gaugeType, labels: labels})
+}
+
+// NewHistogram registers a histogram with upper bounds of buckets
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Vec {
+	return register(&Vec{name: name, help: help, kind: histogramType, labels: labels, buckets: buckets})
+}
+
+func register(v *Vec) *Vec {
+	mu.Lock()
+	defer mu.Unlock()
+
+	v.values = map[string]*value{}
+	registry[v.name] = v
+	return v
+}
+
+func (v *Vec) get(labels []string) *value {
+	if len(labels) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s expects %d labels, got %d", v.name, len(v.labels), len(labels)))
+	}
+
+	key := strings.Join(labels, "\x00")
+	val, ok := v.values[key]
+	if !ok {
+		val = &value{labels: labels}
+		if v.kind == histogramType {
+			val.counts = make([]uint64, len(v.buckets))
+		}
+		v.values[key] = val
+	}
+	return val
+}
+
+// Inc increases counter by one
+func (v *Vec) Inc(labels ...string) {
+	v.Add(1, labels...)
+}
+
+// Add increases counter by delta
+func (v *Vec) Add(delta float64, labels ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.get(labels).value += delta
+}
+
+// Set sets gauge value
+func (v *Vec) Set(val float64, labels ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.get(labels).value = val
+}
+
+// Reset removes all values, so gauges of removed items are not reported
+func (v *Vec) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.values = map[string]*value{}
+}
+
+// Observe adds a value to histogram
+func (v *Vec) Observe(val float64, labels ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	h := v.get(labels)
+	for i, bound := range v.buckets {
+		if val <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.value += val
+}
+
+// ObserveSince adds seconds, passed since start, to histogram
+func (v *Vec) ObserveSince(start time.Time, labels ...string) {
+	v.Observe(time.Since(start).Seconds(), labels...)
+}
+
+// Write writes all metrics in Prometheus text exposition format
+func Write(w io.Writer) {
+	mu.Lock()
+	vecs := make([]*Vec, 0, len(registry))
+	for _, v := range registry {
+		vecs = append(vecs, v)
+	}
+	mu.Unlock()
+
+	sort.Slice(vecs, func(i, j int) bool {
+		return vecs[i].name < vecs[j].name
+	})
+	for _, v := range vecs {
+		v.write(w)
+	}
+}
+
+func (v *Vec) write(w io.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, v.kind)
+
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		val := v.values[k]
+		if v.kind != histogramType {
+			fmt.Fprintf(w, "%s%s %s\n", v.name, v.labelString(val.labels, ""), formatValue(val.value))
+			continue
+		}
+
+		for i, bound := range v.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", v.name, v.labelString(val.labels, formatValue(bound)), val.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", v.name, v.labelString(val.labels, "+Inf"), val.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", v.name, v.labelString(val.labels, ""), formatValue(val.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", v.name, v.labelString(val.labels, ""), val.count)
+	}
+}
+
+func (v *Vec) labelString(values []string, le string) string {
+	pairs := make([]string, 0, len(values)+1)
+	for i, name := range v.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabel(values[i])))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf(`le="%s"`, le))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelReplacer.Replace(s)
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	mu.Lock()
+	registry = map[string]*Vec{}
+	mu.Unlock()
+
+	counter := NewCounter("test_requests_total", "Requests\nby status", "status")
+	counter.Inc("200")
+	counter.Add(2, "500")
+
+	gauge := NewGauge("test_peers", `Peers of "torrent"`, "name")
+	gauge.Set(1.5, `Some "quoted" \ name`+"\n")
+
+	histogram := NewHistogram("test_buffer_seconds", "Buffering time", []float64{1, 5})
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+	histogram.Observe(10)
+
+	var b bytes.Buffer
+	Write(&b)
+
+	expected := `# HELP test_buffer_seconds Buffering time
+# TYPE test_buffer_seconds histogram
+test_buffer_seconds_bucket{le="1"} 1
+test_buffer_seconds_bucket{le="5"} 2
+test_buffer_seconds_bucket{le="+Inf"} 3
+test_buffer_seconds_sum 13.5
+test_buffer_seconds_count 3
+# HELP test_peers Peers of "torrent"
+# TYPE test_peers gauge
+test_peers{name="Some \"quoted\" \\ name\n"} 1.5
+# HELP test_requests_total Requests\nby status
+# TYPE test_requests_total counter
+test_requests_total{status="200"} 1
+test_requests_total{status="500"} 2
+`
+	if b.String() != expected {
+		t.Errorf("expected output:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kolo/xmlrpc"
 	"github.com/op/go-logging"
+
+	"github.com/bcrusher29/solaris/httpclient"
 )
 
 const (
@@ -24,6 +27,15 @@ const (
 
 var log = logging.MustGetLogger("osdb")
 
+// OpenSubtitles allows 40 requests per 10 seconds for every IP
+var httpClient = httpclient.New(httpclient.Config{
+	Name:         "osdb",
+	RateLimit:    40,
+	RateInterval: 10 * time.Second,
+	Parallel:     5,
+	RetryAll:     true,
+})
+
 // Client ...
 type Client struct {
 	UserAgent string
@@ -74,7 +86,7 @@ func (m Movies) Empty() bool {
 
 // NewClient ...
 func NewClient() (*Client, error) {
-	rpc, err := xmlrpc.NewClient(DefaultOSDBServer, nil)
+	rpc, err := xmlrpc.NewClient(DefaultOSDBServer, httpClient.Transport())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,120 @@
+package playcount
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/bcrusher29/solaris/database"
+)
+
+// Local contains watched states from local watch history,
+// it is consulted before Kodi and Trakt states
+var Local = map[uint64]bool{}
+
+// localEpisodes counts locally watched episodes by show and season keys,
+// shows and seasons are watched, when all their episodes are
+var localEpisodes = map[uint64]int{}
+
+// LoadLocal reads local watch history into memory
+func LoadLocal() {
+	local := map[uint64]bool{}
+	episodes := map[uint64]int{}
+	for _, item := range database.Get().GetWatchedItems() {
+		if item.State == database.WatchedUnset {
+			continue
+		}
+
+		local[localKey(item.MediaType, item.TMDB, item.Season, item.Episode)] = item.State == database.WatchedYes
+		if item.MediaType == EpisodeType && item.State == database.WatchedYes {
+			countEpisode(episodes, item.TMDB, item.Season, 1)
+		}
+	}
+
+	Mu.Lock()
+	defer Mu.Unlock()
+
+	Local = local
+	localEpisodes = episodes
+	log.Debugf("Loaded %d items from local watch history", len(Local))
+}
+
+// SetLocalMovie updates watched state of a movie in local watch history
+func SetLocalMovie(id int, watched bool) {
+	setLocal(MovieType, id, 0, 0, watched)
+}
+
+// SetLocalEpisode updates watched state of an episode in local watch history
+func SetLocalEpisode(id int, season, episode int, watched bool) {
+	setLocal(EpisodeType, id, season, episode, watched)
+}
+
+func setLocal(mediaType, id, season, episode int, watched bool) {
+	state := database.WatchedNo
+	if watched {
+		state = database.WatchedYes
+	}
+	database.Get().SetWatchedState(mediaType, id, season, episode, state)
+	updateLocal(mediaType, id, season, episode, watched)
+}
+
+// AddLocalPlay stores finished playback in local watch history
+func AddLocalPlay(mediaType, id, season, episode int) {
+	if id == 0 {
+		return
+	}
+
+	database.Get().AddWatchedPlay(mediaType, id, season, episode, time.Now())
+	updateLocal(mediaType, id, season, episode, true)
+}
+
+// updateLocal updates in-memory state, episode counts of shows and seasons change,
+// only when the episode changes its state
+func updateLocal(mediaType, id, season, episode int, watched bool) {
+	Mu.Lock()
+	defer Mu.Unlock()
+
+	key := localKey(mediaType, id, season, episode)
+	if mediaType == EpisodeType && Local[key] != watched {
+		if watched {
+			countEpisode(localEpisodes, id, season, 1)
+		} else {
+			countEpisode(localEpisodes, id, season, -1)
+		}
+	}
+	Local[key] = watched
+}
+
+// SetLocalProgress stores resume point in local watch history
+func SetLocalProgress(mediaType, id, season, episode int, position, total float64) {
+	if id == 0 {
+		return
+	}
+
+	database.Get().SetWatchedProgress(mediaType, id, season, episode, position, total)
+}
+
+// countEpisode adds a watched episode to counts of its season and show,
+// specials are not counted for the show
+func countEpisode(counts map[uint64]int, id, season, delta int) {
+	counts[seasonKey(id, season)] += delta
+	if season > 0 {
+		counts[showKey(id)] += delta
+	}
+}
+
+func showKey(id int) uint64 {
+	return xxhash.Sum64String(fmt.Sprintf("%d_%d_%d", ShowType, TMDBScraper, id))
+}
+
+func seasonKey(id, season int) uint64 {
+	return xxhash.Sum64String(fmt.Sprintf("%d_%d_%d_%d", SeasonType, TMDBScraper, id, season))
+}
+
+func localKey(mediaType, id, season, episode int) uint64 {
+	if mediaType == EpisodeType {
+		return xxhash.Sum64String(fmt.Sprintf("%d_%d_%d_%d_%d", EpisodeType, TMDBScraper, id, season, episode))
+	}
+	return xxhash.Sum64String(fmt.Sprintf("%d_%d_%d", MovieType, TMDBScraper, id))
+}
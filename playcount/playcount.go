@@ -49,7 +49,12 @@ func GetWatchedMovieByTMDB(id int) (ret WatchedState) {
 	Mu.RLock()
 	defer Mu.RUnlock()
 
-	_, ret = Watched[xxhash.Sum64String(fmt.Sprintf("%d_%d_%d", MovieType, TMDBScraper, id))]
+	key := xxhash.Sum64String(fmt.Sprintf("%d_%d_%d", MovieType, TMDBScraper, id))
+	if w, ok := Local[key]; ok {
+		return WatchedState(w)
+	}
+
+	_, ret = Watched[key]
 	return
 }
 
@@ -71,12 +76,18 @@ func GetWatchedMovieByTrakt(id int) (ret WatchedState) {
 	return
 }
 
-// GetWatchedShowByTMDB checks whether item is watched
-func GetWatchedShowByTMDB(id int) (ret WatchedState) {
+// GetWatchedShowByTMDB checks whether item is watched, show with episodes count
+// is watched, when all its episodes are in local watch history, count is 0 if unknown
+func GetWatchedShowByTMDB(id int, episodes int) (ret WatchedState) {
 	Mu.RLock()
 	defer Mu.RUnlock()
 
-	_, ret = Watched[xxhash.Sum64String(fmt.Sprintf("%d_%d_%d", ShowType, TMDBScraper, id))]
+	key := showKey(id)
+	if episodes > 0 && localEpisodes[key] >= episodes {
+		return true
+	}
+
+	_, ret = Watched[key]
 	return
 }
 
@@ -98,12 +109,17 @@ func GetWatchedShowByTrakt(id int) (ret WatchedState) {
 	return
 }
 
-// GetWatchedSeasonByTMDB checks whether item is watched
-func GetWatchedSeasonByTMDB(id int, season int) (ret WatchedState) {
+// GetWatchedSeasonByTMDB checks whether item is watched, the same way as shows
+func GetWatchedSeasonByTMDB(id int, season int, episodes int) (ret WatchedState) {
 	Mu.RLock()
 	defer Mu.RUnlock()
 
-	_, ret = Watched[xxhash.Sum64String(fmt.Sprintf("%d_%d_%d_%d", SeasonType, TMDBScraper, id, season))]
+	key := seasonKey(id, season)
+	if episodes > 0 && localEpisodes[key] >= episodes {
+		return true
+	}
+
+	_, ret = Watched[key]
 	return
 }
 
@@ -130,7 +146,12 @@ func GetWatchedEpisodeByTMDB(id int, season, episode int) (ret WatchedState) {
 	Mu.RLock()
 	defer Mu.RUnlock()
 
-	_, ret = Watched[xxhash.Sum64String(fmt.Sprintf("%d_%d_%d_%d_%d", EpisodeType, TMDBScraper, id, season, episode))]
+	key := xxhash.Sum64String(fmt.Sprintf("%d_%d_%d_%d_%d", EpisodeType, TMDBScraper, id, season, episode))
+	if w, ok := Local[key]; ok {
+		return WatchedState(w)
+	}
+
+	_, ret = Watched[key]
 	return
 }
 
@@ -58,6 +58,10 @@ type EpisodeSearchObject struct {
 	Year           int               `json:"year"`
 	Titles         map[string]string `json:"titles"`
 	AbsoluteNumber int               `json:"absolute_number"`
+	AniDBId        int               `json:"anidb_id"`
+	AniListId      int               `json:"anilist_id"`
+	MALId          int               `json:"mal_id"`
+	AniDBEpisode   int               `json:"anidb_episode"`
 }
 
 func (sp *SearchPayload) String() string {
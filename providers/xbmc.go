@@ -10,8 +10,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bcrusher29/solaris/anime"
 	"github.com/bcrusher29/solaris/bittorrent"
 	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/metrics"
 	"github.com/bcrusher29/solaris/tmdb"
 	"github.com/bcrusher29/solaris/tvdb"
 	"github.com/bcrusher29/solaris/util"
@@ -35,6 +37,12 @@ type AddonSearcher struct {
 	log     *logging.Logger
 }
 
+var (
+	searchMetric         = metrics.NewHistogram("elementum_provider_search_seconds", "Duration of provider searches", metrics.DurationBuckets, "addon", "method")
+	searchFailuresMetric = metrics.NewCounter("elementum_provider_search_failures_total", "Provider searches, that timed out or returned bad results", "addon", "method", "reason")
+	searchResultsMetric  = metrics.NewCounter("elementum_provider_results_total", "Torrents, returned by providers", "addon", "method")
+)
+
 var cbLock = sync.RWMutex{}
 var callbacks = map[string]chan []byte{}
 
@@ -243,19 +251,40 @@ func (as *AddonSearcher) GetEpisodeSearchObject(show *tmdb.Show, episode *tmdb.E
 
 	// Is this an Anime?
 	absoluteNumber := 0
+	isAnime := show.IsAnime() || (show.IsAnimation() && anime.IsAnime(tvdbID))
+	var animeEpisode *anime.Mapping
 	if tvdbID > 0 {
-		if show.IsAnime() {
-			tvdbShow, err := tvdb.GetShow(tvdbID, config.Get().Language)
-			if err == nil && len(tvdbShow.Seasons) >= episode.SeasonNumber+1 {
-				tvdbSeason := tvdbShow.Seasons[episode.SeasonNumber]
-				if len(tvdbSeason.Episodes) >= episode.EpisodeNumber {
-					tvdbEpisode := tvdbSeason.Episodes[episode.EpisodeNumber-1]
-					if tvdbEpisode.AbsoluteNumber > 0 {
-						absoluteNumber = tvdbEpisode.AbsoluteNumber
+		if isAnime {
+			// TMDB and TVDB often split anime into seasons differently,
+			// so TVDB episode is found by absolute number, counted by TMDB seasons
+			tmdbAbsolute := tmdbAbsoluteNumber(show, episode.SeasonNumber, episode.EpisodeNumber)
+			tvdbSeason, tvdbNumber := episode.SeasonNumber, episode.EpisodeNumber
+			absoluteNumber = tmdbAbsolute
+
+			// TheTVDB is used only with API key, set by user
+			if tvdb.Enabled() {
+				if tvdbShow, err := tvdb.GetShow(tvdbID, config.Get().Language); err == nil {
+					var tvdbEpisode *tvdb.Episode
+					if tmdbAbsolute > 0 {
+						tvdbEpisode = tvdbShow.GetEpisode(tvdb.OrderAbsolute, 0, tmdbAbsolute)
+					}
+					if tvdbEpisode == nil {
+						tvdbEpisode = tvdbShow.GetEpisode(tvdb.OrderAired, episode.SeasonNumber, episode.EpisodeNumber)
+					}
+					if tvdbEpisode != nil {
+						if tvdbEpisode.AbsoluteNumber > 0 {
+							absoluteNumber = tvdbEpisode.AbsoluteNumber
+						}
+						tvdbSeason, tvdbNumber = tvdbEpisode.SeasonNumber, tvdbEpisode.EpisodeNumber
+						title = tvdbShow.SeriesName
 					}
-					title = tvdbShow.SeriesName
 				}
 			}
+
+			animeEpisode = anime.GetTMDBEpisode(show.ID, episode.SeasonNumber, episode.EpisodeNumber, tmdbAbsolute)
+			if animeEpisode == nil {
+				animeEpisode = anime.GetEpisode(tvdbID, tvdbSeason, tvdbNumber, absoluteNumber)
+			}
 		}
 	}
 
@@ -270,6 +299,21 @@ func (as *AddonSearcher) GetEpisodeSearchObject(show *tmdb.Show, episode *tmdb.E
 		AbsoluteNumber: absoluteNumber,
 	}
 
+	// Split-cour seasons are separate AniDB entries, so anime providers
+	// need AniDB episode number along with IDs, romanized and English titles
+	if animeEpisode != nil {
+		sObject.AniDBId = animeEpisode.AniDBID
+		sObject.AniListId = animeEpisode.AniListID
+		sObject.MALId = animeEpisode.MALID
+		sObject.AniDBEpisode = animeEpisode.Episode
+		if animeEpisode.Title != "" {
+			sObject.Titles["romaji"] = NormalizeTitle(animeEpisode.Title)
+		}
+		if animeEpisode.EnglishTitle != "" {
+			sObject.Titles["english"] = NormalizeTitle(animeEpisode.EnglishTitle)
+		}
+	}
+
 	// Collect titles from AlternativeTitles
 	if show.AlternativeTitles != nil && show.AlternativeTitles.Titles != nil {
 		for _, title := range show.AlternativeTitles.Titles {
@@ -291,7 +335,7 @@ func (as *AddonSearcher) GetEpisodeSearchObject(show *tmdb.Show, episode *tmdb.E
 		}
 	}
 
-	if show.IsAnime() && config.Get().UseAnimeEnTitle {
+	if isAnime && config.Get().UseAnimeEnTitle {
 		if t, ok := sObject.Titles["en"]; ok {
 			sObject.Titles["original"] = t
 		}
@@ -304,6 +348,27 @@ func (as *AddonSearcher) GetEpisodeSearchObject(show *tmdb.Show, episode *tmdb.E
 	return sObject
 }
 
+// tmdbAbsoluteNumber counts episode number from the start of the show
+// by TMDB seasons, specials are not counted. It is 0, when episode counts
+// of previous seasons are unknown.
+func tmdbAbsoluteNumber(show *tmdb.Show, season, episode int) int {
+	if season < 1 {
+		return 0
+	}
+
+	number := episode
+	for _, s := range show.Seasons {
+		if s == nil || s.Season < 1 || s.Season >= season {
+			continue
+		}
+		if s.EpisodeCount == 0 {
+			return 0
+		}
+		number += s.EpisodeCount
+	}
+	return number
+}
+
 func (as *AddonSearcher) call(method string, searchObject interface{}) []*bittorrent.TorrentFile {
 	torrents := make([]*bittorrent.TorrentFile, 0)
 	cid, c := GetCallback()
@@ -315,6 +380,7 @@ func (as *AddonSearcher) call(method string, searchObject interface{}) []*bittor
 		SearchObject: searchObject,
 	}
 
+	start := time.Now()
 	xbmc.ExecuteAddon(as.addonID, payload.String())
 
 	timeout := providerTimeout()
@@ -326,9 +392,15 @@ func (as *AddonSearcher) call(method string, searchObject interface{}) []*bittor
 	case <-time.After(timeout):
 		as.log.Warningf("Provider %s was too slow. Ignored.", as.addonID)
 		RemoveCallback(cid)
+		searchFailuresMetric.Inc(as.addonID, method, "timeout")
 	case result := <-c:
-		json.Unmarshal(result, &torrents)
+		if err := json.Unmarshal(result, &torrents); err != nil {
+			as.log.Warningf("Could not parse results of provider %s: %s", as.addonID, err)
+			searchFailuresMetric.Inc(as.addonID, method, "bad_result")
+		}
 	}
+	searchMetric.ObserveSince(start, as.addonID, method)
+	searchResultsMetric.Add(float64(len(torrents)), as.addonID, method)
 
 	return torrents
 }
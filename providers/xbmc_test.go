@@ -0,0 +1,38 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/bcrusher29/solaris/tmdb"
+)
+
+func TestTMDBAbsoluteNumber(t *testing.T) {
+	show := &tmdb.Show{
+		Seasons: tmdb.SeasonList{
+			{Season: 0, EpisodeCount: 3},
+			{Season: 1, EpisodeCount: 12},
+			nil,
+			{Season: 2, EpisodeCount: 13},
+			{Season: 3, EpisodeCount: 0},
+		},
+	}
+
+	tests := []struct {
+		season   int
+		episode  int
+		expected int
+	}{
+		{0, 2, 0},
+		{1, 5, 5},
+		{2, 1, 13},
+		{3, 4, 29},
+		// Unknown episode count of a previous season
+		{4, 1, 0},
+	}
+
+	for _, test := range tests {
+		if number := tmdbAbsoluteNumber(show, test.season, test.episode); number != test.expected {
+			t.Errorf("tmdbAbsoluteNumber(S%02dE%02d) = %d, expected %d", test.season, test.episode, number, test.expected)
+		}
+	}
+}
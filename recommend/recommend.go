@@ -0,0 +1,240 @@
+// Package recommend finds movies and shows, similar to recently watched ones,
+// using TMDB similar and recommended titles. Candidates, that several
+// watched titles lead to, are ranked higher.
+package recommend
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/op/go-logging"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/library"
+	"github.com/bcrusher29/solaris/playcount"
+	"github.com/bcrusher29/solaris/tmdb"
+	"github.com/bcrusher29/solaris/trakt"
+)
+
+var log = logging.MustGetLogger("recommend")
+
+// Weights of score parts
+const (
+	seedWeight           = 2.0
+	recommendationWeight = 1.0
+	similarWeight        = 0.5
+	// Titles older than that get no recency bonus
+	recencyYears = 20
+	// Ratings of titles with fewer votes are not trusted
+	minVotes = 50
+)
+
+// Seed is a watched title, that candidates are found for
+type Seed struct {
+	TMDBID  int       `json:"tmdb_id"`
+	Watched time.Time `json:"watched"`
+}
+
+// Candidate is a title, related to one or more seeds
+type Candidate struct {
+	*tmdb.Entity
+	Seeds []int   `json:"seeds"`
+	Score float64 `json:"score"`
+
+	relations float64
+}
+
+// MovieSeeds returns recently watched movies from local watch history
+// and Trakt, when it is authorized
+func MovieSeeds(limit int) []*Seed {
+	seeds := map[int]time.Time{}
+	for _, item := range database.Get().GetWatchedItems() {
+		if item.MediaType == playcount.MovieType && item.State != database.WatchedNo && item.Plays > 0 {
+			addSeed(seeds, item.TMDB, item.LastWatched)
+		}
+	}
+
+	if config.Get().TraktToken != "" {
+		if movies, err := trakt.WatchedMovies(); err == nil {
+			for _, m := range movies {
+				if m.Movie != nil {
+					addSeed(seeds, m.Movie.IDs.TMDB, m.LastWatchedAt)
+				}
+			}
+		}
+	}
+
+	return sortSeeds(seeds, limit)
+}
+
+// ShowSeeds returns shows with recently watched episodes from local
+// watch history and Trakt, when it is authorized
+func ShowSeeds(limit int) []*Seed {
+	seeds := map[int]time.Time{}
+	for _, item := range database.Get().GetWatchedItems() {
+		if item.MediaType == playcount.EpisodeType && item.State != database.WatchedNo && item.Plays > 0 {
+			addSeed(seeds, item.TMDB, item.LastWatched)
+		}
+	}
+
+	if config.Get().TraktToken != "" {
+		if shows, err := trakt.WatchedShows(); err == nil {
+			for _, s := range shows {
+				if s.Show != nil {
+					addSeed(seeds, s.Show.IDs.TMDB, s.LastWatchedAt)
+				}
+			}
+		}
+	}
+
+	return sortSeeds(seeds, limit)
+}
+
+// Movies returns candidates for seeds, except watched movies and movies in the library,
+// best first
+func Movies(seeds []*Seed) []*Candidate {
+	return rank(seeds, tmdb.GetRelatedMovies, func(id int) bool {
+		if playcount.GetWatchedMovieByTMDB(id) {
+			return true
+		}
+		_, err := library.GetMovieByTMDB(id)
+		return err == nil || library.IsDuplicateMovie(strconv.Itoa(id)) != nil
+	})
+}
+
+// Shows returns candidates for seeds, except watched shows and shows in the library,
+// best first
+func Shows(seeds []*Seed) []*Candidate {
+	return rank(seeds, tmdb.GetRelatedShows, func(id int) bool {
+		// Candidates have no episode counts, so only Kodi and Trakt states are checked
+		if playcount.GetWatchedShowByTMDB(id, 0) {
+			return true
+		}
+		_, err := library.GetShowByTMDB(id)
+		return err == nil
+	})
+}
+
+func addSeed(seeds map[int]time.Time, tmdbID int, watched time.Time) {
+	if tmdbID == 0 {
+		return
+	}
+	if t, ok := seeds[tmdbID]; !ok || watched.After(t) {
+		seeds[tmdbID] = watched
+	}
+}
+
+func sortSeeds(seeds map[int]time.Time, limit int) []*Seed {
+	ret := make([]*Seed, 0, len(seeds))
+	for id, t := range seeds {
+		ret = append(ret, &Seed{TMDBID: id, Watched: t})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Watched.After(ret[j].Watched)
+	})
+
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret
+}
+
+func rank(seeds []*Seed, related func(int, string, string) []*tmdb.Entity, excluded func(int) bool) []*Candidate {
+	language := config.Get().Language
+
+	type result struct {
+		seed     int
+		weight   float64
+		entities []*tmdb.Entity
+	}
+	results := make(chan *result, len(seeds)*2)
+
+	var wg sync.WaitGroup
+	for _, seed := range seeds {
+		for kind, weight := range map[string]float64{tmdb.RelatedRecommendations: recommendationWeight, tmdb.RelatedSimilar: similarWeight} {
+			wg.Add(1)
+			go func(seed int, kind string, weight float64) {
+				defer wg.Done()
+				results <- &result{seed: seed, weight: weight, entities: related(seed, kind, language)}
+			}(seed.TMDBID, kind, weight)
+		}
+	}
+	wg.Wait()
+	close(results)
+
+	isSeed := map[int]bool{}
+	for _, seed := range seeds {
+		isSeed[seed.TMDBID] = true
+	}
+
+	candidates := map[int]*Candidate{}
+	for r := range results {
+		for _, e := range r.entities {
+			if e == nil || isSeed[e.ID] {
+				continue
+			}
+
+			c, ok := candidates[e.ID]
+			if !ok {
+				c = &Candidate{Entity: e}
+				candidates[e.ID] = c
+			}
+			if !containsInt(c.Seeds, r.seed) {
+				c.Seeds = append(c.Seeds, r.seed)
+			}
+			c.relations += r.weight
+		}
+	}
+
+	ret := make([]*Candidate, 0, len(candidates))
+	for id, c := range candidates {
+		if excluded(id) {
+			continue
+		}
+		c.Score = score(c)
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score == ret[j].Score {
+			return ret[i].ID < ret[j].ID
+		}
+		return ret[i].Score > ret[j].Score
+	})
+
+	log.Debugf("Ranked %d candidates from %d seeds", len(ret), len(seeds))
+	return ret
+}
+
+// score sums shared seeds, relation kinds, rating and recency
+func score(c *Candidate) float64 {
+	s := float64(len(c.Seeds))*seedWeight + c.relations
+
+	if c.VoteCount >= minVotes {
+		s += float64(c.VoteAverage) / 10
+	}
+
+	date := c.ReleaseDate
+	if date == "" {
+		date = c.FirstAirDate
+	}
+	if year, err := strconv.Atoi(strings.SplitN(date, "-", 2)[0]); err == nil {
+		if age := time.Now().Year() - year; age < recencyYears {
+			s += float64(recencyYears-age) / recencyYears
+		}
+	}
+
+	return s
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
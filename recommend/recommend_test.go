@@ -0,0 +1,66 @@
+package recommend
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bcrusher29/solaris/tmdb"
+)
+
+func TestRank(t *testing.T) {
+	thisYear := strconv.Itoa(time.Now().Year()) + "-01-01"
+	related := map[int]map[string][]*tmdb.Entity{
+		1: {
+			tmdb.RelatedRecommendations: {{ID: 10}, {ID: 11}, {ID: 2}, {ID: 12}},
+			tmdb.RelatedSimilar:         {{ID: 10}, {ID: 13}},
+		},
+		2: {
+			tmdb.RelatedRecommendations: {{ID: 10}, {ID: 11}, nil},
+			tmdb.RelatedSimilar: {
+				{ID: 1},
+				{ID: 14, VoteAverage: 9, VoteCount: 100, ReleaseDate: thisYear},
+				{ID: 15, VoteAverage: 9, VoteCount: 10},
+			},
+		},
+	}
+	seeds := []*Seed{{TMDBID: 1}, {TMDBID: 2}}
+
+	candidates := rank(seeds, func(id int, kind string, language string) []*tmdb.Entity {
+		return related[id][kind]
+	}, func(id int) bool {
+		return id == 12
+	})
+
+	expected := []struct {
+		id    int
+		seeds []int
+		score float64
+	}{
+		// Two seeds, recommended by both and similar to one
+		{10, []int{1, 2}, 6.5},
+		{11, []int{1, 2}, 6},
+		// Rating and recency bonus
+		{14, []int{2}, 4.4},
+		// Equal scores are ordered by ID, rating of few votes is ignored
+		{13, []int{1}, 2.5},
+		{15, []int{2}, 2.5},
+	}
+
+	if len(candidates) != len(expected) {
+		ids := []int{}
+		for _, c := range candidates {
+			ids = append(ids, c.ID)
+		}
+		t.Fatalf("expected %d candidates, got %v", len(expected), ids)
+	}
+	for i, e := range expected {
+		c := candidates[i]
+		sort.Ints(c.Seeds)
+		if c.ID != e.id || !reflect.DeepEqual(c.Seeds, e.seeds) || c.Score < e.score-0.001 || c.Score > e.score+0.001 {
+			t.Errorf("expected candidate %d with seeds %v and score %.2f at %d, got %d with seeds %v and score %.2f", e.id, e.seeds, e.score, i, c.ID, c.Seeds, c.Score)
+		}
+	}
+}
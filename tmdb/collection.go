@@ -0,0 +1,54 @@
+package tmdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bcrusher29/solaris/cache"
+
+	"github.com/jmcvetta/napping"
+)
+
+// GetCollection returns a collection with its movies, sorted by release date
+func GetCollection(collectionID int, language string) *Collection {
+	var collection *Collection
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.tmdb.collection.%d.%s", collectionID, language)
+	if err := cacheStore.Get(key, &collection); err != nil {
+		err = MakeRequest(APIRequest{
+			URL: fmt.Sprintf("%s/collection/%d", tmdbEndpoint, collectionID),
+			Params: napping.Params{
+				"api_key":  apiKey,
+				"language": language,
+			}.AsUrlValues(),
+			Result:      &collection,
+			Description: "collection",
+		})
+
+		if collection != nil {
+			cacheStore.Set(key, collection, cacheExpiration)
+		}
+	}
+	if collection == nil {
+		return nil
+	}
+
+	// Unreleased movies have no date and go last
+	sort.SliceStable(collection.Parts, func(i, j int) bool {
+		a, b := collection.Parts[i].ReleaseDate, collection.Parts[j].ReleaseDate
+		if a == "" || b == "" {
+			return a != ""
+		}
+		return a < b
+	})
+	return collection
+}
+
+// MovieIDs returns TMDB IDs of collection movies
+func (c *Collection) MovieIDs() []int {
+	ids := make([]int, 0, len(c.Parts))
+	for _, part := range c.Parts {
+		ids = append(ids, part.ID)
+	}
+	return ids
+}
@@ -0,0 +1,312 @@
+package tmdb
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/playcount"
+
+	"github.com/jmcvetta/napping"
+)
+
+// Sort orders of discover lists
+const (
+	DiscoverSortPopularity = "popularity.desc"
+	DiscoverSortRating     = "vote_average.desc"
+	DiscoverSortVotes      = "vote_count.desc"
+	DiscoverSortNewest     = "release_date.desc"
+	DiscoverSortOldest     = "release_date.asc"
+)
+
+// keywordExpiration is long, since keyword IDs never change
+const keywordExpiration = 30 * 24 * time.Hour
+
+// excludeWatchedPages limits pages of a list, that are read
+// to fill one page of unwatched results
+const excludeWatchedPages = 4
+
+// DiscoverMovies lists movies, that match all filters
+func DiscoverMovies(filters DiscoverFilters, language string, page int) (Movies, int) {
+	p := filters.params(language, "primary_release_date", "release_date")
+	if filters.Certification != "" {
+		p["certification"] = filters.Certification
+		p["certification_country"] = filters.certificationCountry()
+	}
+
+	cacheKey := "discover." + filters.Values().Encode()
+	if !filters.ExcludeWatched {
+		return listMovies("discover/movie", cacheKey, p, page)
+	}
+
+	// Watched movies are removed after TMDB pagination, so the list is read
+	// from the start, until the page is filled with unwatched ones
+	perPage := config.Get().ResultsPerPage
+	skip := (page - 1) * perPage
+	ret := make(Movies, 0, perPage)
+	total := 0
+	for current := 1; current <= page*excludeWatchedPages; current++ {
+		movies, listTotal := listMovies("discover/movie", cacheKey, p, current)
+		total = listTotal
+		for _, m := range movies {
+			if m == nil || playcount.GetWatchedMovieByTMDB(m.ID) {
+				continue
+			} else if skip > 0 {
+				skip--
+				continue
+			}
+
+			ret = append(ret, m)
+			if len(ret) == perPage {
+				return ret, total
+			}
+		}
+
+		// Whole list is read, so there is no next page
+		if current*perPage >= listTotal {
+			return ret, (page-1)*perPage + len(ret)
+		}
+	}
+	return ret, total
+}
+
+// DiscoverShows lists shows, that match all filters.
+// TMDB has no certifications for shows, so Certification is ignored.
+func DiscoverShows(filters DiscoverFilters, language string, page int) (Shows, int) {
+	p := filters.params(language, "first_air_date", "first_air_date")
+
+	cacheKey := "discover." + filters.Values().Encode()
+	if !filters.ExcludeWatched {
+		return listShows("discover/tv", cacheKey, p, page)
+	}
+
+	// Same as for movies, the page is filled from following pages of the list
+	perPage := config.Get().ResultsPerPage
+	skip := (page - 1) * perPage
+	ret := make(Shows, 0, perPage)
+	total := 0
+	for current := 1; current <= page*excludeWatchedPages; current++ {
+		shows, listTotal := listShows("discover/tv", cacheKey, p, current)
+		total = listTotal
+		for _, s := range shows {
+			if s == nil || playcount.GetWatchedShowByTMDB(s.ID, s.NumberOfEpisodes) {
+				continue
+			} else if skip > 0 {
+				skip--
+				continue
+			}
+
+			ret = append(ret, s)
+			if len(ret) == perPage {
+				return ret, total
+			}
+		}
+
+		if current*perPage >= listTotal {
+			return ret, (page-1)*perPage + len(ret)
+		}
+	}
+	return ret, total
+}
+
+// params converts filters into discover parameters, dateField is a field
+// to filter years by and sortDate is a date field to sort by.
+func (f DiscoverFilters) params(language, dateField, sortDate string) napping.Params {
+	p := napping.Params{
+		"language":         language,
+		"sort_by":          DiscoverSortPopularity,
+		dateField + ".lte": time.Now().UTC().Format("2006-01-02"),
+	}
+
+	if f.SortBy != "" {
+		p["sort_by"] = strings.Replace(f.SortBy, "release_date", sortDate, 1)
+	}
+
+	genres := f.Genres
+	if f.Genre != "" {
+		if id, err := strconv.Atoi(f.Genre); err == nil {
+			genres = append(genres, id)
+		}
+	}
+	separator := ","
+	if f.GenresAny {
+		separator = "|"
+	}
+	if len(genres) > 0 {
+		p["with_genres"] = joinInts(genres, separator)
+	}
+	if len(f.WithoutGenres) > 0 {
+		p["without_genres"] = joinInts(f.WithoutGenres, ",")
+	}
+
+	if f.Country != "" {
+		p["region"] = f.Country
+	}
+	if f.Language != "" {
+		p["with_original_language"] = f.Language
+	}
+
+	if f.YearFrom > 0 {
+		p[dateField+".gte"] = fmt.Sprintf("%d-01-01", f.YearFrom)
+	}
+	if f.YearTo > 0 && f.YearTo < time.Now().Year() {
+		p[dateField+".lte"] = fmt.Sprintf("%d-12-31", f.YearTo)
+	}
+
+	if f.VoteAverageMin > 0 {
+		p["vote_average.gte"] = strconv.FormatFloat(f.VoteAverageMin, 'f', -1, 64)
+	}
+	if f.VoteCountMin > 0 {
+		p["vote_count.gte"] = strconv.Itoa(f.VoteCountMin)
+	}
+	if f.RuntimeMin > 0 {
+		p["with_runtime.gte"] = strconv.Itoa(f.RuntimeMin)
+	}
+	if f.RuntimeMax > 0 {
+		p["with_runtime.lte"] = strconv.Itoa(f.RuntimeMax)
+	}
+
+	// Movies with any of keywords are listed
+	keywords := []int{}
+	for _, k := range f.Keywords {
+		if id := GetKeywordID(k); id != 0 {
+			keywords = append(keywords, id)
+		}
+	}
+	if len(keywords) > 0 {
+		p["with_keywords"] = joinInts(keywords, "|")
+	}
+
+	return p
+}
+
+func (f DiscoverFilters) certificationCountry() string {
+	if f.Country != "" {
+		return f.Country
+	}
+	return "US"
+}
+
+// Values encodes filters into query parameters, that ParseDiscoverFilters reads
+func (f DiscoverFilters) Values() url.Values {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" && value != "0" {
+			v.Set(key, value)
+		}
+	}
+
+	set("genre", f.Genre)
+	set("country", f.Country)
+	set("language", f.Language)
+	set("genres", joinInts(f.Genres, ","))
+	set("without_genres", joinInts(f.WithoutGenres, ","))
+	if f.GenresAny {
+		set("genres_any", "true")
+	}
+	set("year_from", strconv.Itoa(f.YearFrom))
+	set("year_to", strconv.Itoa(f.YearTo))
+	set("vote_average_min", strconv.FormatFloat(f.VoteAverageMin, 'f', -1, 64))
+	set("vote_count_min", strconv.Itoa(f.VoteCountMin))
+	set("runtime_min", strconv.Itoa(f.RuntimeMin))
+	set("runtime_max", strconv.Itoa(f.RuntimeMax))
+	set("certification", f.Certification)
+	set("keywords", strings.Join(f.Keywords, ","))
+	if f.ExcludeWatched {
+		set("exclude_watched", "true")
+	}
+	set("sort_by", f.SortBy)
+
+	return v
+}
+
+// ParseDiscoverFilters reads filters from query parameters
+func ParseDiscoverFilters(v url.Values) DiscoverFilters {
+	f := DiscoverFilters{
+		Genre:          v.Get("genre"),
+		Country:        v.Get("country"),
+		Language:       v.Get("language"),
+		Genres:         splitInts(v.Get("genres")),
+		GenresAny:      v.Get("genres_any") == "true",
+		WithoutGenres:  splitInts(v.Get("without_genres")),
+		Certification:  v.Get("certification"),
+		ExcludeWatched: v.Get("exclude_watched") == "true",
+		SortBy:         v.Get("sort_by"),
+	}
+	f.YearFrom, _ = strconv.Atoi(v.Get("year_from"))
+	f.YearTo, _ = strconv.Atoi(v.Get("year_to"))
+	f.VoteAverageMin, _ = strconv.ParseFloat(v.Get("vote_average_min"), 64)
+	f.VoteCountMin, _ = strconv.Atoi(v.Get("vote_count_min"))
+	f.RuntimeMin, _ = strconv.Atoi(v.Get("runtime_min"))
+	f.RuntimeMax, _ = strconv.Atoi(v.Get("runtime_max"))
+	for _, k := range strings.Split(v.Get("keywords"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			f.Keywords = append(f.Keywords, k)
+		}
+	}
+
+	return f
+}
+
+// GetKeywordID resolves keyword name into TMDB keyword ID, using the first match
+func GetKeywordID(name string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if id, err := strconv.Atoi(name); err == nil {
+		return id
+	}
+
+	var id int
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.tmdb.keyword.%s", name)
+	if err := cacheStore.Get(key, &id); err != nil {
+		var results struct {
+			Results []*IDName `json:"results"`
+		}
+		MakeRequest(APIRequest{
+			URL: fmt.Sprintf("%s/search/keyword", tmdbEndpoint),
+			Params: napping.Params{
+				"api_key": apiKey,
+				"query":   name,
+			}.AsUrlValues(),
+			Result:      &results,
+			Description: "search keyword",
+		})
+
+		// Exact match is preferred over the first, most popular, one
+		for _, k := range results.Results {
+			if strings.ToLower(k.Name) == name {
+				id = k.ID
+				break
+			}
+		}
+		if id == 0 && len(results.Results) > 0 {
+			id = results.Results[0].ID
+		}
+		if id != 0 {
+			cacheStore.Set(key, id, keywordExpiration)
+		}
+	}
+	return id
+}
+
+func joinInts(ints []int, separator string) string {
+	parts := make([]string, 0, len(ints))
+	for _, i := range ints {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return strings.Join(parts, separator)
+}
+
+func splitInts(s string) []int {
+	ret := []int{}
+	for _, part := range strings.Split(s, ",") {
+		if i, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
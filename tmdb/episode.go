@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
-	"time"
 
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
@@ -19,7 +18,7 @@ import (
 func GetEpisode(showID int, seasonNumber int, episodeNumber int, language string) *Episode {
 	var episode *Episode
 	cacheStore := cache.NewDBStore()
-	key := fmt.Sprintf("com.tmdb.episode.%d.%d.%d.%s", showID, seasonNumber, episodeNumber, language)
+	key := fmt.Sprintf("com.tmdb.episode.%d.%d.%d.%s", showID, seasonNumber, episodeNumber, LanguageKey(language))
 	if err := cacheStore.Get(key, &episode); err != nil {
 		err = MakeRequest(APIRequest{
 			URL: fmt.Sprintf("%s/tv/%d/season/%d/episode/%d", tmdbEndpoint, showID, seasonNumber, episodeNumber),
@@ -33,6 +32,7 @@ func GetEpisode(showID int, seasonNumber int, episodeNumber int, language string
 		})
 
 		if episode != nil {
+			episode.applyLanguageFallbacks(language)
 			cacheStore.Set(key, episode, cacheExpiration)
 		}
 	}
@@ -51,14 +51,13 @@ func (episodes EpisodeList) ToListItems(show *Show, season *Season) []*xbmc.List
 		fanarts = append(fanarts, ImageURL(backdrop.FilePath, "w1280"))
 	}
 
-	now := util.UTCBod()
+	timezone := show.Timezone()
 	for _, episode := range episodes {
 		if config.Get().ShowUnairedEpisodes == false {
 			if episode.AirDate == "" {
 				continue
 			}
-			firstAired, _ := time.Parse("2006-01-02", episode.AirDate)
-			if firstAired.After(now) || firstAired.Equal(now) {
+			if util.IsUnaired(util.AirTime(episode.AirDate, "", timezone)) {
 				continue
 			}
 		}
@@ -0,0 +1,168 @@
+package tmdb
+
+import (
+	"strings"
+
+	"github.com/bcrusher29/solaris/config"
+)
+
+// originalLanguage in fallbacks stands for the original language of a title
+const originalLanguage = "original"
+
+// LanguageChain returns languages, that metadata fields are taken from,
+// in order: requested language, configured fallbacks, English
+func LanguageChain(language string) []string {
+	chain := []string{language}
+	for _, l := range strings.Split(config.Get().LanguageFallbacks, ",") {
+		if l = strings.ToLower(strings.TrimSpace(l)); l != "" && !containsString(chain, l) {
+			chain = append(chain, l)
+		}
+	}
+	if !containsString(chain, "en") {
+		chain = append(chain, "en")
+	}
+	return chain
+}
+
+// LanguageKey is used in cache keys instead of a language,
+// so results with different fallbacks are cached separately
+func LanguageKey(language string) string {
+	return strings.Join(LanguageChain(language), "-")
+}
+
+// imageLanguages returns languages of images to request,
+// null is for images without text
+func imageLanguages(language string) string {
+	langs := []string{}
+	for _, l := range LanguageChain(language) {
+		if l != originalLanguage {
+			langs = append(langs, l)
+		}
+	}
+	return strings.Join(append(langs, "null"), ",")
+}
+
+// resolveChain replaces "original" in the chain with original language of a title
+func resolveChain(language, original string) []string {
+	chain := LanguageChain(language)
+	for i, l := range chain {
+		if l == originalLanguage {
+			chain[i] = original
+		}
+	}
+	return chain
+}
+
+// translate returns a field of the first translation in chain order, that has it filled
+func translate(translations []*Translation, chain []string, field func(*TranslationData) string) string {
+	for _, l := range chain {
+		for _, t := range translations {
+			if t == nil || t.Data == nil || t.Iso639_1 != l {
+				continue
+			}
+			if v := strings.TrimSpace(field(t.Data)); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// bestPoster returns a poster in the first language of chain, that has one
+func bestPoster(images *Images, chain []string) string {
+	if images == nil {
+		return ""
+	}
+	for _, l := range chain {
+		for _, p := range images.Posters {
+			if p != nil && p.Iso639_1 == l {
+				return p.FilePath
+			}
+		}
+	}
+	return ""
+}
+
+func (movie *Movie) applyLanguageFallbacks(language string) {
+	if movie.Translations == nil {
+		return
+	}
+
+	chain := resolveChain(language, movie.OriginalLanguage)
+	translations := movie.Translations.Translations
+	if v := translate(translations, chain, func(d *TranslationData) string { return d.Title }); v != "" {
+		movie.Title = v
+	} else if containsString(chain, movie.OriginalLanguage) && movie.OriginalTitle != "" {
+		movie.Title = movie.OriginalTitle
+	}
+	if v := translate(translations, chain, func(d *TranslationData) string { return d.Overview }); v != "" {
+		movie.Overview = v
+	}
+	if v := translate(translations, chain, func(d *TranslationData) string { return d.Tagline }); v != "" {
+		movie.TagLine = v
+	}
+	if poster := bestPoster(movie.Images, chain); poster != "" {
+		movie.PosterPath = poster
+	}
+}
+
+func (show *Show) applyLanguageFallbacks(language string) {
+	if show.Translations == nil {
+		return
+	}
+
+	chain := resolveChain(language, show.OriginalLanguage)
+	translations := show.Translations.Translations
+	if v := translate(translations, chain, func(d *TranslationData) string { return d.Name }); v != "" {
+		show.Name = v
+	} else if containsString(chain, show.OriginalLanguage) && show.OriginalName != "" {
+		show.Name = show.OriginalName
+	}
+	if v := translate(translations, chain, func(d *TranslationData) string { return d.Overview }); v != "" {
+		show.Overview = v
+	}
+	if poster := bestPoster(show.Images, chain); poster != "" {
+		show.PosterPath = poster
+	}
+}
+
+// Seasons and episodes keep translated names, since fallback names are
+// often generic, like "Season 1", and only empty ones are replaced
+func (season *Season) applyLanguageFallbacks(language string) {
+	if season.Translations == nil {
+		return
+	}
+
+	chain := LanguageChain(language)
+	translations := season.Translations.Translations
+	if season.Name == "" {
+		season.Name = translate(translations, chain, func(d *TranslationData) string { return d.Name })
+	}
+	if poster := bestPoster(season.Images, chain); poster != "" {
+		season.Poster = poster
+	}
+}
+
+func (episode *Episode) applyLanguageFallbacks(language string) {
+	if episode.Translations == nil {
+		return
+	}
+
+	chain := LanguageChain(language)
+	translations := episode.Translations.Translations
+	if episode.Name == "" {
+		episode.Name = translate(translations, chain, func(d *TranslationData) string { return d.Name })
+	}
+	if episode.Overview == "" {
+		episode.Overview = translate(translations, chain, func(d *TranslationData) string { return d.Overview })
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package tmdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bcrusher29/solaris/config"
+)
+
+func TestLanguageChain(t *testing.T) {
+	fallbacks := config.Get().LanguageFallbacks
+	defer func() { config.Get().LanguageFallbacks = fallbacks }()
+
+	tests := []struct {
+		language  string
+		fallbacks string
+		chain     []string
+	}{
+		{"de", "", []string{"de", "en"}},
+		{"en", "", []string{"en"}},
+		{"pt", "es, original", []string{"pt", "es", "original", "en"}},
+		{"pt", " ES ,, pt,en ", []string{"pt", "es", "en"}},
+		{"ru", "en,uk", []string{"ru", "en", "uk"}},
+	}
+
+	for _, test := range tests {
+		config.Get().LanguageFallbacks = test.fallbacks
+		if chain := LanguageChain(test.language); !reflect.DeepEqual(chain, test.chain) {
+			t.Errorf("LanguageChain(%q) with fallbacks %q = %v, expected %v", test.language, test.fallbacks, chain, test.chain)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	translations := []*Translation{
+		nil,
+		{Iso639_1: "fr"},
+		{Iso639_1: "de", Data: &TranslationData{Title: "Der Titel", Overview: "  "}},
+		{Iso639_1: "es", Data: &TranslationData{Title: "El título", Overview: "Resumen"}},
+		{Iso639_1: "en", Data: &TranslationData{Title: " The title ", Overview: "Overview"}},
+	}
+	title := func(d *TranslationData) string { return d.Title }
+	overview := func(d *TranslationData) string { return d.Overview }
+
+	tests := []struct {
+		chain    []string
+		field    func(*TranslationData) string
+		expected string
+	}{
+		{[]string{"de", "en"}, title, "Der Titel"},
+		{[]string{"de", "en"}, overview, "Overview"},
+		{[]string{"de", "es", "en"}, overview, "Resumen"},
+		{[]string{"fr", "en"}, title, "The title"},
+		{[]string{"fr", "it"}, title, ""},
+		{nil, title, ""},
+	}
+
+	for _, test := range tests {
+		if v := translate(translations, test.chain, test.field); v != test.expected {
+			t.Errorf("translate with chain %v = %q, expected %q", test.chain, v, test.expected)
+		}
+	}
+}
@@ -36,7 +36,7 @@ func GetImages(movieID int) *Images {
 			URL: fmt.Sprintf("%s/movie/%d/images", tmdbEndpoint, movieID),
 			Params: napping.Params{
 				"api_key":                apiKey,
-				"include_image_language": fmt.Sprintf("%s,en,null", config.Get().Language),
+				"include_image_language": imageLanguages(config.Get().Language),
 			}.AsUrlValues(),
 			Result:      &images,
 			Description: "movie images",
@@ -58,20 +58,22 @@ func GetMovie(tmdbID int, language string) *Movie {
 func GetMovieByID(movieID string, language string) *Movie {
 	var movie *Movie
 	cacheStore := cache.NewDBStore()
-	key := fmt.Sprintf("com.tmdb.movie.%s.%s", movieID, language)
+	key := fmt.Sprintf("com.tmdb.movie.%s.%s", movieID, LanguageKey(language))
 	if err := cacheStore.Get(key, &movie); err != nil {
 		err = MakeRequest(APIRequest{
 			URL: fmt.Sprintf("%s/movie/%s", tmdbEndpoint, movieID),
 			Params: napping.Params{
-				"api_key":            apiKey,
-				"append_to_response": "credits,images,alternative_titles,translations,external_ids,trailers,release_dates",
-				"language":           language,
+				"api_key":                apiKey,
+				"append_to_response":     "credits,images,alternative_titles,translations,external_ids,trailers,release_dates",
+				"include_image_language": imageLanguages(language),
+				"language":               language,
 			}.AsUrlValues(),
 			Result:      &movie,
 			Description: "movie",
 		})
 
 		if movie != nil {
+			movie.applyLanguageFallbacks(language)
 			cacheStore.Set(key, movie, cacheExpiration)
 		}
 	}
@@ -465,6 +467,10 @@ func (movie *Movie) ToListItem() *xbmc.ListItem {
 	}
 	item.Info.Genre = strings.Join(genres, " / ")
 
+	if movie.Collection != nil {
+		item.Info.Set = movie.Collection.Name
+	}
+
 	if movie.Trailers != nil {
 		for _, trailer := range movie.Trailers.Youtube {
 			item.Info.Trailer = util.TrailerURL(trailer.Source)
@@ -258,6 +258,134 @@ func (z *Cast) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *Collection) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "ID"
+	o = append(o, 0x86, 0xa2, 0x49, 0x44)
+	o = msgp.AppendInt(o, z.ID)
+	// string "Name"
+	o = append(o, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "Overview"
+	o = append(o, 0xa8, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77)
+	o = msgp.AppendString(o, z.Overview)
+	// string "PosterPath"
+	o = append(o, 0xaa, 0x50, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x68)
+	o = msgp.AppendString(o, z.PosterPath)
+	// string "BackdropPath"
+	o = append(o, 0xac, 0x42, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x6f, 0x70, 0x50, 0x61, 0x74, 0x68)
+	o = msgp.AppendString(o, z.BackdropPath)
+	// string "Parts"
+	o = append(o, 0xa5, 0x50, 0x61, 0x72, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Parts)))
+	for za0001 := range z.Parts {
+		if z.Parts[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Parts[za0001].MarshalMsg(o)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Collection) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Overview":
+			z.Overview, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "PosterPath":
+			z.PosterPath, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "BackdropPath":
+			z.BackdropPath, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Parts":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Parts) >= int(zb0002) {
+				z.Parts = (z.Parts)[:zb0002]
+			} else {
+				z.Parts = make([]*Entity, zb0002)
+			}
+			for za0001 := range z.Parts {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Parts[za0001] = nil
+				} else {
+					if z.Parts[za0001] == nil {
+						z.Parts[za0001] = new(Entity)
+					}
+					bts, err = z.Parts[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Collection) Msgsize() (s int) {
+	s = 1 + 3 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Name) + 9 + msgp.StringPrefixSize + len(z.Overview) + 11 + msgp.StringPrefixSize + len(z.PosterPath) + 13 + msgp.StringPrefixSize + len(z.BackdropPath) + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Parts {
+		if z.Parts[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Parts[za0001].Msgsize()
+		}
+	}
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z Country) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -655,11 +783,11 @@ func (z *Crew) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z DiscoverFilters) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DiscoverFilters) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
+	// map header, size 16
 	// string "Genre"
-	o = append(o, 0x83, 0xa5, 0x47, 0x65, 0x6e, 0x72, 0x65)
+	o = append(o, 0xde, 0x0, 0x10, 0xa5, 0x47, 0x65, 0x6e, 0x72, 0x65)
 	o = msgp.AppendString(o, z.Genre)
 	// string "Country"
 	o = append(o, 0xa7, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79)
@@ -667,6 +795,54 @@ func (z DiscoverFilters) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "Language"
 	o = append(o, 0xa8, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65)
 	o = msgp.AppendString(o, z.Language)
+	// string "Genres"
+	o = append(o, 0xa6, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Genres)))
+	for za0001 := range z.Genres {
+		o = msgp.AppendInt(o, z.Genres[za0001])
+	}
+	// string "GenresAny"
+	o = append(o, 0xa9, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x41, 0x6e, 0x79)
+	o = msgp.AppendBool(o, z.GenresAny)
+	// string "WithoutGenres"
+	o = append(o, 0xad, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.WithoutGenres)))
+	for za0002 := range z.WithoutGenres {
+		o = msgp.AppendInt(o, z.WithoutGenres[za0002])
+	}
+	// string "YearFrom"
+	o = append(o, 0xa8, 0x59, 0x65, 0x61, 0x72, 0x46, 0x72, 0x6f, 0x6d)
+	o = msgp.AppendInt(o, z.YearFrom)
+	// string "YearTo"
+	o = append(o, 0xa6, 0x59, 0x65, 0x61, 0x72, 0x54, 0x6f)
+	o = msgp.AppendInt(o, z.YearTo)
+	// string "VoteAverageMin"
+	o = append(o, 0xae, 0x56, 0x6f, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x4d, 0x69, 0x6e)
+	o = msgp.AppendFloat64(o, z.VoteAverageMin)
+	// string "VoteCountMin"
+	o = append(o, 0xac, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x6e)
+	o = msgp.AppendInt(o, z.VoteCountMin)
+	// string "RuntimeMin"
+	o = append(o, 0xaa, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6e)
+	o = msgp.AppendInt(o, z.RuntimeMin)
+	// string "RuntimeMax"
+	o = append(o, 0xaa, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x61, 0x78)
+	o = msgp.AppendInt(o, z.RuntimeMax)
+	// string "Certification"
+	o = append(o, 0xad, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Certification)
+	// string "Keywords"
+	o = append(o, 0xa8, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Keywords)))
+	for za0003 := range z.Keywords {
+		o = msgp.AppendString(o, z.Keywords[za0003])
+	}
+	// string "ExcludeWatched"
+	o = append(o, 0xae, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.ExcludeWatched)
+	// string "SortBy"
+	o = append(o, 0xa6, 0x53, 0x6f, 0x72, 0x74, 0x42, 0x79)
+	o = msgp.AppendString(o, z.SortBy)
 	return
 }
 
@@ -701,6 +877,107 @@ func (z *DiscoverFilters) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if err != nil {
 				return
 			}
+		case "Genres":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Genres) >= int(zb0002) {
+				z.Genres = (z.Genres)[:zb0002]
+			} else {
+				z.Genres = make([]int, zb0002)
+			}
+			for za0001 := range z.Genres {
+				z.Genres[za0001], bts, err = msgp.ReadIntBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "GenresAny":
+			z.GenresAny, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				return
+			}
+		case "WithoutGenres":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.WithoutGenres) >= int(zb0003) {
+				z.WithoutGenres = (z.WithoutGenres)[:zb0003]
+			} else {
+				z.WithoutGenres = make([]int, zb0003)
+			}
+			for za0002 := range z.WithoutGenres {
+				z.WithoutGenres[za0002], bts, err = msgp.ReadIntBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "YearFrom":
+			z.YearFrom, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "YearTo":
+			z.YearTo, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "VoteAverageMin":
+			z.VoteAverageMin, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				return
+			}
+		case "VoteCountMin":
+			z.VoteCountMin, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "RuntimeMin":
+			z.RuntimeMin, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "RuntimeMax":
+			z.RuntimeMax, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Certification":
+			z.Certification, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Keywords":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Keywords) >= int(zb0004) {
+				z.Keywords = (z.Keywords)[:zb0004]
+			} else {
+				z.Keywords = make([]string, zb0004)
+			}
+			for za0003 := range z.Keywords {
+				z.Keywords[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "ExcludeWatched":
+			z.ExcludeWatched, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				return
+			}
+		case "SortBy":
+			z.SortBy, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -713,8 +990,12 @@ func (z *DiscoverFilters) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z DiscoverFilters) Msgsize() (s int) {
-	s = 1 + 6 + msgp.StringPrefixSize + len(z.Genre) + 8 + msgp.StringPrefixSize + len(z.Country) + 9 + msgp.StringPrefixSize + len(z.Language)
+func (z *DiscoverFilters) Msgsize() (s int) {
+	s = 3 + 6 + msgp.StringPrefixSize + len(z.Genre) + 8 + msgp.StringPrefixSize + len(z.Country) + 9 + msgp.StringPrefixSize + len(z.Language) + 7 + msgp.ArrayHeaderSize + (len(z.Genres) * (msgp.IntSize)) + 10 + msgp.BoolSize + 14 + msgp.ArrayHeaderSize + (len(z.WithoutGenres) * (msgp.IntSize)) + 9 + msgp.IntSize + 7 + msgp.IntSize + 15 + msgp.Float64Size + 13 + msgp.IntSize + 11 + msgp.IntSize + 11 + msgp.IntSize + 14 + msgp.StringPrefixSize + len(z.Certification) + 9 + msgp.ArrayHeaderSize
+	for za0003 := range z.Keywords {
+		s += msgp.StringPrefixSize + len(z.Keywords[za0003])
+	}
+	s += 15 + msgp.BoolSize + 7 + msgp.StringPrefixSize + len(z.SortBy)
 	return
 }
 
@@ -2812,9 +3093,9 @@ func (z *List) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *Movie) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 16
+	// map header, size 17
 	// string "Entity"
-	o = append(o, 0xde, 0x0, 0x10, 0xa6, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79)
+	o = append(o, 0xde, 0x0, 0x11, 0xa6, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79)
 	o, err = z.Entity.MarshalMsg(o)
 	if err != nil {
 		return
@@ -2988,6 +3269,16 @@ func (z *Movie) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "Collection"
+	o = append(o, 0xaa, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	if z.Collection == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Collection.MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -3468,6 +3759,22 @@ func (z *Movie) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "Collection":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Collection = nil
+			} else {
+				if z.Collection == nil {
+					z.Collection = new(Collection)
+				}
+				bts, err = z.Collection.UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -3567,6 +3874,12 @@ func (z *Movie) Msgsize() (s int) {
 			}
 		}
 	}
+	s += 11
+	if z.Collection == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Collection.Msgsize()
+	}
 	return
 }
 
@@ -3634,29 +3947,67 @@ func (z Movies) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *ReleaseDate) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *Person) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 5
-	// string "Certification"
-	o = append(o, 0x85, 0xad, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
-	o = msgp.AppendString(o, z.Certification)
-	// string "Iso639_1"
-	o = append(o, 0xa8, 0x49, 0x73, 0x6f, 0x36, 0x33, 0x39, 0x5f, 0x31)
-	o = msgp.AppendString(o, z.Iso639_1)
-	// string "Note"
-	o = append(o, 0xa4, 0x4e, 0x6f, 0x74, 0x65)
-	o = msgp.AppendString(o, z.Note)
-	// string "ReleaseDate"
-	o = append(o, 0xab, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65)
-	o = msgp.AppendString(o, z.ReleaseDate)
-	// string "Type"
-	o = append(o, 0xa4, 0x54, 0x79, 0x70, 0x65)
-	o = msgp.AppendInt(o, z.Type)
+	// map header, size 12
+	// string "ID"
+	o = append(o, 0x8c, 0xa2, 0x49, 0x44)
+	o = msgp.AppendInt(o, z.ID)
+	// string "Name"
+	o = append(o, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "Biography"
+	o = append(o, 0xa9, 0x42, 0x69, 0x6f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x79)
+	o = msgp.AppendString(o, z.Biography)
+	// string "Birthday"
+	o = append(o, 0xa8, 0x42, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x79)
+	o = msgp.AppendString(o, z.Birthday)
+	// string "Deathday"
+	o = append(o, 0xa8, 0x44, 0x65, 0x61, 0x74, 0x68, 0x64, 0x61, 0x79)
+	o = msgp.AppendString(o, z.Deathday)
+	// string "PlaceOfBirth"
+	o = append(o, 0xac, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x66, 0x42, 0x69, 0x72, 0x74, 0x68)
+	o = msgp.AppendString(o, z.PlaceOfBirth)
+	// string "ProfilePath"
+	o = append(o, 0xab, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68)
+	o = msgp.AppendString(o, z.ProfilePath)
+	// string "KnownForDepartment"
+	o = append(o, 0xb2, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x46, 0x6f, 0x72, 0x44, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74)
+	o = msgp.AppendString(o, z.KnownForDepartment)
+	// string "IMDBId"
+	o = append(o, 0xa6, 0x49, 0x4d, 0x44, 0x42, 0x49, 0x64)
+	o = msgp.AppendString(o, z.IMDBId)
+	// string "Popularity"
+	o = append(o, 0xaa, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79)
+	o = msgp.AppendFloat64(o, z.Popularity)
+	// string "KnownFor"
+	o = append(o, 0xa8, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x46, 0x6f, 0x72)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.KnownFor)))
+	for za0001 := range z.KnownFor {
+		if z.KnownFor[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.KnownFor[za0001].MarshalMsg(o)
+			if err != nil {
+				return
+			}
+		}
+	}
+	// string "CombinedCredits"
+	o = append(o, 0xaf, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x64, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x73)
+	if z.CombinedCredits == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.CombinedCredits.MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *ReleaseDate) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *Person) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -3671,14 +4022,554 @@ func (z *ReleaseDate) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Certification":
-			z.Certification, bts, err = msgp.ReadStringBytes(bts)
+		case "ID":
+			z.ID, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
 				return
 			}
-		case "Iso639_1":
-			z.Iso639_1, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Biography":
+			z.Biography, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Birthday":
+			z.Birthday, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Deathday":
+			z.Deathday, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "PlaceOfBirth":
+			z.PlaceOfBirth, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "ProfilePath":
+			z.ProfilePath, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "KnownForDepartment":
+			z.KnownForDepartment, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "IMDBId":
+			z.IMDBId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Popularity":
+			z.Popularity, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				return
+			}
+		case "KnownFor":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.KnownFor) >= int(zb0002) {
+				z.KnownFor = (z.KnownFor)[:zb0002]
+			} else {
+				z.KnownFor = make([]*Entity, zb0002)
+			}
+			for za0001 := range z.KnownFor {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.KnownFor[za0001] = nil
+				} else {
+					if z.KnownFor[za0001] == nil {
+						z.KnownFor[za0001] = new(Entity)
+					}
+					bts, err = z.KnownFor[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						return
+					}
+				}
+			}
+		case "CombinedCredits":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.CombinedCredits = nil
+			} else {
+				if z.CombinedCredits == nil {
+					z.CombinedCredits = new(PersonCredits)
+				}
+				bts, err = z.CombinedCredits.UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Person) Msgsize() (s int) {
+	s = 1 + 3 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Name) + 10 + msgp.StringPrefixSize + len(z.Biography) + 9 + msgp.StringPrefixSize + len(z.Birthday) + 9 + msgp.StringPrefixSize + len(z.Deathday) + 13 + msgp.StringPrefixSize + len(z.PlaceOfBirth) + 12 + msgp.StringPrefixSize + len(z.ProfilePath) + 19 + msgp.StringPrefixSize + len(z.KnownForDepartment) + 7 + msgp.StringPrefixSize + len(z.IMDBId) + 11 + msgp.Float64Size + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.KnownFor {
+		if z.KnownFor[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.KnownFor[za0001].Msgsize()
+		}
+	}
+	s += 16
+	if z.CombinedCredits == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.CombinedCredits.Msgsize()
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PersonCredit) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 11
+	// string "ID"
+	o = append(o, 0x8b, 0xa2, 0x49, 0x44)
+	o = msgp.AppendInt(o, z.ID)
+	// string "MediaType"
+	o = append(o, 0xa9, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65)
+	o = msgp.AppendString(o, z.MediaType)
+	// string "Title"
+	o = append(o, 0xa5, 0x54, 0x69, 0x74, 0x6c, 0x65)
+	o = msgp.AppendString(o, z.Title)
+	// string "Name"
+	o = append(o, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "Character"
+	o = append(o, 0xa9, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Character)
+	// string "Department"
+	o = append(o, 0xaa, 0x44, 0x65, 0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74)
+	o = msgp.AppendString(o, z.Department)
+	// string "Job"
+	o = append(o, 0xa3, 0x4a, 0x6f, 0x62)
+	o = msgp.AppendString(o, z.Job)
+	// string "ReleaseDate"
+	o = append(o, 0xab, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.ReleaseDate)
+	// string "FirstAirDate"
+	o = append(o, 0xac, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x69, 0x72, 0x44, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.FirstAirDate)
+	// string "VoteCount"
+	o = append(o, 0xa9, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt(o, z.VoteCount)
+	// string "Popularity"
+	o = append(o, 0xaa, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79)
+	o = msgp.AppendFloat64(o, z.Popularity)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PersonCredit) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "MediaType":
+			z.MediaType, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Title":
+			z.Title, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Character":
+			z.Character, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Department":
+			z.Department, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Job":
+			z.Job, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "ReleaseDate":
+			z.ReleaseDate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "FirstAirDate":
+			z.FirstAirDate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "VoteCount":
+			z.VoteCount, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Popularity":
+			z.Popularity, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PersonCredit) Msgsize() (s int) {
+	s = 1 + 3 + msgp.IntSize + 10 + msgp.StringPrefixSize + len(z.MediaType) + 6 + msgp.StringPrefixSize + len(z.Title) + 5 + msgp.StringPrefixSize + len(z.Name) + 10 + msgp.StringPrefixSize + len(z.Character) + 11 + msgp.StringPrefixSize + len(z.Department) + 4 + msgp.StringPrefixSize + len(z.Job) + 12 + msgp.StringPrefixSize + len(z.ReleaseDate) + 13 + msgp.StringPrefixSize + len(z.FirstAirDate) + 10 + msgp.IntSize + 11 + msgp.Float64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PersonCredits) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Cast"
+	o = append(o, 0x82, 0xa4, 0x43, 0x61, 0x73, 0x74)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Cast)))
+	for za0001 := range z.Cast {
+		if z.Cast[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Cast[za0001].MarshalMsg(o)
+			if err != nil {
+				return
+			}
+		}
+	}
+	// string "Crew"
+	o = append(o, 0xa4, 0x43, 0x72, 0x65, 0x77)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Crew)))
+	for za0002 := range z.Crew {
+		if z.Crew[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Crew[za0002].MarshalMsg(o)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PersonCredits) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Cast":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Cast) >= int(zb0002) {
+				z.Cast = (z.Cast)[:zb0002]
+			} else {
+				z.Cast = make([]*PersonCredit, zb0002)
+			}
+			for za0001 := range z.Cast {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Cast[za0001] = nil
+				} else {
+					if z.Cast[za0001] == nil {
+						z.Cast[za0001] = new(PersonCredit)
+					}
+					bts, err = z.Cast[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						return
+					}
+				}
+			}
+		case "Crew":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Crew) >= int(zb0003) {
+				z.Crew = (z.Crew)[:zb0003]
+			} else {
+				z.Crew = make([]*PersonCredit, zb0003)
+			}
+			for za0002 := range z.Crew {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Crew[za0002] = nil
+				} else {
+					if z.Crew[za0002] == nil {
+						z.Crew[za0002] = new(PersonCredit)
+					}
+					bts, err = z.Crew[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PersonCredits) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Cast {
+		if z.Cast[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Cast[za0001].Msgsize()
+		}
+	}
+	s += 5 + msgp.ArrayHeaderSize
+	for za0002 := range z.Crew {
+		if z.Crew[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Crew[za0002].Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PersonList) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Page"
+	o = append(o, 0x84, 0xa4, 0x50, 0x61, 0x67, 0x65)
+	o = msgp.AppendInt(o, z.Page)
+	// string "Results"
+	o = append(o, 0xa7, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Results)))
+	for za0001 := range z.Results {
+		if z.Results[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Results[za0001].MarshalMsg(o)
+			if err != nil {
+				return
+			}
+		}
+	}
+	// string "TotalPages"
+	o = append(o, 0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt(o, z.TotalPages)
+	// string "TotalResults"
+	o = append(o, 0xac, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73)
+	o = msgp.AppendInt(o, z.TotalResults)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PersonList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Page":
+			z.Page, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Results":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Results) >= int(zb0002) {
+				z.Results = (z.Results)[:zb0002]
+			} else {
+				z.Results = make([]*Person, zb0002)
+			}
+			for za0001 := range z.Results {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Results[za0001] = nil
+				} else {
+					if z.Results[za0001] == nil {
+						z.Results[za0001] = new(Person)
+					}
+					bts, err = z.Results[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						return
+					}
+				}
+			}
+		case "TotalPages":
+			z.TotalPages, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "TotalResults":
+			z.TotalResults, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PersonList) Msgsize() (s int) {
+	s = 1 + 5 + msgp.IntSize + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Results {
+		if z.Results[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Results[za0001].Msgsize()
+		}
+	}
+	s += 11 + msgp.IntSize + 13 + msgp.IntSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReleaseDate) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "Certification"
+	o = append(o, 0x85, 0xad, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Certification)
+	// string "Iso639_1"
+	o = append(o, 0xa8, 0x49, 0x73, 0x6f, 0x36, 0x33, 0x39, 0x5f, 0x31)
+	o = msgp.AppendString(o, z.Iso639_1)
+	// string "Note"
+	o = append(o, 0xa4, 0x4e, 0x6f, 0x74, 0x65)
+	o = msgp.AppendString(o, z.Note)
+	// string "ReleaseDate"
+	o = append(o, 0xab, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.ReleaseDate)
+	// string "Type"
+	o = append(o, 0xa4, 0x54, 0x79, 0x70, 0x65)
+	o = msgp.AppendInt(o, z.Type)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReleaseDate) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Certification":
+			z.Certification, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Iso639_1":
+			z.Iso639_1, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
 				return
 			}
 		case "Note":
@@ -4505,9 +5396,9 @@ func (z SeasonList) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *Show) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 23
+	// map header, size 25
 	// string "Entity"
-	o = append(o, 0xde, 0x0, 0x17, 0xa6, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79)
+	o = append(o, 0xde, 0x0, 0x19, 0xa6, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79)
 	o, err = z.Entity.MarshalMsg(o)
 	if err != nil {
 		return
@@ -4694,6 +5585,26 @@ func (z *Show) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "LastEpisodeToAir"
+	o = append(o, 0xb0, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x41, 0x69, 0x72)
+	if z.LastEpisodeToAir == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.LastEpisodeToAir.MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
+	// string "NextEpisodeToAir"
+	o = append(o, 0xb0, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x41, 0x69, 0x72)
+	if z.NextEpisodeToAir == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.NextEpisodeToAir.MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -5189,6 +6100,38 @@ func (z *Show) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "LastEpisodeToAir":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.LastEpisodeToAir = nil
+			} else {
+				if z.LastEpisodeToAir == nil {
+					z.LastEpisodeToAir = new(Episode)
+				}
+				bts, err = z.LastEpisodeToAir.UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "NextEpisodeToAir":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.NextEpisodeToAir = nil
+			} else {
+				if z.NextEpisodeToAir == nil {
+					z.NextEpisodeToAir = new(Episode)
+				}
+				bts, err = z.NextEpisodeToAir.UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -5282,6 +6225,18 @@ func (z *Show) Msgsize() (s int) {
 			s += z.Seasons[za0008].Msgsize()
 		}
 	}
+	s += 17
+	if z.LastEpisodeToAir == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.LastEpisodeToAir.Msgsize()
+	}
+	s += 17
+	if z.NextEpisodeToAir == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.NextEpisodeToAir.Msgsize()
+	}
 	return
 }
 
@@ -5526,9 +6481,9 @@ func (z *Translation) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *TranslationData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "Name"
-	o = append(o, 0x84, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = append(o, 0x85, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.Name)
 	// string "Title"
 	o = append(o, 0xa5, 0x54, 0x69, 0x74, 0x6c, 0x65)
@@ -5539,6 +6494,9 @@ func (z *TranslationData) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "Homepage"
 	o = append(o, 0xa8, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65)
 	o = msgp.AppendString(o, z.Homepage)
+	// string "Tagline"
+	o = append(o, 0xa7, 0x54, 0x61, 0x67, 0x6c, 0x69, 0x6e, 0x65)
+	o = msgp.AppendString(o, z.Tagline)
 	return
 }
 
@@ -5578,6 +6536,11 @@ func (z *TranslationData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if err != nil {
 				return
 			}
+		case "Tagline":
+			z.Tagline, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -5591,6 +6554,6 @@ func (z *TranslationData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *TranslationData) Msgsize() (s int) {
-	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 6 + msgp.StringPrefixSize + len(z.Title) + 9 + msgp.StringPrefixSize + len(z.Overview) + 9 + msgp.StringPrefixSize + len(z.Homepage)
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 6 + msgp.StringPrefixSize + len(z.Title) + 9 + msgp.StringPrefixSize + len(z.Overview) + 9 + msgp.StringPrefixSize + len(z.Homepage) + 8 + msgp.StringPrefixSize + len(z.Tagline)
 	return
 }
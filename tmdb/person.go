@@ -0,0 +1,131 @@
+package tmdb
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/xbmc"
+
+	"github.com/jmcvetta/napping"
+)
+
+// Media types of person credits
+const (
+	movieMediaType = "movie"
+	showMediaType  = "tv"
+)
+
+// SearchPeople ...
+func SearchPeople(query string, language string, page int) ([]*Person, int) {
+	var results PersonList
+	MakeRequest(APIRequest{
+		URL: fmt.Sprintf("%s/search/person", tmdbEndpoint),
+		Params: napping.Params{
+			"api_key":  apiKey,
+			"query":    query,
+			"language": language,
+			"page":     strconv.Itoa(page),
+		}.AsUrlValues(),
+		Result:      &results,
+		Description: "search person",
+	})
+
+	return results.Results, results.TotalResults
+}
+
+// GetPerson returns person details with combined movie and show credits
+func GetPerson(personID int, language string) *Person {
+	var person *Person
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.tmdb.person.%d.%s", personID, language)
+	if err := cacheStore.Get(key, &person); err != nil {
+		err = MakeRequest(APIRequest{
+			URL: fmt.Sprintf("%s/person/%d", tmdbEndpoint, personID),
+			Params: napping.Params{
+				"api_key":            apiKey,
+				"append_to_response": "combined_credits",
+				"language":           language,
+			}.AsUrlValues(),
+			Result:      &person,
+			Description: "person",
+		})
+
+		if person != nil {
+			cacheStore.Set(key, person, cacheExpiration)
+		}
+	}
+	return person
+}
+
+// MovieIDs returns TMDB IDs of movies, that person was in, newest first
+func (p *Person) MovieIDs() []int {
+	return p.creditIDs(movieMediaType)
+}
+
+// ShowIDs returns TMDB IDs of shows, that person was in, newest first
+func (p *Person) ShowIDs() []int {
+	return p.creditIDs(showMediaType)
+}
+
+func (p *Person) creditIDs(mediaType string) []int {
+	if p.CombinedCredits == nil {
+		return nil
+	}
+
+	credits := make([]*PersonCredit, 0, len(p.CombinedCredits.Cast)+len(p.CombinedCredits.Crew))
+	seen := map[int]bool{}
+	for _, c := range append(p.CombinedCredits.Cast, p.CombinedCredits.Crew...) {
+		if c.MediaType != mediaType || seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		credits = append(credits, c)
+	}
+
+	// Unreleased titles have no date and go last
+	sort.SliceStable(credits, func(i, j int) bool {
+		a, b := credits[i].date(), credits[j].date()
+		if a == "" || b == "" {
+			return a != ""
+		}
+		return a > b
+	})
+
+	ids := make([]int, 0, len(credits))
+	for _, c := range credits {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func (c *PersonCredit) date() string {
+	if c.MediaType == showMediaType {
+		return c.FirstAirDate
+	}
+	return c.ReleaseDate
+}
+
+// ToListItem ...
+func (p *Person) ToListItem() *xbmc.ListItem {
+	item := &xbmc.ListItem{
+		Label:  p.Name,
+		Label2: p.KnownForDepartment,
+		Info: &xbmc.ListItemInfo{
+			Title: p.Name,
+			Plot:  p.Biography,
+		},
+	}
+
+	if p.ProfilePath != "" {
+		item.Thumbnail = ImageURL(p.ProfilePath, "w500")
+		item.Icon = item.Thumbnail
+		item.Art = &xbmc.ListItemArt{
+			Poster:    item.Thumbnail,
+			Thumbnail: item.Thumbnail,
+		}
+	}
+
+	return item
+}
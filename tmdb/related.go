@@ -0,0 +1,50 @@
+package tmdb
+
+import (
+	"fmt"
+
+	"github.com/bcrusher29/solaris/cache"
+
+	"github.com/jmcvetta/napping"
+)
+
+// Kinds of related titles
+const (
+	RelatedSimilar         = "similar"
+	RelatedRecommendations = "recommendations"
+)
+
+// GetRelatedMovies returns the first page of similar or recommended movies
+func GetRelatedMovies(tmdbID int, kind string, language string) []*Entity {
+	return getRelated("movie", tmdbID, kind, language)
+}
+
+// GetRelatedShows returns the first page of similar or recommended shows
+func GetRelatedShows(tmdbID int, kind string, language string) []*Entity {
+	return getRelated("tv", tmdbID, kind, language)
+}
+
+func getRelated(mediaType string, tmdbID int, kind string, language string) []*Entity {
+	var results *EntityList
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.tmdb.%s.%d.%s.%s", mediaType, tmdbID, kind, language)
+	if err := cacheStore.Get(key, &results); err != nil {
+		err = MakeRequest(APIRequest{
+			URL: fmt.Sprintf("%s/%s/%d/%s", tmdbEndpoint, mediaType, tmdbID, kind),
+			Params: napping.Params{
+				"api_key":  apiKey,
+				"language": language,
+			}.AsUrlValues(),
+			Result:      &results,
+			Description: fmt.Sprintf("%s %s", mediaType, kind),
+		})
+
+		if results != nil {
+			cacheStore.Set(key, results, cacheExpiration)
+		}
+	}
+	if results == nil {
+		return nil
+	}
+	return results.Results
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/fanart"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/util"
 	"github.com/bcrusher29/solaris/xbmc"
@@ -18,20 +19,21 @@ import (
 func GetSeason(showID int, seasonNumber int, language string) *Season {
 	var season *Season
 	cacheStore := cache.NewDBStore()
-	key := fmt.Sprintf("com.tmdb.season.%d.%d.%s", showID, seasonNumber, language)
+	key := fmt.Sprintf("com.tmdb.season.%d.%d.%s", showID, seasonNumber, LanguageKey(language))
 	if err := cacheStore.Get(key, &season); err != nil {
 		err = MakeRequest(APIRequest{
 			URL: fmt.Sprintf("%s/tv/%d/season/%d", tmdbEndpoint, showID, seasonNumber),
 			Params: napping.Params{
-				"api_key":            apiKey,
-				"append_to_response": "credits,images,videos,external_ids,alternative_titles,translations,trailers",
-				"language":           language,
+				"api_key":                apiKey,
+				"append_to_response":     "credits,images,videos,external_ids,alternative_titles,translations,trailers",
+				"include_image_language": imageLanguages(language),
+				"language":               language,
 			}.AsUrlValues(),
 			Result:      &season,
 			Description: "season",
 		})
 
-		if season == nil && err != nil && err == util.ErrNotFound {
+		if season == nil && httpclient.IsNotFound(err) {
 			cacheStore.Set(key, season, cacheHalfExpiration)
 		}
 		if season == nil {
@@ -39,16 +41,21 @@ func GetSeason(showID int, seasonNumber int, language string) *Season {
 		}
 
 		season.EpisodeCount = len(season.Episodes)
+		season.applyLanguageFallbacks(language)
 
 		// Fix for shows that have translations but return empty strings
 		// for episode names and overviews.
 		// We detect if episodes have their name filled, and if not re-query
 		// with no language set.
 		// See https://github.com/scakemyer/plugin.video.quasar/issues/249
+		// Untranslated overviews alone are common, re-querying them would
+		// be a request for each episode, so they stay empty.
 		if season.EpisodeCount > 0 {
 			for index := 0; index < season.EpisodeCount && index < len(season.Episodes); index++ {
 				if season.Episodes[index] != nil && season.Episodes[index].Name == "" {
-					season.Episodes[index] = GetEpisode(showID, seasonNumber, index+1, language)
+					if episode := GetEpisode(showID, seasonNumber, index+1, language); episode != nil {
+						season.Episodes[index] = episode
+					}
 				}
 			}
 		}
@@ -76,14 +83,13 @@ func (seasons SeasonList) ToListItems(show *Show) []*xbmc.ListItem {
 		fanarts = append(fanarts, ImageURL(backdrop.FilePath, "w1280"))
 	}
 
-	now := util.UTCBod()
+	timezone := show.Timezone()
 	for _, season := range seasons {
 		if season.EpisodeCount == 0 {
 			continue
 		}
 		if config.Get().ShowUnairedSeasons == false {
-			firstAired, _ := time.Parse("2006-01-02", season.AirDate)
-			if firstAired.After(now) || firstAired.Equal(now) {
+			if util.IsUnaired(util.AirTime(season.AirDate, "", timezone)) {
 				continue
 			}
 		}
@@ -127,7 +133,7 @@ func (season *Season) ToListItem(show *Show) *xbmc.ListItem {
 			Mediatype:     "season",
 			Code:          show.ExternalIDs.IMDBId,
 			IMDBNumber:    show.ExternalIDs.IMDBId,
-			PlayCount:     playcount.GetWatchedSeasonByTMDB(show.ID, season.Season).Int(),
+			PlayCount:     playcount.GetWatchedSeasonByTMDB(show.ID, season.Season, season.EpisodeCount).Int(),
 		},
 		Art: &xbmc.ListItemArt{},
 	}
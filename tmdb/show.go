@@ -13,6 +13,7 @@ import (
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/fanart"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/util"
 	"github.com/bcrusher29/solaris/xbmc"
@@ -37,7 +38,7 @@ func GetShowImages(showID int) *Images {
 			URL: fmt.Sprintf("%s/tv/%d/images", tmdbEndpoint, showID),
 			Params: napping.Params{
 				"api_key":                apiKey,
-				"include_image_language": fmt.Sprintf("%s,en,null", config.Get().Language),
+				"include_image_language": imageLanguages(config.Get().Language),
 			}.AsUrlValues(),
 			Result:      &images,
 			Description: "show images",
@@ -60,7 +61,7 @@ func GetSeasonImages(showID int, season int) *Images {
 			URL: fmt.Sprintf("%s/tv/%d/season/%d/images", tmdbEndpoint, showID, season),
 			Params: napping.Params{
 				"api_key":                apiKey,
-				"include_image_language": fmt.Sprintf("%s,en,null", config.Get().Language),
+				"include_image_language": imageLanguages(config.Get().Language),
 			}.AsUrlValues(),
 			Result:      &images,
 			Description: "season images",
@@ -83,7 +84,7 @@ func GetEpisodeImages(showID, season, episode int) *Images {
 			URL: fmt.Sprintf("%s/tv/%d/season/%d/episode/%d/images", tmdbEndpoint, showID, season, episode),
 			Params: napping.Params{
 				"api_key":                apiKey,
-				"include_image_language": fmt.Sprintf("%s,en,null", config.Get().Language),
+				"include_image_language": imageLanguages(config.Get().Language),
 			}.AsUrlValues(),
 			Result:      &images,
 			Description: "season images",
@@ -108,26 +109,28 @@ func GetShow(showID int, language string) (show *Show) {
 		return
 	}
 	cacheStore := cache.NewDBStore()
-	key := fmt.Sprintf("com.tmdb.show.%d.%s", showID, language)
+	key := fmt.Sprintf("com.tmdb.show.%d.%s", showID, LanguageKey(language))
 	if err := cacheStore.Get(key, &show); err != nil {
 		err = MakeRequest(APIRequest{
 			URL: fmt.Sprintf("%s/tv/%d", tmdbEndpoint, showID),
 			Params: napping.Params{
-				"api_key":            apiKey,
-				"append_to_response": "credits,images,alternative_titles,translations,external_ids",
-				"language":           language,
+				"api_key":                apiKey,
+				"append_to_response":     "credits,images,alternative_titles,translations,external_ids",
+				"include_image_language": imageLanguages(language),
+				"language":               language,
 			}.AsUrlValues(),
 			Result:      &show,
 			Description: "show",
 		})
 
-		if show == nil && err != nil && err == util.ErrNotFound {
+		if show == nil && httpclient.IsNotFound(err) {
 			cacheStore.Set(key, show, cacheHalfExpiration)
 		}
 		if show == nil {
 			return nil
 		}
 
+		show.applyLanguageFallbacks(language)
 		cacheStore.Set(key, show, cacheExpiration)
 	}
 	if show == nil {
@@ -439,6 +442,28 @@ func GetTVGenres(language string) []*Genre {
 	return genres.Genres
 }
 
+// Timezone returns the zone show airs in, guessed by its network and country
+func (show *Show) Timezone() string {
+	if show == nil {
+		return ""
+	}
+
+	for _, network := range show.Networks {
+		if network == nil {
+			continue
+		}
+		if tz := util.ShowTimezone(network.Name, ""); tz != "" {
+			return tz
+		}
+	}
+	for _, country := range show.OriginCountry {
+		if tz := util.ShowTimezone("", country); tz != "" {
+			return tz
+		}
+	}
+	return ""
+}
+
 // IsAnime ...
 func (show *Show) IsAnime() bool {
 	if show == nil || show.OriginCountry == nil || show.Genres == nil {
@@ -452,15 +477,22 @@ func (show *Show) IsAnime() bool {
 			break
 		}
 	}
-	genreIsAnim := false
+
+	return countryIsJP && show.IsAnimation()
+}
+
+// IsAnimation ...
+func (show *Show) IsAnimation() bool {
+	if show == nil || show.Genres == nil {
+		return false
+	}
+
 	for _, genre := range show.Genres {
 		if genre.ID == 16 {
-			genreIsAnim = true
-			break
+			return true
 		}
 	}
-
-	return countryIsJP && genreIsAnim
+	return false
 }
 
 // ToListItem ...
@@ -488,7 +520,7 @@ func (show *Show) ToListItem() *xbmc.ListItem {
 			Rating:        show.VoteAverage,
 			TVShowTitle:   show.OriginalName,
 			Premiered:     show.FirstAirDate,
-			PlayCount:     playcount.GetWatchedShowByTMDB(show.ID).Int(),
+			PlayCount:     playcount.GetWatchedShowByTMDB(show.ID, show.NumberOfEpisodes).Int(),
 			DBTYPE:        "tvshow",
 			Mediatype:     "tvshow",
 		},
@@ -9,6 +9,7 @@ import (
 
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/util"
 	"github.com/bcrusher29/solaris/xbmc"
 	"github.com/jmcvetta/napping"
@@ -68,6 +69,8 @@ type Movie struct {
 	Images  *Images  `json:"images,omitempty"`
 
 	ReleaseDates *ReleaseDatesResults `json:"release_dates"`
+
+	Collection *Collection `json:"belongs_to_collection"`
 }
 
 // Show ...
@@ -103,6 +106,9 @@ type Show struct {
 	Images  *Images  `json:"images,omitempty"`
 
 	Seasons SeasonList `json:"seasons"`
+
+	LastEpisodeToAir *Episode `json:"last_episode_to_air"`
+	NextEpisodeToAir *Episode `json:"next_episode_to_air"`
 }
 
 // Season ...
@@ -291,6 +297,7 @@ type TranslationData struct {
 	Title    string `json:"title"`
 	Overview string `json:"overview"`
 	Homepage string `json:"homepage"`
+	Tagline  string `json:"tagline"`
 }
 
 // FindResult ...
@@ -315,6 +322,61 @@ type List struct {
 	Items         []*Entity `json:"items"`
 }
 
+// Collection ...
+type Collection struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview,omitempty"`
+	PosterPath   string    `json:"poster_path"`
+	BackdropPath string    `json:"backdrop_path"`
+	Parts        []*Entity `json:"parts,omitempty"`
+}
+
+// Person ...
+type Person struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	Biography          string         `json:"biography,omitempty"`
+	Birthday           string         `json:"birthday,omitempty"`
+	Deathday           string         `json:"deathday,omitempty"`
+	PlaceOfBirth       string         `json:"place_of_birth,omitempty"`
+	ProfilePath        string         `json:"profile_path"`
+	KnownForDepartment string         `json:"known_for_department"`
+	IMDBId             string         `json:"imdb_id,omitempty"`
+	Popularity         float64        `json:"popularity"`
+	KnownFor           []*Entity      `json:"known_for,omitempty"`
+	CombinedCredits    *PersonCredits `json:"combined_credits,omitempty"`
+}
+
+// PersonList ...
+type PersonList struct {
+	Page         int       `json:"page"`
+	Results      []*Person `json:"results"`
+	TotalPages   int       `json:"total_pages"`
+	TotalResults int       `json:"total_results"`
+}
+
+// PersonCredits ...
+type PersonCredits struct {
+	Cast []*PersonCredit `json:"cast"`
+	Crew []*PersonCredit `json:"crew"`
+}
+
+// PersonCredit is a movie or a show, that person was in
+type PersonCredit struct {
+	ID           int     `json:"id"`
+	MediaType    string  `json:"media_type"`
+	Title        string  `json:"title,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Character    string  `json:"character,omitempty"`
+	Department   string  `json:"department,omitempty"`
+	Job          string  `json:"job,omitempty"`
+	ReleaseDate  string  `json:"release_date,omitempty"`
+	FirstAirDate string  `json:"first_air_date,omitempty"`
+	VoteCount    int     `json:"vote_count"`
+	Popularity   float64 `json:"popularity"`
+}
+
 // Trailer ...
 type Trailer struct {
 	Name   string `json:"name"`
@@ -345,9 +407,24 @@ type ReleaseDate struct {
 
 // DiscoverFilters ...
 type DiscoverFilters struct {
-	Genre    string
-	Country  string
-	Language string
+	Genre    string `json:"genre,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Language string `json:"language,omitempty"`
+
+	// Genres are matched all together, or any of them with GenresAny
+	Genres         []int    `json:"genres,omitempty"`
+	GenresAny      bool     `json:"genres_any,omitempty"`
+	WithoutGenres  []int    `json:"without_genres,omitempty"`
+	YearFrom       int      `json:"year_from,omitempty"`
+	YearTo         int      `json:"year_to,omitempty"`
+	VoteAverageMin float64  `json:"vote_average_min,omitempty"`
+	VoteCountMin   int      `json:"vote_count_min,omitempty"`
+	RuntimeMin     int      `json:"runtime_min,omitempty"`
+	RuntimeMax     int      `json:"runtime_max,omitempty"`
+	Certification  string   `json:"certification,omitempty"`
+	Keywords       []string `json:"keywords,omitempty"`
+	ExcludeWatched bool     `json:"exclude_watched,omitempty"`
+	SortBy         string   `json:"sort_by,omitempty"`
 }
 
 // APIRequest ...
@@ -384,7 +461,12 @@ var (
 	WarmingUp = util.Event{}
 )
 
-var rl = util.NewRateLimiter(burstRate, burstTime, simultaneousConnections)
+var client = httpclient.New(httpclient.Config{
+	Name:         "tmdb",
+	RateLimit:    burstRate,
+	RateInterval: burstTime,
+	Parallel:     simultaneousConnections,
+})
 
 // CheckAPIKey ...
 func CheckAPIKey() {
@@ -427,18 +509,12 @@ func tmdbCheck(key string) bool {
 		"api_key": key,
 	}.AsUrlValues()
 
-	resp, err := napping.Get(
-		tmdbEndpoint+"/movie/550",
-		&urlValues,
-		&result,
-		nil,
-	)
-
-	if err != nil {
+	resp, err := client.Get(httpclient.Background(), tmdbEndpoint+"/movie/550", urlValues, &result)
+	if resp == nil {
 		log.Error(err.Error())
 		xbmc.Notify("Elementum", "TMDB check failed, check your logs.", config.AddonIcon())
 		return false
-	} else if resp.Status() != 200 {
+	} else if err != nil {
 		return false
 	}
 
@@ -579,36 +655,23 @@ func GetLanguages(language string) []*Language {
 }
 
 // MakeRequest used to proxy requests with proper RateLimiter usage and HTTP error processing
-func MakeRequest(r APIRequest) (ret error) {
-	rl.Call(func() error {
-		resp, err := napping.Get(
-			r.URL,
-			&r.Params,
-			r.Result,
-			r.ErrMsg,
-		)
-		if err != nil {
-			log.Errorf("Failed to make request to %s for %s with %+v: %s", r.URL, r.Description, r.Params, err)
-			ret = err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting %s with %+v on %s, cooling down...", r.Description, r.Params, r.URL)
-			rl.CoolDown(resp.HttpResponse().Header)
-			ret = util.ErrExceeded
-			return util.ErrExceeded
-		} else if resp.Status() == 404 {
-			log.Warningf("Rate limit exceeded getting %s with %+v on %s, cooling down...", r.Description, r.Params, r.URL)
-			rl.CoolDown(resp.HttpResponse().Header)
-			ret = util.ErrNotFound
-			return util.ErrNotFound
-		} else if resp.Status() != 200 {
-			log.Errorf("Bad status getting %s with %+v on %s: %d", r.Description, r.Params, r.URL, resp.Status())
-			ret = util.ErrHTTP
-			return util.ErrHTTP
-		}
-
-		ret = nil
+func MakeRequest(r APIRequest) error {
+	resp, err := client.Get(httpclient.Background(), r.URL, r.Params, r.Result)
+	if err == nil {
 		return nil
-	})
+	}
+
+	if r.ErrMsg != nil && resp != nil {
+		resp.Unmarshal(r.ErrMsg)
+	}
+
+	if httpclient.IsNotFound(err) {
+		log.Debugf("Not found %s with %+v on %s", r.Description, r.Params, r.URL)
+	} else if resp != nil {
+		log.Errorf("Bad status getting %s with %+v on %s: %d", r.Description, r.Params, r.URL, resp.Status())
+	} else {
+		log.Errorf("Failed to make request to %s for %s with %+v: %s", r.URL, r.Description, r.Params, err)
+	}
 
-	return
+	return err
 }
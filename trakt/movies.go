@@ -11,6 +11,7 @@ import (
 
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/tmdb"
 	"github.com/bcrusher29/solaris/util"
@@ -209,7 +210,7 @@ func TopMovies(topCategory string, page string) (movies []*Movies, total int, er
 	key := fmt.Sprintf("com.trakt.movies.%s.%s", topCategory, page)
 	totalKey := fmt.Sprintf("com.trakt.movies.%s.total", topCategory)
 	if err := cacheStore.Get(key, &movies); err != nil || len(movies) == 0 {
-		var resp *napping.Response
+		var resp *httpclient.Response
 		var err error
 
 		if config.Get().TraktToken == "" {
@@ -244,7 +245,7 @@ func TopMovies(topCategory string, page string) (movies []*Movies, total int, er
 			}
 		}
 
-		pagination := getPagination(resp.HttpResponse().Header)
+		pagination := getPagination(resp.Header())
 		total = pagination.ItemCount
 		if err != nil {
 			log.Warning(err)
@@ -357,7 +358,7 @@ func Userlists() (lists []*List) {
 
 	params := napping.Params{}.AsUrlValues()
 
-	var resp *napping.Response
+	var resp *httpclient.Response
 	var err error
 
 	if config.Get().TraktToken == "" {
@@ -399,7 +400,7 @@ func Likedlists() (lists []*List) {
 	endPoint := "users/likes"
 	params := napping.Params{}.AsUrlValues()
 
-	var resp *napping.Response
+	var resp *httpclient.Response
 	var err error
 
 	if config.Get().TraktToken == "" {
@@ -446,7 +447,7 @@ func TopLists(page string) (lists []*ListContainer, hasNext bool) {
 		"limit": strconv.Itoa(config.Get().ResultsPerPage),
 	}.AsUrlValues()
 
-	var resp *napping.Response
+	var resp *httpclient.Response
 	var err error
 
 	if config.Get().TraktToken == "" {
@@ -475,7 +476,7 @@ func TopLists(page string) (lists []*ListContainer, hasNext bool) {
 		return lists[i].List.Name < lists[j].List.Name
 	})
 
-	p := getPagination(resp.HttpResponse().Header)
+	p := getPagination(resp.Header())
 	hasNext = p.PageCount > pageInt
 
 	return lists, hasNext
@@ -491,7 +492,7 @@ func ListItemsMovies(user string, listID string) (movies []*Movies, err error) {
 
 	params := napping.Params{}.AsUrlValues()
 
-	var resp *napping.Response
+	var resp *httpclient.Response
 
 	cacheStore := cache.NewDBStore()
 	key := fmt.Sprintf("com.trakt.movies.list.%s", listID)
@@ -563,7 +564,7 @@ func CalendarMovies(endPoint string, page string) (movies []*CalendarMovie, tota
 			log.Warning(errUnm)
 		}
 
-		pagination := getPagination(resp.HttpResponse().Header)
+		pagination := getPagination(resp.Header())
 		total = pagination.ItemCount
 		if err != nil {
 			total = -1
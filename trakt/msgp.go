@@ -730,9 +730,9 @@ func (z *CollectionShow) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *Episode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 11
+	// map header, size 12
 	// string "Number"
-	o = append(o, 0x8b, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
+	o = append(o, 0x8c, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
 	o = msgp.AppendInt(o, z.Number)
 	// string "Season"
 	o = append(o, 0xa6, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e)
@@ -781,6 +781,9 @@ func (z *Episode) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "Runtime"
+	o = append(o, 0xa7, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65)
+	o = msgp.AppendInt(o, z.Runtime)
 	return
 }
 
@@ -889,6 +892,11 @@ func (z *Episode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "Runtime":
+			z.Runtime, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -902,7 +910,7 @@ func (z *Episode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *Episode) Msgsize() (s int) {
-	s = 1 + 7 + msgp.IntSize + 7 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 9 + msgp.StringPrefixSize + len(z.Overview) + 9 + msgp.IntSize + 11 + msgp.StringPrefixSize + len(z.FirstAired) + 13 + msgp.ArrayHeaderSize
+	s = 1 + 7 + msgp.IntSize + 7 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 9 + msgp.StringPrefixSize + len(z.Overview) + 9 + msgp.IntSize + 11 + msgp.StringPrefixSize + len(z.FirstAired) + 13 + msgp.ArrayHeaderSize + 8 + msgp.IntSize
 	for za0001 := range z.Translations {
 		s += msgp.StringPrefixSize + len(z.Translations[za0001])
 	}
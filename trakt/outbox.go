@@ -0,0 +1,145 @@
+package trakt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/database"
+	"github.com/bcrusher29/solaris/httpclient"
+)
+
+// ErrQueued is returned when Trakt could not be reached,
+// and the change is stored for a later replay
+var ErrQueued = errors.New("Trakt is not reachable, change will be sent later")
+
+const (
+	outboxReplayInterval = 5 * time.Minute
+	outboxMaxAttempts    = 20
+	outboxBatchSize      = 50
+
+	// Trakt marks scrobbled item as watched after this progress
+	scrobbleWatchedPercent = 80
+)
+
+var outboxWakeup = make(chan struct{}, 1)
+
+// postOrQueue sends change to Trakt. If Trakt is not reachable, or there are
+// changes already waiting in the outbox, change is put to the outbox,
+// to keep changes in order.
+func postOrQueue(kind, dedup, endPoint string, payload []byte) (resp *httpclient.Response, err error) {
+	if dedup == "" {
+		dedup = strconv.FormatUint(xxhash.Sum64String(endPoint+string(payload)), 10)
+	}
+
+	if database.Get().GetOutboxCount(database.OutboxPending) > 0 {
+		log.Debugf("Trakt outbox is not empty, queueing %s change to %s", kind, endPoint)
+		database.Get().AddOutboxItem(kind, endPoint, string(payload), dedup, "")
+		WakeupOutbox()
+		return nil, ErrQueued
+	}
+
+	resp, err = Post(endPoint, bytes.NewBuffer(payload))
+	if err != nil && isTemporaryError(err) {
+		log.Warningf("Could not send %s change to Trakt, queueing it: %s", kind, err)
+		database.Get().AddOutboxItem(kind, endPoint, string(payload), dedup, err.Error())
+		return resp, ErrQueued
+	}
+
+	return
+}
+
+// queueScrobble stores failed scrobble. Finished item is stored as a history
+// entry, to keep the time it was watched at, and unfinished item is stored
+// as a pause, to keep playback progress. Start events are not worth a replay.
+func queueScrobble(action, contentType string, tmdbID int, progress float64, payload string, err error) {
+	dedup := fmt.Sprintf("scrobble:%s:%d", contentType, tmdbID)
+	if action == "stop" && progress >= scrobbleWatchedPercent {
+		payload = fmt.Sprintf(`{"%ss": [{"watched_at": "%s", "ids": {"tmdb": %d}}]}`, contentType, time.Now().UTC().Format(time.RFC3339), tmdbID)
+		database.Get().AddOutboxItem("history", "sync/history", payload, dedup, err.Error())
+		return
+	}
+
+	database.Get().AddOutboxItem("scrobble", "scrobble/pause", payload, dedup, err.Error())
+}
+
+// isTemporaryError checks whether request can succeed if sent later
+func isTemporaryError(err error) bool {
+	// Open circuit means Trakt has been failing with 5xx or network errors
+	if errors.Is(err, httpclient.ErrServer) || errors.Is(err, httpclient.ErrRateLimited) || errors.Is(err, httpclient.ErrCircuitOpen) {
+		return true
+	}
+
+	// Network errors, request never reached Trakt,
+	// while canceled requests and broken responses would fail again
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+// WakeupOutbox asks outbox worker to replay pending changes now
+func WakeupOutbox() {
+	select {
+	case outboxWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// OutboxHandler replays changes from the outbox, once Trakt is reachable
+func OutboxHandler() {
+	ticker := time.NewTicker(outboxReplayInterval)
+	defer ticker.Stop()
+
+	ReplayOutbox()
+	for {
+		select {
+		case <-ticker.C:
+		case <-outboxWakeup:
+		}
+
+		ReplayOutbox()
+	}
+}
+
+// ReplayOutbox sends pending changes in the order they were made.
+// Replay stops on the first temporary error, to keep that order.
+func ReplayOutbox() {
+	if config.Get().TraktToken == "" {
+		return
+	}
+	if u, err := url.Parse(APIURL); err == nil && !client.IsAvailable(u.Host) {
+		return
+	}
+
+	for {
+		items := database.Get().GetOutboxItems(database.OutboxPending, outboxBatchSize)
+		if len(items) == 0 {
+			return
+		}
+
+		log.Infof("Replaying %d changes from Trakt outbox", len(items))
+		for _, item := range items {
+			_, err := Post(item.EndPoint, bytes.NewBufferString(item.Payload))
+			if err == nil {
+				database.Get().DeleteOutboxItem(item.ID)
+				continue
+			}
+
+			item.Attempts++
+			if isTemporaryError(err) && item.Attempts < outboxMaxAttempts {
+				log.Debugf("Trakt is still not reachable: %s", err)
+				database.Get().UpdateOutboxItem(item.ID, database.OutboxPending, item.Attempts, err.Error())
+				return
+			}
+
+			log.Warningf("Could not replay %s change to %s: %s", item.Kind, item.EndPoint, err)
+			database.Get().UpdateOutboxItem(item.ID, database.OutboxFailed, item.Attempts, err.Error())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package trakt
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/util"
+	"github.com/jmcvetta/napping"
+)
+
+// PlaybackProgress is a paused item, returned by sync/playback
+type PlaybackProgress struct {
+	ID       int64     `json:"id"`
+	Progress float64   `json:"progress"`
+	PausedAt time.Time `json:"paused_at"`
+	Type     string    `json:"type"`
+	Movie    *Movie    `json:"movie"`
+	Episode  *Episode  `json:"episode"`
+	Show     *Show     `json:"show"`
+}
+
+// Position returns resume position in seconds for an item of given duration
+func (p *PlaybackProgress) Position(total float64) float64 {
+	return total * p.Progress / 100
+}
+
+// Runtime returns item runtime in seconds, as Trakt knows it
+func (p *PlaybackProgress) Runtime() float64 {
+	if p.Movie != nil {
+		return float64(p.Movie.Runtime * 60)
+	} else if p.Episode != nil && p.Episode.Runtime > 0 {
+		return float64(p.Episode.Runtime * 60)
+	} else if p.Show != nil {
+		return float64(p.Show.Runtime * 60)
+	}
+	return 0
+}
+
+// PlaybackMovies returns movies, paused on Trakt
+func PlaybackMovies() (items []*PlaybackProgress, err error) {
+	return playbackProgress("movies")
+}
+
+// PlaybackEpisodes returns episodes, paused on Trakt
+func PlaybackEpisodes() (items []*PlaybackProgress, err error) {
+	return playbackProgress("episodes")
+}
+
+func playbackProgress(itemType string) (items []*PlaybackProgress, err error) {
+	if err := Authorized(); err != nil {
+		return items, err
+	}
+
+	lastActivities, errAct := GetLastActivities()
+	if errAct != nil {
+		return items, errAct
+	}
+
+	pausedAt := lastActivities.Movies.PausedAt
+	if itemType == "episodes" {
+		pausedAt = lastActivities.Episodes.PausedAt
+	}
+
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.trakt.playback.%s", itemType)
+	pausedKey := fmt.Sprintf("com.trakt.progress.playback.%s", itemType)
+
+	var cachedPausedAt time.Time
+	if err := cacheStore.Get(pausedKey, &cachedPausedAt); err == nil && !pausedAt.After(cachedPausedAt) {
+		if err := cacheStore.Get(key, &items); err == nil {
+			return items, nil
+		}
+	}
+
+	endPoint := fmt.Sprintf("sync/playback/%s", itemType)
+	params := napping.Params{
+		"extended": "full",
+	}.AsUrlValues()
+
+	resp, err := GetWithAuth(endPoint, params)
+	if err != nil {
+		return items, err
+	}
+
+	if err := resp.Unmarshal(&items); err != nil {
+		log.Warning(err)
+	}
+
+	cacheStore.Set(key, items, progressExpiration)
+	cacheStore.Set(pausedKey, pausedAt, activitiesExpiration)
+
+	return
+}
+
+// SetMoviePlayback saves paused position of a movie on Trakt
+func SetMoviePlayback(tmdbID int, progress float64) error {
+	payload := fmt.Sprintf(`{"movie": {"ids": {"tmdb": %d}}, "progress": %f, "app_version": "%s"}`,
+		tmdbID, progress, util.GetVersion())
+	return setPlayback(payload)
+}
+
+// SetEpisodePlayback saves paused position of an episode on Trakt
+func SetEpisodePlayback(showID int, season int, episode int, progress float64) error {
+	payload := fmt.Sprintf(`{"show": {"ids": {"tmdb": %d}}, "episode": {"season": %d, "number": %d}, "progress": %f, "app_version": "%s"}`,
+		showID, season, episode, progress, util.GetVersion())
+	return setPlayback(payload)
+}
+
+// setPlayback uses scrobble pause, which is the only way
+// to store playback progress on Trakt
+func setPlayback(payload string) error {
+	if err := Authorized(); err != nil {
+		return err
+	}
+
+	_, err := Post("scrobble/pause", bytes.NewBufferString(payload))
+	return err
+}
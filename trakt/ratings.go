@@ -0,0 +1,267 @@
+package trakt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
+	"github.com/bcrusher29/solaris/util"
+	"github.com/jmcvetta/napping"
+)
+
+// ErrCheckinInProgress is returned when another check-in is active
+var ErrCheckinInProgress = errors.New("Another check-in is in progress")
+
+// Rating is an item, rated by the user, returned by sync/ratings
+type Rating struct {
+	RatedAt time.Time `json:"rated_at"`
+	Rating  int       `json:"rating"`
+	Type    string    `json:"type"`
+	Movie   *Movie    `json:"movie"`
+	Show    *Show     `json:"show"`
+	Season  *Season   `json:"season"`
+	Episode *Episode  `json:"episode"`
+}
+
+// Comment ...
+type Comment struct {
+	ID         int       `json:"id"`
+	ParentID   int       `json:"parent_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Comment    string    `json:"comment"`
+	Spoiler    bool      `json:"spoiler"`
+	Review     bool      `json:"review"`
+	Replies    int       `json:"replies"`
+	Likes      int       `json:"likes"`
+	UserRating int       `json:"user_rating"`
+	User       *User     `json:"user"`
+}
+
+// Checkin is returned by a successful check-in
+type Checkin struct {
+	ID        int64     `json:"id"`
+	WatchedAt time.Time `json:"watched_at"`
+	Movie     *Movie    `json:"movie"`
+	Show      *Show     `json:"show"`
+	Episode   *Episode  `json:"episode"`
+}
+
+// RatedMovies returns movies, rated by the user
+func RatedMovies() (ratings []*Rating, err error) {
+	return userRatings("movies")
+}
+
+// RatedShows returns shows, rated by the user
+func RatedShows() (ratings []*Rating, err error) {
+	return userRatings("shows")
+}
+
+// RatedEpisodes returns episodes, rated by the user
+func RatedEpisodes() (ratings []*Rating, err error) {
+	return userRatings("episodes")
+}
+
+func userRatings(itemType string) (ratings []*Rating, err error) {
+	if err := Authorized(); err != nil {
+		return ratings, err
+	}
+
+	lastActivities, errAct := GetLastActivities()
+	if errAct != nil {
+		return ratings, errAct
+	}
+
+	ratedAt := lastActivities.Movies.RatedAt
+	if itemType == "shows" {
+		ratedAt = lastActivities.Shows.RatedAt
+	} else if itemType == "episodes" {
+		ratedAt = lastActivities.Episodes.RatedAt
+	}
+
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.trakt.ratings.%s", itemType)
+	ratedKey := fmt.Sprintf("com.trakt.progress.ratings.%s", itemType)
+
+	var cachedRatedAt time.Time
+	if err := cacheStore.Get(ratedKey, &cachedRatedAt); err == nil && !ratedAt.After(cachedRatedAt) {
+		if err := cacheStore.Get(key, &ratings); err == nil {
+			return ratings, nil
+		}
+	}
+
+	endPoint := fmt.Sprintf("sync/ratings/%s", itemType)
+	resp, err := GetWithAuth(endPoint, napping.Params{}.AsUrlValues())
+	if err != nil {
+		return ratings, err
+	}
+
+	if err := resp.Unmarshal(&ratings); err != nil {
+		log.Warning(err)
+	}
+
+	cacheStore.Set(key, ratings, progressExpiration)
+	cacheStore.Set(ratedKey, ratedAt, activitiesExpiration)
+
+	return
+}
+
+// RateMovie sets user's rating for a movie, zero rating removes it
+func RateMovie(tmdbID int, rating int) (resp *httpclient.Response, err error) {
+	item := fmt.Sprintf(`{"ids": {"tmdb": %d}%s}`, tmdbID, ratingField(rating))
+	return rate("movies", item, fmt.Sprintf("rating:movie:%d", tmdbID), rating)
+}
+
+// RateShow sets user's rating for a show, zero rating removes it
+func RateShow(tmdbID int, rating int) (resp *httpclient.Response, err error) {
+	item := fmt.Sprintf(`{"ids": {"tmdb": %d}%s}`, tmdbID, ratingField(rating))
+	return rate("shows", item, fmt.Sprintf("rating:show:%d", tmdbID), rating)
+}
+
+// RateEpisode sets user's rating for an episode, zero rating removes it
+func RateEpisode(showID, season, episode int, rating int) (resp *httpclient.Response, err error) {
+	item := fmt.Sprintf(`{"ids": {"tmdb": %d}, "seasons": [{"number": %d, "episodes": [{"number": %d%s}]}]}`, showID, season, episode, ratingField(rating))
+	return rate("shows", item, fmt.Sprintf("rating:episode:%d:%d:%d", showID, season, episode), rating)
+}
+
+func ratingField(rating int) string {
+	if rating == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`, "rating": %d`, rating)
+}
+
+func rate(itemType string, item string, dedup string, rating int) (resp *httpclient.Response, err error) {
+	if err := Authorized(); err != nil {
+		return nil, err
+	}
+	if rating < 0 || rating > 10 {
+		return nil, fmt.Errorf("Rating should be between 1 and 10, got %d", rating)
+	}
+
+	endPoint := "sync/ratings"
+	if rating == 0 {
+		endPoint = "sync/ratings/remove"
+	}
+
+	return postOrQueue("rating", dedup, endPoint, []byte(fmt.Sprintf(`{"%s": [%s]}`, itemType, item)))
+}
+
+// CheckinMovie checks the user in to a movie
+func CheckinMovie(tmdbID int) (checkin *Checkin, err error) {
+	payload := fmt.Sprintf(`{"movie": {"ids": {"tmdb": %d}}, "app_version": "%s"}`, tmdbID, util.GetVersion())
+	return doCheckin(payload)
+}
+
+// CheckinEpisode checks the user in to an episode
+func CheckinEpisode(showID, season, episode int) (checkin *Checkin, err error) {
+	payload := fmt.Sprintf(`{"show": {"ids": {"tmdb": %d}}, "episode": {"season": %d, "number": %d}, "app_version": "%s"}`,
+		showID, season, episode, util.GetVersion())
+	return doCheckin(payload)
+}
+
+func doCheckin(payload string) (checkin *Checkin, err error) {
+	if err := Authorized(); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(httpclient.Background(), &httpclient.Request{
+		Method: "POST",
+		URL:    "checkin",
+		Header: newHeader(true),
+		Body:   []byte(payload),
+		Result: &checkin,
+	})
+	if httpclient.IsStatus(err, 409) {
+		return nil, ErrCheckinInProgress
+	} else if err != nil {
+		return nil, err
+	} else if resp.Status() != 201 {
+		return nil, fmt.Errorf("Bad status doing Trakt check-in: %d", resp.Status())
+	}
+
+	return
+}
+
+// CancelCheckin removes active check-in of the user
+func CancelCheckin() error {
+	if err := Authorized(); err != nil {
+		return err
+	}
+
+	_, err := client.Do(httpclient.Background(), &httpclient.Request{
+		Method: "DELETE",
+		URL:    "checkin",
+		Header: newHeader(true),
+	})
+	return err
+}
+
+// MovieComments returns comments for a movie
+func MovieComments(tmdbID int, page int) (comments []*Comment, total int, err error) {
+	movie := GetMovieByTMDB(strconv.Itoa(tmdbID))
+	if movie == nil || movie.IDs == nil {
+		return nil, 0, fmt.Errorf("Movie %d not found on Trakt", tmdbID)
+	}
+
+	return getComments(fmt.Sprintf("movies/%d/comments/newest", movie.IDs.Trakt), page)
+}
+
+// ShowComments returns comments for a show
+func ShowComments(tmdbID int, page int) (comments []*Comment, total int, err error) {
+	show := GetShowByTMDB(strconv.Itoa(tmdbID))
+	if show == nil || show.IDs == nil {
+		return nil, 0, fmt.Errorf("Show %d not found on Trakt", tmdbID)
+	}
+
+	return getComments(fmt.Sprintf("shows/%d/comments/newest", show.IDs.Trakt), page)
+}
+
+// EpisodeComments returns comments for an episode
+func EpisodeComments(tmdbID, season, episode int, page int) (comments []*Comment, total int, err error) {
+	show := GetShowByTMDB(strconv.Itoa(tmdbID))
+	if show == nil || show.IDs == nil {
+		return nil, 0, fmt.Errorf("Show %d not found on Trakt", tmdbID)
+	}
+
+	return getComments(fmt.Sprintf("shows/%d/seasons/%d/episodes/%d/comments/newest", show.IDs.Trakt, season, episode), page)
+}
+
+func getComments(endPoint string, page int) (comments []*Comment, total int, err error) {
+	params := napping.Params{
+		"page":  strconv.Itoa(page),
+		"limit": strconv.Itoa(config.Get().ResultsPerPage),
+	}.AsUrlValues()
+
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.trakt.comments.%s.%d", endPoint, page)
+	totalKey := fmt.Sprintf("com.trakt.comments.%s.total", endPoint)
+	if err := cacheStore.Get(key, &comments); err == nil {
+		if err := cacheStore.Get(totalKey, &total); err != nil {
+			total = -1
+		}
+		return comments, total, nil
+	}
+
+	resp, err := Get(endPoint, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := resp.Unmarshal(&comments); err != nil {
+		log.Warning(err)
+	}
+
+	if total, err = totalFromHeaders(resp.Header()); err != nil {
+		total = -1
+	} else {
+		cacheStore.Set(totalKey, total, recentExpiration)
+	}
+	cacheStore.Set(key, comments, recentExpiration)
+
+	return comments, total, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
 	"github.com/bcrusher29/solaris/fanart"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/playcount"
 	"github.com/bcrusher29/solaris/tmdb"
 	"github.com/bcrusher29/solaris/util"
@@ -192,6 +193,70 @@ func GetShowByTVDB(tvdbID string) (show *Show) {
 	return
 }
 
+// Timezone returns the zone show airs in, Trakt keeps it for most shows,
+// others are guessed by network and country
+func (show *Show) Timezone() string {
+	if show.Airs != nil && show.Airs.Timezone != "" {
+		return show.Airs.Timezone
+	}
+	return util.ShowTimezone(show.Network, show.Country)
+}
+
+// AirTime returns the moment an episode of the show airs, date is either
+// a full timestamp or a date in show's local time
+func (show *Show) AirTime(date string) time.Time {
+	clock := ""
+	if show.Airs != nil {
+		clock = show.Airs.Time
+	}
+	return util.AirTime(date, clock, show.Timezone())
+}
+
+// ShowAirs returns local air time and zone of a TMDB show. TMDB has only
+// air dates, so air time is taken from Trakt, when it is authorized.
+// It runs for every show of library updates, so it makes one request
+// and failures are only logged.
+func ShowAirs(show *tmdb.Show) (clock, timezone string) {
+	if show == nil {
+		return
+	}
+	timezone = show.Timezone()
+	if config.Get().TraktToken == "" {
+		return
+	}
+
+	var airs *Airs
+	cacheStore := cache.NewDBStore()
+	key := fmt.Sprintf("com.trakt.show.airs.tmdb.%d", show.ID)
+	if err := cacheStore.Get(key, &airs); err != nil {
+		endPoint := fmt.Sprintf("search/tmdb/%d", show.ID)
+		params := napping.Params{"type": "show", "extended": "full"}.AsUrlValues()
+
+		resp, err := Get(endPoint, params)
+		if err != nil {
+			log.Warningf("Could not get air time of show %d: %s", show.ID, err)
+			return
+		}
+
+		var results ShowSearchResults
+		if err := resp.Unmarshal(&results); err != nil {
+			log.Warning(err)
+		}
+		if len(results) > 0 && results[0].Show != nil {
+			airs = results[0].Show.Airs
+		}
+		cacheStore.Set(key, airs, cacheExpiration)
+	}
+	if airs == nil {
+		return
+	}
+
+	if airs.Timezone != "" {
+		timezone = airs.Timezone
+	}
+	return airs.Time, timezone
+}
+
 // GetSeasonEpisodes ...
 func GetSeasonEpisodes(showID, seasonNumber int) (episodes []*Episode) {
 	endPoint := fmt.Sprintf("shows/%d/seasons/%d", showID, seasonNumber)
@@ -360,7 +425,7 @@ func TopShows(topCategory string, page string) (shows []*Shows, total int, err e
 	key := fmt.Sprintf("com.trakt.shows.%s.%s", topCategory, page)
 	totalKey := fmt.Sprintf("com.trakt.shows.%s.total", topCategory)
 	if err := cacheStore.Get(key, &shows); err != nil || len(shows) == 0 {
-		var resp *napping.Response
+		var resp *httpclient.Response
 		var err error
 
 		if config.Get().TraktToken == "" {
@@ -395,7 +460,7 @@ func TopShows(topCategory string, page string) (shows []*Shows, total int, err e
 			}
 		}
 
-		pagination := getPagination(resp.HttpResponse().Header)
+		pagination := getPagination(resp.Header())
 		total = pagination.ItemCount
 		if err != nil {
 			log.Warning(err)
@@ -506,7 +571,7 @@ func ListItemsShows(listID string) (shows []*Shows, err error) {
 
 	params := napping.Params{}.AsUrlValues()
 
-	var resp *napping.Response
+	var resp *httpclient.Response
 
 	cacheStore := cache.NewDBStore()
 	key := fmt.Sprintf("com.trakt.shows.list.%s", listID)
@@ -576,7 +641,7 @@ func CalendarShows(endPoint string, page string) (shows []*CalendarShow, total i
 			log.Warning(errUnm)
 		}
 
-		pagination := getPagination(resp.HttpResponse().Header)
+		pagination := getPagination(resp.Header())
 		total = pagination.ItemCount
 		if err != nil {
 			total = -1
@@ -785,7 +850,7 @@ func (show *Show) ToListItem() (item *xbmc.ListItem) {
 				Code:          show.IDs.IMDB,
 				IMDBNumber:    show.IDs.IMDB,
 				Trailer:       util.TrailerURL(show.Trailer),
-				PlayCount:     playcount.GetWatchedShowByTMDB(show.IDs.TMDB).Int(),
+				PlayCount:     playcount.GetWatchedShowByTMDB(show.IDs.TMDB, show.AiredEpisodes).Int(),
 				DBTYPE:        "tvshow",
 				Mediatype:     "tvshow",
 			},
@@ -13,6 +13,7 @@ import (
 
 	"github.com/bcrusher29/solaris/cache"
 	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/util"
 	"github.com/bcrusher29/solaris/xbmc"
 	"github.com/jmcvetta/napping"
@@ -42,7 +43,6 @@ var (
 var (
 	// PagesAtOnce ...
 	PagesAtOnce             = 5
-	retriesLeft             = 3
 	burstRate               = 50
 	burstTime               = 10 * time.Second
 	simultaneousConnections = 25
@@ -66,7 +66,13 @@ const (
 	ProgressSortAiredOlder
 )
 
-var rl = util.NewRateLimiter(burstRate, burstTime, simultaneousConnections)
+var client = httpclient.New(httpclient.Config{
+	Name:         "trakt",
+	BaseURL:      APIURL,
+	RateLimit:    burstRate,
+	RateInterval: burstTime,
+	Parallel:     simultaneousConnections,
+})
 
 // Object ...
 type Object struct {
@@ -165,6 +171,7 @@ type Episode struct {
 	Overview     string   `json:"overview"`
 	Absolute     int      `json:"number_abs"`
 	FirstAired   string   `json:"first_aired"`
+	Runtime      int      `json:"runtime"`
 	Translations []string `json:"available_translations"`
 
 	Rating float32 `json:"rating"`
@@ -533,8 +540,7 @@ func getIntFromHeader(headers http.Header, key string) (res int) {
 	return -1
 }
 
-// Get ...
-func Get(endPoint string, params url.Values) (resp *napping.Response, err error) {
+func newHeader(withAuth bool) http.Header {
 	header := http.Header{
 		"Content-type":      []string{"application/json"},
 		"trakt-api-key":     []string{ClientID},
@@ -542,74 +548,36 @@ func Get(endPoint string, params url.Values) (resp *napping.Response, err error)
 		"User-Agent":        []string{UserAgent},
 		"Cookie":            []string{Cookies},
 	}
-
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s", APIURL, endPoint),
-		Method: "GET",
-		Params: &params,
-		Header: &header,
+	if withAuth {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", config.Get().TraktToken))
 	}
+	return header
+}
 
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-		if err != nil {
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting %s, cooling down...", endPoint)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			retriesLeft--
-			resp, err = Get(endPoint, params)
-		}
-
-		return nil
+// Get ...
+func Get(endPoint string, params url.Values) (resp *httpclient.Response, err error) {
+	return client.Do(httpclient.Background(), &httpclient.Request{
+		Method: "GET",
+		URL:    endPoint,
+		Params: params,
+		Header: newHeader(false),
 	})
-	return
 }
 
 // GetWithAuth ...
-func GetWithAuth(endPoint string, params url.Values) (resp *napping.Response, err error) {
-	header := http.Header{
-		"Content-type":      []string{"application/json"},
-		"Authorization":     []string{fmt.Sprintf("Bearer %s", config.Get().TraktToken)},
-		"trakt-api-key":     []string{ClientID},
-		"trakt-api-version": []string{APIVersion},
-		"User-Agent":        []string{UserAgent},
-		"Cookie":            []string{Cookies},
-	}
-
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s", APIURL, endPoint),
+func GetWithAuth(endPoint string, params url.Values) (resp *httpclient.Response, err error) {
+	return client.Do(httpclient.Background(), &httpclient.Request{
 		Method: "GET",
-		Params: &params,
-		Header: &header,
-	}
-
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-
-		if err != nil {
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting %s, cooling down...", endPoint)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			retriesLeft--
-			resp, err = GetWithAuth(endPoint, params)
-		}
-
-		return nil
+		URL:    endPoint,
+		Params: params,
+		Header: newHeader(true),
 	})
-	return
 }
 
 // PostJSON ...
-func PostJSON(endPoint string, obj interface{}) (resp *napping.Response, err error) {
+func PostJSON(endPoint string, obj interface{}) (resp *httpclient.Response, err error) {
 	b, err := json.Marshal(obj)
 	if err != nil {
-		fmt.Println(err)
 		return
 	}
 
@@ -617,45 +585,17 @@ func PostJSON(endPoint string, obj interface{}) (resp *napping.Response, err err
 }
 
 // Post ...
-func Post(endPoint string, payload *bytes.Buffer) (resp *napping.Response, err error) {
-	header := http.Header{
-		"Content-type":      []string{"application/json"},
-		"Authorization":     []string{fmt.Sprintf("Bearer %s", config.Get().TraktToken)},
-		"trakt-api-key":     []string{ClientID},
-		"trakt-api-version": []string{APIVersion},
-		"User-Agent":        []string{UserAgent},
-		"Cookie":            []string{Cookies},
-	}
-
-	req := napping.Request{
-		Url:        fmt.Sprintf("%s/%s", APIURL, endPoint),
-		Method:     "POST",
-		RawPayload: true,
-		Payload:    payload,
-		Header:     &header,
-	}
-
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-		if err != nil {
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting %s, cooling down...", endPoint)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			retriesLeft--
-			resp, err = Post(endPoint, payload)
-		}
-
-		return nil
+func Post(endPoint string, payload *bytes.Buffer) (resp *httpclient.Response, err error) {
+	return client.Do(httpclient.Background(), &httpclient.Request{
+		Method: "POST",
+		URL:    endPoint,
+		Header: newHeader(true),
+		Body:   payload.Bytes(),
 	})
-	return
 }
 
 // GetCode ...
 func GetCode() (code *Code, err error) {
-	endPoint := "oauth/device/code"
 	header := http.Header{
 		"Content-type": []string{"application/json"},
 		"User-Agent":   []string{UserAgent},
@@ -665,41 +605,21 @@ func GetCode() (code *Code, err error) {
 		"client_id": ClientID,
 	}.AsUrlValues()
 
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s", APIURL, endPoint),
+	_, err = client.Do(httpclient.Background(), &httpclient.Request{
 		Method: "POST",
-		Params: &params,
-		Header: &header,
-	}
-
-	var resp *napping.Response
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-		if err != nil {
-			err = resp.Unmarshal(&code)
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting Trakt code %s, cooling down...", code)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			retriesLeft--
-			code, err = GetCode()
-		} else {
-			resp.Unmarshal(&code)
-		}
-
-		return nil
+		URL:    "oauth/device/code",
+		Params: params,
+		Header: header,
+		Result: &code,
 	})
-	if err == nil && resp.Status() != 200 {
-		err = fmt.Errorf("Unable to get Trakt code: %d", resp.Status())
+	if err != nil {
+		err = fmt.Errorf("Unable to get Trakt code: %s", err)
 	}
 	return
 }
 
 // GetToken ...
-func GetToken(code string) (resp *napping.Response, err error) {
-	endPoint := "oauth/device/token"
+func GetToken(code string) (resp *httpclient.Response, err error) {
 	header := http.Header{
 		"Content-type": []string{"application/json"},
 		"User-Agent":   []string{UserAgent},
@@ -711,29 +631,12 @@ func GetToken(code string) (resp *napping.Response, err error) {
 		"client_secret": ClientSecret,
 	}.AsUrlValues()
 
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s", APIURL, endPoint),
+	return client.Do(httpclient.Background(), &httpclient.Request{
 		Method: "POST",
-		Params: &params,
-		Header: &header,
-	}
-
-	rl.Call(func() error {
-		resp, err = napping.Send(&req)
-		if err != nil {
-			return err
-		} else if resp.Status() == 429 {
-			log.Warningf("Rate limit exceeded getting Trakt token with code %s, cooling down...", code)
-			rl.CoolDown(resp.HttpResponse().Header)
-			return util.ErrExceeded
-		} else if resp.Status() == 403 && retriesLeft > 0 {
-			retriesLeft--
-			resp, err = GetToken(code)
-		}
-
-		return nil
+		URL:    "oauth/device/token",
+		Params: params,
+		Header: header,
 	})
-	return
 }
 
 // PollToken ...
@@ -748,7 +651,7 @@ func PollToken(code *Code) (token *Token, err error) {
 		select {
 		case <-interval.C:
 			resp, errGet := GetToken(code.DeviceCode)
-			if errGet != nil {
+			if resp == nil {
 				return nil, errGet
 			}
 			if resp.Status() == 200 {
@@ -783,8 +686,7 @@ func PollToken(code *Code) (token *Token, err error) {
 }
 
 // RefreshToken ...
-func RefreshToken() (resp *napping.Response, err error) {
-	endPoint := "oauth/token"
+func RefreshToken() (resp *httpclient.Response, err error) {
 	header := http.Header{
 		"Content-type": []string{"application/json"},
 		"User-Agent":   []string{UserAgent},
@@ -798,21 +700,12 @@ func RefreshToken() (resp *napping.Response, err error) {
 		"grant_type":    "refresh_token",
 	}.AsUrlValues()
 
-	req := napping.Request{
-		Url:    fmt.Sprintf("%s/%s", APIURL, endPoint),
+	return client.Do(httpclient.Background(), &httpclient.Request{
 		Method: "POST",
-		Params: &params,
-		Header: &header,
-	}
-
-	resp, err = napping.Send(&req)
-	if err != nil {
-		return
-	} else if resp.Status() == 403 && retriesLeft > 0 {
-		retriesLeft--
-		resp, err = RefreshToken()
-	}
-	return
+		URL:    "oauth/token",
+		Params: params,
+		Header: header,
+	})
 }
 
 // TokenRefreshHandler ...
@@ -828,7 +721,7 @@ func TokenRefreshHandler() {
 		case <-ticker.C:
 			if time.Now().Unix() > int64(config.Get().TraktTokenExpiry)-int64(259200) {
 				resp, err := RefreshToken()
-				if err != nil {
+				if resp == nil {
 					xbmc.Notify("Elementum", err.Error(), config.AddonIcon())
 					log.Error(err)
 					return
@@ -921,7 +814,7 @@ func Authorized() error {
 }
 
 // SyncAddedItem adds item (movie/show) to watchlist or collection
-func SyncAddedItem(itemType string, tmdbID string, location int) (resp *napping.Response, err error) {
+func SyncAddedItem(itemType string, tmdbID string, location int) (resp *httpclient.Response, err error) {
 	list := config.Get().TraktSyncAddedMoviesList
 	if itemType == "shows" {
 		list = config.Get().TraktSyncAddedShowsList
@@ -939,7 +832,7 @@ func SyncAddedItem(itemType string, tmdbID string, location int) (resp *napping.
 }
 
 // SyncRemovedItem removes item (movie/show) from watchlist or collection
-func SyncRemovedItem(itemType string, tmdbID string, location int) (resp *napping.Response, err error) {
+func SyncRemovedItem(itemType string, tmdbID string, location int) (resp *httpclient.Response, err error) {
 	list := config.Get().TraktSyncRemovedMoviesList
 	if itemType == "shows" {
 		list = config.Get().TraktSyncRemovedShowsList
@@ -957,17 +850,18 @@ func SyncRemovedItem(itemType string, tmdbID string, location int) (resp *nappin
 }
 
 // AddToWatchlist ...
-func AddToWatchlist(itemType string, tmdbID string) (resp *napping.Response, err error) {
+func AddToWatchlist(itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
 
 	endPoint := "sync/watchlist"
-	return Post(endPoint, bytes.NewBufferString(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
+	dedup := fmt.Sprintf("watchlist:%s:%s", itemType, tmdbID)
+	return postOrQueue("watchlist", dedup, endPoint, []byte(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
 }
 
 // AddToUserlist ...
-func AddToUserlist(listID int, itemType string, tmdbID string) (resp *napping.Response, err error) {
+func AddToUserlist(listID int, itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
@@ -985,11 +879,17 @@ func AddToUserlist(listID int, itemType string, tmdbID string) (resp *napping.Re
 		payload.Shows = append(payload.Shows, i)
 	}
 
-	return PostJSON(endPoint, payload)
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	dedup := fmt.Sprintf("list:%d:%s:%s", listID, itemType, tmdbID)
+	return postOrQueue("list", dedup, endPoint, b)
 }
 
 // RemoveFromUserlist ...
-func RemoveFromUserlist(listID int, itemType string, tmdbID string) (resp *napping.Response, err error) {
+func RemoveFromUserlist(listID int, itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
@@ -1007,41 +907,50 @@ func RemoveFromUserlist(listID int, itemType string, tmdbID string) (resp *nappi
 		payload.Shows = append(payload.Shows, i)
 	}
 
-	return PostJSON(endPoint, payload)
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	dedup := fmt.Sprintf("list:%d:%s:%s", listID, itemType, tmdbID)
+	return postOrQueue("list", dedup, endPoint, b)
 }
 
 // RemoveFromWatchlist ...
-func RemoveFromWatchlist(itemType string, tmdbID string) (resp *napping.Response, err error) {
+func RemoveFromWatchlist(itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
 
 	endPoint := "sync/watchlist/remove"
-	return Post(endPoint, bytes.NewBufferString(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
+	dedup := fmt.Sprintf("watchlist:%s:%s", itemType, tmdbID)
+	return postOrQueue("watchlist", dedup, endPoint, []byte(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
 }
 
 // AddToCollection ...
-func AddToCollection(itemType string, tmdbID string) (resp *napping.Response, err error) {
+func AddToCollection(itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
 
 	endPoint := "sync/collection"
-	return Post(endPoint, bytes.NewBufferString(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
+	dedup := fmt.Sprintf("collection:%s:%s", itemType, tmdbID)
+	return postOrQueue("collection", dedup, endPoint, []byte(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
 }
 
 // RemoveFromCollection ...
-func RemoveFromCollection(itemType string, tmdbID string) (resp *napping.Response, err error) {
+func RemoveFromCollection(itemType string, tmdbID string) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
 
 	endPoint := "sync/collection/remove"
-	return Post(endPoint, bytes.NewBufferString(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
+	dedup := fmt.Sprintf("collection:%s:%s", itemType, tmdbID)
+	return postOrQueue("collection", dedup, endPoint, []byte(fmt.Sprintf(`{"%s": [{"ids": {"tmdb": %s}}]}`, itemType, tmdbID)))
 }
 
 // SetWatched addes and removes from watched history
-func SetWatched(item *WatchedItem) (resp *napping.Response, err error) {
+func SetWatched(item *WatchedItem) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil {
 		return nil, err
 	}
@@ -1058,11 +967,12 @@ func SetWatched(item *WatchedItem) (resp *napping.Response, err error) {
 		endPoint = "sync/history/remove"
 	}
 
-	return Post(endPoint, bytes.NewBufferString(pre+query+post))
+	dedup := fmt.Sprintf("history:%d:%d:%d:%d", item.Movie, item.Show, item.Season, item.Episode)
+	return postOrQueue("history", dedup, endPoint, []byte(pre+query+post))
 }
 
 // SetMultipleWatched adds and removes from watched history
-func SetMultipleWatched(items []*WatchedItem) (resp *napping.Response, err error) {
+func SetMultipleWatched(items []*WatchedItem) (resp *httpclient.Response, err error) {
 	if err := Authorized(); err != nil || len(items) == 0 {
 		return nil, err
 	}
@@ -1090,7 +1000,7 @@ func SetMultipleWatched(items []*WatchedItem) (resp *napping.Response, err error
 	cache.NewDBStore().Delete(fmt.Sprintf("com.trakt.%ss.watched", items[0].MediaType))
 
 	log.Debugf("Setting watch state for %d %s items", len(items), items[0].MediaType)
-	return Post(endPoint, bytes.NewBufferString(pre+query+post))
+	return postOrQueue("history", "", endPoint, []byte(pre+query+post))
 }
 
 func (item *WatchedItem) String() (query string) {
@@ -1111,7 +1021,7 @@ func (item *WatchedItem) String() (query string) {
 
 // This is commented for future use (if needed)
 // // SetMultipleWatched addes and removes list from watched history
-// func SetMultipleWatched(watched bool, itemType string, tmdbID []string) (resp *napping.Response, err error) {
+// func SetMultipleWatched(watched bool, itemType string, tmdbID []string) (resp *httpclient.Response, err error) {
 // 	if err := Authorized(); err != nil {
 // 		return nil, err
 // 	}
@@ -1147,7 +1057,10 @@ func Scrobble(action string, contentType string, tmdbID int, watched float64, ru
 	payload := fmt.Sprintf(`{"%s": {"ids": {"tmdb": %d}}, "progress": %f, "app_version": "%s"}`,
 		contentType, tmdbID, progress, util.GetVersion())
 	resp, err := Post(endPoint, bytes.NewBufferString(payload))
-	if err != nil {
+	if err != nil && action != "start" && isTemporaryError(err) {
+		log.Warningf("Could not scrobble %s #%d to %s, queueing it: %s", contentType, tmdbID, action, err)
+		queueScrobble(action, contentType, tmdbID, progress, payload, err)
+	} else if err != nil {
 		log.Error(err.Error())
 		xbmc.Notify("Elementum", "Scrobble failed, check your logs.", config.AddonIcon())
 	} else if resp.Status() != 201 {
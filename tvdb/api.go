@@ -0,0 +1,294 @@
+package tvdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bcrusher29/solaris/config"
+	"github.com/bcrusher29/solaris/httpclient"
+)
+
+// Responses of TheTVDB v4 API. These are converted into Show, Season,
+// Episode, Banner and Actor, that are kept in the cache.
+
+// Artwork types of series
+const (
+	artworkBanner     = 1
+	artworkPoster     = 2
+	artworkBackground = 3
+	artworkIcon       = 5
+	artworkClearArt   = 22
+	artworkClearLogo  = 23
+)
+
+// Character type of actors
+const characterActor = 3
+
+// Token is valid for a month, it is renewed a bit earlier
+const tokenExpiration = 25 * 24 * time.Hour
+
+// ErrNoAPIKey is returned, when TheTVDB API key is not configured.
+// v4 API needs a personal or project key, there is no shared one,
+// so TheTVDB is opt-in and is used only after the key is set in settings.
+var ErrNoAPIKey = errors.New("TheTVDB API key is not configured")
+
+var (
+	tokenMu      sync.Mutex
+	token        string
+	tokenExpires time.Time
+	// Missing key is logged once per run
+	noKeyLogged sync.Once
+)
+
+// languages maps ISO 639-1 codes to ISO 639-2 codes, that are used by TheTVDB
+var languages = map[string]string{
+	"ar": "ara", "bg": "bul", "ca": "cat", "cs": "ces", "da": "dan",
+	"de": "deu", "el": "ell", "en": "eng", "es": "spa", "et": "est",
+	"fa": "fas", "fi": "fin", "fr": "fra", "he": "heb", "hi": "hin",
+	"hr": "hrv", "hu": "hun", "id": "ind", "it": "ita", "ja": "jpn",
+	"ko": "kor", "lt": "lit", "lv": "lav", "ms": "msa", "nl": "nld",
+	"no": "nor", "pl": "pol", "pt": "por", "ro": "ron", "ru": "rus",
+	"sk": "slk", "sl": "slv", "sr": "srp", "sv": "swe", "th": "tha",
+	"tr": "tur", "uk": "ukr", "vi": "vie", "zh": "zho",
+}
+
+type apiResponse struct {
+	Status string      `json:"status"`
+	Data   interface{} `json:"data"`
+	Links  struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+type apiName struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type apiSeries struct {
+	ID                int                `json:"id"`
+	Name              string             `json:"name"`
+	Image             string             `json:"image"`
+	FirstAired        string             `json:"firstAired"`
+	LastUpdated       string             `json:"lastUpdated"`
+	Score             float64            `json:"score"`
+	Status            apiName            `json:"status"`
+	OriginalLanguage  string             `json:"originalLanguage"`
+	OriginalCountry   string             `json:"originalCountry"`
+	AverageRuntime    int                `json:"averageRuntime"`
+	Overview          string             `json:"overview"`
+	AirsTime          string             `json:"airsTime"`
+	AirsDays          map[string]bool    `json:"airsDays"`
+	OriginalNetwork   *apiName           `json:"originalNetwork"`
+	LatestNetwork     *apiName           `json:"latestNetwork"`
+	Genres            []apiName          `json:"genres"`
+	Artworks          []apiArtwork       `json:"artworks"`
+	Characters        []apiCharacter     `json:"characters"`
+	RemoteIDs         []apiRemoteID      `json:"remoteIds"`
+	Seasons           []apiSeason        `json:"seasons"`
+	ContentRatings    []apiContentRating `json:"contentRatings"`
+	Translations      apiTranslations    `json:"translations"`
+	DefaultSeasonType int                `json:"defaultSeasonType"`
+}
+
+type apiTranslations struct {
+	Names []struct {
+		Name     string `json:"name"`
+		Language string `json:"language"`
+	} `json:"nameTranslations"`
+	Overviews []struct {
+		Overview string `json:"overview"`
+		Language string `json:"language"`
+	} `json:"overviewTranslations"`
+}
+
+type apiArtwork struct {
+	ID        int     `json:"id"`
+	Image     string  `json:"image"`
+	Thumbnail string  `json:"thumbnail"`
+	Language  string  `json:"language"`
+	Type      int     `json:"type"`
+	Score     float64 `json:"score"`
+	Width     int     `json:"width"`
+	Height    int     `json:"height"`
+}
+
+type apiCharacter struct {
+	Name       string `json:"name"`
+	PeopleID   int    `json:"peopleId"`
+	PersonName string `json:"personName"`
+	Image      string `json:"image"`
+	PersonImg  string `json:"personImgURL"`
+	Sort       int    `json:"sort"`
+	Type       int    `json:"type"`
+}
+
+type apiRemoteID struct {
+	ID         string `json:"id"`
+	SourceName string `json:"sourceName"`
+}
+
+type apiSeason struct {
+	ID     int    `json:"id"`
+	Number int    `json:"number"`
+	Image  string `json:"image"`
+	Type   struct {
+		Type string `json:"type"`
+	} `json:"type"`
+}
+
+type apiContentRating struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+type apiEpisodes struct {
+	Episodes []*apiEpisode `json:"episodes"`
+}
+
+type apiEpisode struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Overview       string `json:"overview"`
+	Aired          string `json:"aired"`
+	Runtime        int    `json:"runtime"`
+	Image          string `json:"image"`
+	Number         int    `json:"number"`
+	SeasonNumber   int    `json:"seasonNumber"`
+	AbsoluteNumber int    `json:"absoluteNumber"`
+}
+
+// Enabled reports whether TheTVDB API key is set in settings
+func Enabled() bool {
+	return config.Get().TVDBApiKey != ""
+}
+
+// apiLanguage converts language of the configuration into TheTVDB language
+func apiLanguage(language string) string {
+	language = strings.ToLower(strings.SplitN(language, "-", 2)[0])
+	if l, ok := languages[language]; ok {
+		return l
+	}
+	return "eng"
+}
+
+// getToken returns a token, logging in if there is no valid token.
+// User's subscription key and PIN are used when they are set.
+func getToken() (string, error) {
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+
+	if token != "" && time.Now().Before(tokenExpires) {
+		return token, nil
+	}
+
+	key := config.Get().TVDBApiKey
+	if key == "" {
+		noKeyLogged.Do(func() {
+			log.Info("TheTVDB API key is not configured, TheTVDB is not used")
+		})
+		return "", ErrNoAPIKey
+	}
+
+	login := map[string]string{"apikey": key}
+	if pin := config.Get().TVDBPin; pin != "" {
+		login["pin"] = pin
+	}
+	body, _ := json.Marshal(login)
+
+	var data struct {
+		Token string `json:"token"`
+	}
+	_, err := client.Do(httpclient.Background(), &httpclient.Request{
+		Method: http.MethodPost,
+		URL:    "login",
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   body,
+		Result: &apiResponse{Data: &data},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Could not login to TheTVDB: %s", err)
+	} else if data.Token == "" {
+		return "", errors.New("Could not login to TheTVDB: empty token")
+	}
+
+	token = data.Token
+	tokenExpires = time.Now().Add(tokenExpiration)
+	return token, nil
+}
+
+func resetToken() {
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+
+	token = ""
+}
+
+// get requests an endpoint and decodes response data into result.
+// Expired token is renewed once.
+func get(endpoint string, params url.Values, result interface{}) (*apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		t, err := getToken()
+		if err != nil {
+			return nil, err
+		}
+
+		ret := &apiResponse{Data: result}
+		_, err = client.Do(httpclient.Background(), &httpclient.Request{
+			Method: http.MethodGet,
+			URL:    endpoint,
+			Params: params,
+			Header: http.Header{"Authorization": []string{"Bearer " + t}},
+			Result: ret,
+		})
+		if errors.Is(err, httpclient.ErrUnauthorized) && attempt == 0 {
+			resetToken()
+			continue
+		}
+		return ret, err
+	}
+}
+
+func getSeries(tvdbID int) (*apiSeries, error) {
+	series := &apiSeries{}
+	params := url.Values{
+		"meta":  []string{"translations"},
+		"short": []string{"false"},
+	}
+	if _, err := get(fmt.Sprintf("series/%d/extended", tvdbID), params, series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// getEpisodes returns all episodes in an order, "official", "dvd" or "absolute".
+// With language, names and overviews are translated, where translations exist.
+func getEpisodes(tvdbID int, order string, language string) ([]*apiEpisode, error) {
+	endpoint := fmt.Sprintf("series/%d/episodes/%s", tvdbID, order)
+	if language != "" {
+		endpoint += "/" + language
+	}
+
+	episodes := []*apiEpisode{}
+	for page := 0; ; page++ {
+		data := &apiEpisodes{}
+		resp, err := get(endpoint, url.Values{"page": []string{strconv.Itoa(page)}}, data)
+		if err != nil {
+			return nil, err
+		}
+
+		episodes = append(episodes, data.Episodes...)
+		if resp.Links.Next == nil || *resp.Links.Next == "" || len(data.Episodes) == 0 {
+			break
+		}
+	}
+
+	return episodes, nil
+}
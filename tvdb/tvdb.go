@@ -1,93 +1,97 @@
 package tvdb
 
 import (
-	"archive/zip"
-	"bytes"
-	"encoding/xml"
 	"fmt"
-	"io/ioutil"
 	"sort"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bcrusher29/solaris/cache"
+	"github.com/bcrusher29/solaris/httpclient"
 	"github.com/bcrusher29/solaris/scrape"
+	logging "github.com/op/go-logging"
 )
 
+var log = logging.MustGetLogger("tvdb")
+
 //go:generate msgp -o msgp.go -io=false -tests=false
 
 const (
-	tvdbURL                 = "http://thetvdb.com"
-	tvdbEndpoint            = tvdbURL + "/api"
-	apiKey                  = "1D62F2F90030C444"
+	tvdbURL                 = "https://api4.thetvdb.com/v4"
+	artworksURL             = "https://artworks.thetvdb.com"
 	burstRate               = 30
 	burstTime               = 1 * time.Second
 	simultaneousConnections = 20
 	cacheExpiration         = 2 * time.Hour
 )
 
+// Episode orders
+const (
+	OrderAired    = "official"
+	OrderDVD      = "dvd"
+	OrderAbsolute = "absolute"
+)
+
+var client = httpclient.New(httpclient.Config{
+	Name:         "tvdb",
+	BaseURL:      tvdbURL,
+	RateLimit:    burstRate,
+	RateInterval: burstTime,
+	Parallel:     simultaneousConnections,
+	HTTPClient:   scrape.GetClient,
+})
+
 // SeasonList ...
 type SeasonList []*Season
 
 // EpisodeList ...
 type EpisodeList []*Episode
 
-// Episode ...
+// Episode is an episode in aired order, with its numbers in DVD and absolute orders
 type Episode struct {
-	ID            string `xml:"id"`
-	Director      string `xml:"Director"`
-	EpisodeName   string `xml:"EpisodeName"`
-	EpisodeNumber int    `xml:"EpisodeNumber"`
-	FirstAired    string `xml:"FirstAired"`
-	GuestStars    string `xml:"GuestStars"`
-	ImdbID        string `xml:"IMDB_ID"`
-	Language      string `xml:"Language"`
-	Overview      string `xml:"Overview"`
-	Rating        string `xml:"Rating"`
-	RatingCount   string `xml:"RatingCount"`
-	SeasonNumber  int    `xml:"SeasonNumber"`
-	Writer        string `xml:"Writer"`
-	FileName      string `xml:"filename"`
-	LastUpdated   string `xml:"lastupdated"`
-	SeasonID      string `xml:"seasonid"`
-	SeriesID      string `xml:"seriesid"`
-	ThumbHeight   string `xml:"thumb_height"`
-	ThumbWidth    string `xml:"thumb_width"`
-
-	AbsoluteNumber       int    `xml:"-"`
-	AbsoluteNumberString string `xml:"absolute_number"`
+	ID               int
+	EpisodeName      string
+	EpisodeNumber    int
+	SeasonNumber     int
+	AbsoluteNumber   int
+	DVDSeason        int
+	DVDEpisodeNumber int
+	FirstAired       string
+	Overview         string
+	Runtime          int
+	Image            string
+	Language         string
 }
 
 // Show ...
 type Show struct {
-	ID            int    `xml:"id"`
-	ActorsSimple  string `xml:"Actors"`
-	AirsDayOfWeek string `xml:"Airs_DayOfWeek"`
-	AirsTime      string `xml:"Airs_Time"`
-	ContentRating string `xml:"ContentRating"`
-	FirstAired    string `xml:"FirstAired"`
-	Genre         string `xml:"Genre"`
-	ImdbID        string `xml:"IMDB_ID"`
-	Language      string `xml:"Language"`
-	Network       string `xml:"Network"`
-	NetworkID     string `xml:"NetworkID"`
-	Overview      string `xml:"Overview"`
-	Rating        string `xml:"Rating"`
-	RatingCount   string `xml:"RatingCount"`
-	RuntimeString string `xml:"Runtime"`
-	SeriesID      string `xml:"SeriesID"`
-	SeriesName    string `xml:"SeriesName"`
-	Status        string `xml:"Status"`
-	Banner        string `xml:"banner"`
-	FanArt        string `xml:"fanart"`
-	LastUpdated   int    `xml:"lastupdated"`
-	Poster        string `xml:"poster"`
-
-	Runtime int `xml:"-"`
-
-	Seasons SeasonList `xml:"-"`
-	Banners []*Banner  `xml:"-"`
-	Actors  []*Actor   `xml:"-"`
+	ID               int
+	SeriesName       string
+	Overview         string
+	Language         string
+	OriginalLanguage string
+	AirsDayOfWeek    string
+	AirsTime         string
+	ContentRating    string
+	FirstAired       string
+	Genre            string
+	ImdbID           string
+	Network          string
+	Country          string
+	Score            float64
+	Status           string
+	Banner           string
+	FanArt           string
+	Poster           string
+	LastUpdated      string
+	Runtime          int
+
+	// EpisodeOrders are orders, available for the show
+	EpisodeOrders []string
+
+	Seasons SeasonList
+	Banners []*Banner
+	Actors  []*Actor
 }
 
 // Season ...
@@ -96,116 +100,340 @@ type Season struct {
 	Episodes EpisodeList
 }
 
-// Banner ...
+// Banner is an artwork of a show or a season
 type Banner struct {
-	ID            string `xml:"id"`
-	BannerPath    string `xml:"BannerPath"`
-	BannerType    string `xml:"BannerType"`
-	BannerType2   string `xml:"BannerType2"`
-	Colors        string `xml:"Colors"`
-	Language      string `xml:"Language"`
-	Rating        string `xml:"Rating"`
-	RatingCount   int    `xml:"RatingCount"`
-	SeriesName    string `xml:"SeriesName"`
-	ThumbnailPath string `xml:"ThumbnailPath"`
-	VignettePath  string `xml:"VignettePath"`
-	Season        int    `xml:"Season,omitempty"`
+	ID            int
+	BannerPath    string
+	ThumbnailPath string
+	BannerType    string
+	Language      string
+	Score         float64
+	Width         int
+	Height        int
+	Season        int
 }
 
 // Actor ...
 type Actor struct {
-	ID        string `xml:"id"`
-	Image     string `xml:"Image"`
-	Name      string `xml:"Name"`
-	Role      string `xml:"Role"`
-	SortOrder int    `xml:"SortOrder"`
+	ID        int
+	Image     string
+	Name      string
+	Role      string
+	SortOrder int
 }
 
 func getShow(tvdbID int, language string) (*Show, error) {
-	var serie struct {
-		Serie    *Show      `xml:"Series"`
-		Episodes []*Episode `xml:"Episode"`
-	}
-	var banners struct {
-		Banners []*Banner `xml:"Banner"`
-	}
-	var actors struct {
-		Actors []*Actor `xml:"Actor"`
-	}
-
-	resp, err := scrape.GetClient().Get(fmt.Sprintf("%s/%s/series/%d/all/%s.zip", tvdbEndpoint, apiKey, tvdbID, language))
+	series, err := getSeries(tvdbID)
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	show := newShow(series, apiLanguage(language))
 
-	br := bytes.NewReader(b)
-	zipReader, err := zip.NewReader(br, int64(br.Len()))
+	episodes, err := getEpisodes(tvdbID, OrderAired, "")
 	if err != nil {
 		return nil, err
 	}
-	for _, file := range zipReader.File {
-		f, err := file.Open()
-		if err != nil {
-			return nil, err
+	if show.Language != series.OriginalLanguage {
+		if translated, err := getEpisodes(tvdbID, OrderAired, show.Language); err == nil {
+			translateEpisodes(episodes, translated)
+		} else {
+			log.Debugf("No %s translation of episodes for %d: %s", show.Language, tvdbID, err)
 		}
-		defer f.Close()
-		decoder := xml.NewDecoder(f)
-		switch file.Name {
-		case language + ".xml":
-			if err := decoder.Decode(&serie); err != nil {
-				return nil, err
-			}
-		case "banners.xml":
-			if err := decoder.Decode(&banners); err != nil {
-				return nil, err
+	}
+
+	list := make(EpisodeList, 0, len(episodes))
+	byID := map[int]*Episode{}
+	for _, e := range episodes {
+		episode := &Episode{
+			ID:             e.ID,
+			EpisodeName:    e.Name,
+			EpisodeNumber:  e.Number,
+			SeasonNumber:   e.SeasonNumber,
+			AbsoluteNumber: e.AbsoluteNumber,
+			FirstAired:     e.Aired,
+			Overview:       e.Overview,
+			Runtime:        e.Runtime,
+			Image:          imageURL(e.Image),
+			Language:       show.Language,
+		}
+		list = append(list, episode)
+		byID[e.ID] = episode
+	}
+
+	if show.HasOrder(OrderDVD) {
+		if dvd, err := getEpisodes(tvdbID, OrderDVD, ""); err == nil {
+			for _, e := range dvd {
+				if episode, ok := byID[e.ID]; ok {
+					episode.DVDSeason = e.SeasonNumber
+					episode.DVDEpisodeNumber = e.Number
+				}
 			}
-		case "actors.xml":
-			if err := decoder.Decode(&actors); err != nil {
-				return nil, err
+		} else {
+			log.Debugf("Could not get DVD order of %d: %s", tvdbID, err)
+		}
+	}
+	if show.HasOrder(OrderAbsolute) {
+		if absolute, err := getEpisodes(tvdbID, OrderAbsolute, ""); err == nil {
+			for _, e := range absolute {
+				if episode, ok := byID[e.ID]; ok && episode.AbsoluteNumber == 0 {
+					episode.AbsoluteNumber = e.Number
+				}
 			}
+		} else {
+			log.Debugf("Could not get absolute order of %d: %s", tvdbID, err)
 		}
 	}
 
-	show := serie.Serie
-	show.Actors = actors.Actors
-	show.Banners = banners.Banners
-	show.Seasons = make([]*Season, 0)
-
-	sort.Sort(sort.Reverse(BannersByRating(banners.Banners)))
-	sort.Sort(BySeasonAndEpisodeNumber(serie.Episodes))
-
-	if rt, err := strconv.Atoi(show.RuntimeString); err == nil {
-		show.Runtime = rt
-	}
+	sort.Sort(BySeasonAndEpisodeNumber(list))
 
 	curSeasonNumber := -1
-	for _, episode := range serie.Episodes {
-		for _ = 0; curSeasonNumber < episode.SeasonNumber; curSeasonNumber++ {
+	for _, episode := range list {
+		for ; curSeasonNumber < episode.SeasonNumber; curSeasonNumber++ {
 			show.Seasons = append(show.Seasons, &Season{
-				Season:   episode.SeasonNumber,
+				Season:   curSeasonNumber + 1,
 				Episodes: make([]*Episode, 0),
 			})
 		}
 		season := show.Seasons[curSeasonNumber]
-		if an, err := strconv.Atoi(episode.AbsoluteNumberString); err == nil {
-			episode.AbsoluteNumber = an
-		}
 		season.Episodes = append(season.Episodes, episode)
 	}
 
 	return show, nil
 }
 
-// GetShow ...
+// newShow converts extended series record, using translations into language
+func newShow(series *apiSeries, language string) *Show {
+	show := &Show{
+		ID:               series.ID,
+		SeriesName:       series.Name,
+		Overview:         series.Overview,
+		Language:         language,
+		OriginalLanguage: series.OriginalLanguage,
+		AirsTime:         series.AirsTime,
+		Country:          series.OriginalCountry,
+		FirstAired:       series.FirstAired,
+		Score:            series.Score,
+		Status:           series.Status.Name,
+		Poster:           imageURL(series.Image),
+		LastUpdated:      series.LastUpdated,
+		Runtime:          series.AverageRuntime,
+		Seasons:          make(SeasonList, 0),
+		Banners:          make([]*Banner, 0),
+		Actors:           make([]*Actor, 0),
+	}
+
+	if name := translatedName(series, language); name != "" {
+		show.SeriesName = name
+	}
+	if overview := translatedOverview(series, language); overview != "" {
+		show.Overview = overview
+	}
+
+	genres := make([]string, 0, len(series.Genres))
+	for _, genre := range series.Genres {
+		genres = append(genres, genre.Name)
+	}
+	show.Genre = strings.Join(genres, "|")
+
+	if series.LatestNetwork != nil {
+		show.Network = series.LatestNetwork.Name
+	} else if series.OriginalNetwork != nil {
+		show.Network = series.OriginalNetwork.Name
+	}
+
+	for _, id := range series.RemoteIDs {
+		if strings.EqualFold(id.SourceName, "IMDB") {
+			show.ImdbID = id.ID
+			break
+		}
+	}
+	for _, rating := range series.ContentRatings {
+		if rating.Country == "usa" || show.ContentRating == "" {
+			show.ContentRating = rating.Name
+		}
+	}
+	show.AirsDayOfWeek = airsDays(series.AirsDays)
+
+	for _, artwork := range series.Artworks {
+		bannerType := artworkType(artwork.Type)
+		if bannerType == "" {
+			continue
+		}
+		show.Banners = append(show.Banners, &Banner{
+			ID:            artwork.ID,
+			BannerPath:    imageURL(artwork.Image),
+			ThumbnailPath: imageURL(artwork.Thumbnail),
+			BannerType:    bannerType,
+			Language:      artwork.Language,
+			Score:         artwork.Score,
+			Width:         artwork.Width,
+			Height:        artwork.Height,
+		})
+	}
+	sort.Sort(sort.Reverse(BannersByRating(show.Banners)))
+
+	for _, season := range series.Seasons {
+		if !show.HasOrder(season.Type.Type) {
+			show.EpisodeOrders = append(show.EpisodeOrders, season.Type.Type)
+		}
+		if season.Type.Type == OrderAired && season.Image != "" {
+			show.Banners = append(show.Banners, &Banner{
+				ID:         season.ID,
+				BannerPath: imageURL(season.Image),
+				BannerType: "season",
+				Language:   series.OriginalLanguage,
+				Season:     season.Number,
+			})
+		}
+	}
+
+	for _, banner := range show.Banners {
+		if banner.BannerType == "series" && show.Banner == "" {
+			show.Banner = banner.BannerPath
+		} else if banner.BannerType == "fanart" && show.FanArt == "" {
+			show.FanArt = banner.BannerPath
+		}
+	}
+
+	for _, character := range series.Characters {
+		if character.Type != characterActor {
+			continue
+		}
+		image := character.PersonImg
+		if image == "" {
+			image = character.Image
+		}
+		show.Actors = append(show.Actors, &Actor{
+			ID:        character.PeopleID,
+			Image:     imageURL(image),
+			Name:      character.PersonName,
+			Role:      character.Name,
+			SortOrder: character.Sort,
+		})
+	}
+	sort.Slice(show.Actors, func(i, j int) bool {
+		return show.Actors[i].SortOrder < show.Actors[j].SortOrder
+	})
+
+	return show
+}
+
+func translatedName(series *apiSeries, language string) string {
+	for _, t := range series.Translations.Names {
+		if t.Language == language {
+			return t.Name
+		}
+	}
+	return ""
+}
+
+func translatedOverview(series *apiSeries, language string) (overview string) {
+	for _, t := range series.Translations.Overviews {
+		if t.Language == language {
+			return t.Overview
+		} else if t.Language == "eng" {
+			overview = t.Overview
+		}
+	}
+	return
+}
+
+// translateEpisodes replaces names and overviews with translated ones
+func translateEpisodes(episodes []*apiEpisode, translated []*apiEpisode) {
+	byID := make(map[int]*apiEpisode, len(translated))
+	for _, e := range translated {
+		byID[e.ID] = e
+	}
+
+	for _, e := range episodes {
+		if t, ok := byID[e.ID]; ok {
+			if t.Name != "" {
+				e.Name = t.Name
+			}
+			if t.Overview != "" {
+				e.Overview = t.Overview
+			}
+		}
+	}
+}
+
+// artworkType returns banner type of series artwork, like in old API
+func artworkType(t int) string {
+	switch t {
+	case artworkBanner:
+		return "series"
+	case artworkPoster:
+		return "poster"
+	case artworkBackground:
+		return "fanart"
+	case artworkIcon:
+		return "icon"
+	case artworkClearArt:
+		return "clearart"
+	case artworkClearLogo:
+		return "clearlogo"
+	}
+	return ""
+}
+
+func airsDays(days map[string]bool) string {
+	names := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	airs := []string{}
+	for _, name := range names {
+		if days[strings.ToLower(name)] {
+			airs = append(airs, name)
+		}
+	}
+
+	if len(airs) == len(names) {
+		return "Daily"
+	} else if len(airs) > 0 {
+		return airs[0]
+	}
+	return ""
+}
+
+// HasOrder checks whether episodes are available in the order
+func (show *Show) HasOrder(order string) bool {
+	for _, o := range show.EpisodeOrders {
+		if o == order {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEpisode returns episode by its season and number in the order.
+// Season is ignored for absolute order.
+func (show *Show) GetEpisode(order string, season, number int) *Episode {
+	for _, s := range show.Seasons {
+		for _, episode := range s.Episodes {
+			switch order {
+			case OrderAired:
+				if episode.SeasonNumber == season && episode.EpisodeNumber == number {
+					return episode
+				}
+			case OrderDVD:
+				if episode.DVDSeason == season && episode.DVDEpisodeNumber == number {
+					return episode
+				}
+			case OrderAbsolute:
+				if episode.AbsoluteNumber == number {
+					return episode
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetShow returns show with episodes in aired order, with names
+// and overviews in the language, where translations exist
 func GetShow(tvdbID int, language string) (*Show, error) {
 	var show *Show
 	cacheStore := cache.NewDBStore()
-	key := fmt.Sprintf("com.tvdb.show.%d.%s", tvdbID, language)
+	key := fmt.Sprintf("com.tvdb.v4.show.%d.%s", tvdbID, language)
 	if err := cacheStore.Get(key, &show); err != nil {
 		newShow, err := getShow(tvdbID, language)
 		if err != nil {
@@ -231,13 +459,9 @@ func (a BySeasonAndEpisodeNumber) Less(i, j int) bool {
 // BannersByRating ...
 type BannersByRating []*Banner
 
-func (a BannersByRating) Len() int      { return len(a) }
-func (a BannersByRating) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a BannersByRating) Less(i, j int) bool {
-	iRating, _ := strconv.ParseFloat(a[i].Rating, 32)
-	jRating, _ := strconv.ParseFloat(a[j].Rating, 32)
-	return iRating < jRating
-}
+func (a BannersByRating) Len() int           { return len(a) }
+func (a BannersByRating) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a BannersByRating) Less(i, j int) bool { return a[i].Score < a[j].Score }
 
 func (s SeasonList) Len() int           { return len(s) }
 func (s SeasonList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,146 @@
+package tvdb
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const seriesFixture = `{
+	"id": 81189,
+	"name": "Breaking Bad",
+	"image": "/banners/posters/81189-10.jpg",
+	"firstAired": "2008-01-20",
+	"score": 9.5,
+	"status": {"id": 2, "name": "Ended"},
+	"originalLanguage": "eng",
+	"originalCountry": "usa",
+	"averageRuntime": 47,
+	"overview": "A chemistry teacher",
+	"airsTime": "22:00",
+	"airsDays": {"sunday": true},
+	"originalNetwork": {"id": 1, "name": "AMC"},
+	"latestNetwork": {"id": 2, "name": "AMC+"},
+	"genres": [{"id": 1, "name": "Drama"}, {"id": 2, "name": "Crime"}],
+	"artworks": [
+		{"id": 1, "image": "https://artworks.thetvdb.com/banners/graphical/1.jpg", "type": 1, "score": 10},
+		{"id": 2, "image": "/banners/fanart/low.jpg", "thumbnail": "/banners/fanart/low_t.jpg", "type": 3, "score": 5},
+		{"id": 3, "image": "/banners/fanart/high.jpg", "type": 3, "score": 50},
+		{"id": 4, "image": "/banners/logo.png", "type": 23, "score": 1},
+		{"id": 5, "image": "/banners/unknown.jpg", "type": 99, "score": 100}
+	],
+	"characters": [
+		{"name": "Jesse", "peopleId": 2, "personName": "Aaron Paul", "image": "/c/2.jpg", "sort": 2, "type": 3},
+		{"name": "Walter", "peopleId": 1, "personName": "Bryan Cranston", "personImgURL": "/p/1.jpg", "sort": 1, "type": 3},
+		{"name": "Director", "peopleId": 3, "personName": "Vince Gilligan", "type": 1}
+	],
+	"remoteIds": [{"id": "tt0903747", "sourceName": "IMDB"}],
+	"seasons": [
+		{"id": 10, "number": 1, "image": "/banners/seasons/1.jpg", "type": {"type": "official"}},
+		{"id": 11, "number": 1, "type": {"type": "dvd"}},
+		{"id": 12, "number": 2, "type": {"type": "official"}}
+	],
+	"contentRatings": [{"name": "MA15+", "country": "aus"}, {"name": "TV-MA", "country": "usa"}],
+	"translations": {
+		"nameTranslations": [{"name": "Во все тяжкие", "language": "rus"}],
+		"overviewTranslations": [{"overview": "English overview", "language": "eng"}]
+	}
+}`
+
+func TestNewShow(t *testing.T) {
+	series := &apiSeries{}
+	if err := json.Unmarshal([]byte(seriesFixture), series); err != nil {
+		t.Fatal(err)
+	}
+
+	show := newShow(series, "rus")
+	checks := []struct {
+		field    string
+		value    interface{}
+		expected interface{}
+	}{
+		{"SeriesName", show.SeriesName, "Во все тяжкие"},
+		{"Overview", show.Overview, "English overview"},
+		{"Status", show.Status, "Ended"},
+		{"Genre", show.Genre, "Drama|Crime"},
+		{"Network", show.Network, "AMC+"},
+		{"ImdbID", show.ImdbID, "tt0903747"},
+		{"ContentRating", show.ContentRating, "TV-MA"},
+		{"AirsDayOfWeek", show.AirsDayOfWeek, "Sunday"},
+		{"Runtime", show.Runtime, 47},
+		{"Poster", show.Poster, "https://artworks.thetvdb.com/banners/posters/81189-10.jpg"},
+		{"Banner", show.Banner, "https://artworks.thetvdb.com/banners/graphical/1.jpg"},
+		{"FanArt", show.FanArt, "https://artworks.thetvdb.com/banners/fanart/high.jpg"},
+		{"EpisodeOrders", len(show.EpisodeOrders), 2},
+		{"Banners", len(show.Banners), 5},
+		{"Actors", len(show.Actors), 2},
+	}
+	for _, c := range checks {
+		if c.value != c.expected {
+			t.Errorf("%s is %v, expected %v", c.field, c.value, c.expected)
+		}
+	}
+
+	if !show.HasOrder(OrderAired) || !show.HasOrder(OrderDVD) || show.HasOrder(OrderAbsolute) {
+		t.Errorf("wrong episode orders %v", show.EpisodeOrders)
+	}
+
+	types := map[string]int{}
+	for _, b := range show.Banners {
+		types[b.BannerType]++
+		if b.BannerType == "season" && (b.Season != 1 || b.BannerPath != "https://artworks.thetvdb.com/banners/seasons/1.jpg") {
+			t.Errorf("wrong season banner %+v", b)
+		}
+	}
+	if types["series"] != 1 || types["fanart"] != 2 || types["clearlogo"] != 1 || types["season"] != 1 {
+		t.Errorf("wrong banner types %v", types)
+	}
+
+	if a := show.Actors[0]; a.Name != "Bryan Cranston" || a.Role != "Walter" || a.Image != "https://artworks.thetvdb.com/banners/p/1.jpg" {
+		t.Errorf("wrong first actor %+v", a)
+	}
+	if a := show.Actors[1]; a.Image != "https://artworks.thetvdb.com/banners/c/2.jpg" {
+		t.Errorf("actor without person image has image %s", a.Image)
+	}
+
+	// Show language without translation keeps original name
+	if show := newShow(series, "deu"); show.SeriesName != "Breaking Bad" {
+		t.Errorf("untranslated name is %s", show.SeriesName)
+	}
+}
+
+func TestAirsDays(t *testing.T) {
+	tests := []struct {
+		days     map[string]bool
+		expected string
+	}{
+		{nil, ""},
+		{map[string]bool{"monday": false}, ""},
+		{map[string]bool{"friday": true}, "Friday"},
+		{map[string]bool{"friday": true, "tuesday": true}, "Tuesday"},
+		{map[string]bool{"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": true, "sunday": true}, "Daily"},
+	}
+
+	for _, test := range tests {
+		if days := airsDays(test.days); days != test.expected {
+			t.Errorf("airsDays(%v) = %q, expected %q", test.days, days, test.expected)
+		}
+	}
+}
+
+func TestArtworkType(t *testing.T) {
+	tests := map[int]string{
+		artworkBanner:     "series",
+		artworkPoster:     "poster",
+		artworkBackground: "fanart",
+		artworkIcon:       "icon",
+		artworkClearArt:   "clearart",
+		artworkClearLogo:  "clearlogo",
+		4:                 "",
+	}
+
+	for artwork, expected := range tests {
+		if bannerType := artworkType(artwork); bannerType != expected {
+			t.Errorf("artworkType(%d) = %q, expected %q", artwork, bannerType, expected)
+		}
+	}
+}
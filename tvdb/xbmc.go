@@ -11,8 +11,27 @@ import (
 	"github.com/bcrusher29/solaris/xbmc"
 )
 
+// imageURL returns full URL of an image, old records keep relative paths
 func imageURL(path string) string {
-	return tvdbURL + "/banners/" + path
+	if path == "" || strings.HasPrefix(path, "http") {
+		return path
+	}
+	return artworksURL + "/banners/" + strings.TrimPrefix(strings.TrimPrefix(path, "/"), "banners/")
+}
+
+// Timezone returns the zone show airs in, guessed by its network and country
+func (show *Show) Timezone() string {
+	return util.ShowTimezone(show.Network, show.Country)
+}
+
+// airedTime returns the moment an episode ends airing, TheTVDB keeps
+// air time in show's local time
+func airedTime(date string, show *Show) time.Time {
+	aired := util.AirTime(date, show.AirsTime, show.Timezone())
+	if aired.IsZero() {
+		return aired
+	}
+	return aired.Add(time.Duration(show.Runtime) * time.Minute)
 }
 
 // ToListItems ...
@@ -22,18 +41,15 @@ func (seasons SeasonList) ToListItems(show *Show) []*xbmc.ListItem {
 	fanarts := make([]string, 0)
 	for _, banner := range show.Banners {
 		if banner.BannerType == "fanart" {
-			fanarts = append(fanarts, imageURL(banner.BannerPath))
+			fanarts = append(fanarts, banner.BannerPath)
 		}
 	}
 
-	now := util.UTCBod()
 	for _, season := range seasons {
 		if len(season.Episodes) == 0 {
 			continue
 		}
-		airedDateTime := fmt.Sprintf("%s %s EST", season.Episodes[0].FirstAired, show.AirsTime)
-		firstAired, _ := time.Parse("2006-01-02 3:04 PM MST", airedDateTime)
-		if firstAired.Add(time.Duration(show.Runtime) * time.Minute).After(now) {
+		if util.IsUnaired(airedTime(season.Episodes[0].FirstAired, show)) {
 			continue
 		}
 		item := season.ToListItem(show)
@@ -55,18 +71,15 @@ func (episodes EpisodeList) ToListItems(show *Show) []*xbmc.ListItem {
 	fanarts := make([]string, 0)
 	for _, banner := range show.Banners {
 		if banner.BannerType == "fanart" {
-			fanarts = append(fanarts, imageURL(banner.BannerPath))
+			fanarts = append(fanarts, banner.BannerPath)
 		}
 	}
 
-	now := util.UTCBod()
 	for _, episode := range episodes {
 		if episode.FirstAired == "" {
 			continue
 		}
-		airedDateTime := fmt.Sprintf("%s %s EST", episode.FirstAired, show.AirsTime)
-		firstAired, _ := time.Parse("2006-01-02 3:04 PM MST", airedDateTime)
-		if firstAired.Add(time.Duration(show.Runtime) * time.Minute).After(now) {
+		if util.IsUnaired(airedTime(episode.FirstAired, show)) {
 			continue
 		}
 		item := episode.ToListItem(show)
@@ -98,11 +111,10 @@ func (season *Season) ToListItem(show *Show) *xbmc.ListItem {
 	}
 
 	for _, banner := range show.Banners {
-		if banner.BannerType2 == "season" &&
+		if banner.BannerType == "season" &&
 			banner.Season == season.Season &&
-			banner.Language == show.Language &&
 			item.Art.Poster == "" {
-			item.Art.Poster = imageURL(banner.BannerPath)
+			item.Art.Poster = banner.BannerPath
 			item.Art.Thumbnail = item.Art.Poster
 			item.Thumbnail = item.Art.Poster
 			break
@@ -135,8 +147,8 @@ func (episode *Episode) ToListItem(show *Show) *xbmc.ListItem {
 			Aired:         episode.FirstAired,
 		},
 		Art: &xbmc.ListItemArt{
-			Thumbnail: imageURL(episode.FileName),
-			Poster:    imageURL(show.Poster),
+			Thumbnail: episode.Image,
+			Poster:    show.Poster,
 		},
 	}
 
@@ -0,0 +1,187 @@
+package util
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/op/go-logging"
+)
+
+var airtimeLog = logging.MustGetLogger("airtime")
+
+// Layouts of local air times, that metadata providers use
+var clockLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM", "3:04 pm", "3:04pm", "3 PM", "3PM"}
+
+// networkTimezones maps networks, that air in a zone different from
+// their country's main zone, or are better known than their country
+var networkTimezones = map[string]string{
+	"abc":             "America/New_York",
+	"amc":             "America/New_York",
+	"cbs":             "America/New_York",
+	"cw":              "America/New_York",
+	"the cw":          "America/New_York",
+	"fox":             "America/New_York",
+	"fx":              "America/New_York",
+	"hbo":             "America/New_York",
+	"nbc":             "America/New_York",
+	"showtime":        "America/New_York",
+	"starz":           "America/New_York",
+	"syfy":            "America/New_York",
+	"usa network":     "America/New_York",
+	"cbc":             "America/Toronto",
+	"bbc one":         "Europe/London",
+	"bbc two":         "Europe/London",
+	"bbc three":       "Europe/London",
+	"itv":             "Europe/London",
+	"itv1":            "Europe/London",
+	"channel 4":       "Europe/London",
+	"sky atlantic":    "Europe/London",
+	"sky one":         "Europe/London",
+	"abc (au)":        "Australia/Sydney",
+	"nhk":             "Asia/Tokyo",
+	"tokyo mx":        "Asia/Tokyo",
+	"tv tokyo":        "Asia/Tokyo",
+	"fuji tv":         "Asia/Tokyo",
+	"kbs2":            "Asia/Seoul",
+	"mbc":             "Asia/Seoul",
+	"sbs":             "Asia/Seoul",
+	"tvn":             "Asia/Seoul",
+	"jtbc":            "Asia/Seoul",
+	"zdf":             "Europe/Berlin",
+	"das erste":       "Europe/Berlin",
+	"canal+":          "Europe/Paris",
+	"rai 1":           "Europe/Rome",
+	"channel 1":       "Europe/Moscow",
+	"russia 1":        "Europe/Moscow",
+	"tnt":             "America/New_York",
+	"adult swim":      "America/New_York",
+	"comedy central":  "America/New_York",
+	"cartoon network": "America/New_York",
+}
+
+// countryTimezones maps two and three letter country codes, that TMDB, Trakt
+// and TheTVDB use, to the zone most of the country's networks air in
+var countryTimezones = map[string]string{
+	"us": "America/New_York", "usa": "America/New_York",
+	"ca": "America/Toronto", "can": "America/Toronto",
+	"mx": "America/Mexico_City", "mex": "America/Mexico_City",
+	"br": "America/Sao_Paulo", "bra": "America/Sao_Paulo",
+	"ar": "America/Argentina/Buenos_Aires", "arg": "America/Argentina/Buenos_Aires",
+	"gb": "Europe/London", "gbr": "Europe/London",
+	"ie": "Europe/Dublin", "irl": "Europe/Dublin",
+	"fr": "Europe/Paris", "fra": "Europe/Paris",
+	"de": "Europe/Berlin", "deu": "Europe/Berlin",
+	"es": "Europe/Madrid", "esp": "Europe/Madrid",
+	"it": "Europe/Rome", "ita": "Europe/Rome",
+	"nl": "Europe/Amsterdam", "nld": "Europe/Amsterdam",
+	"be": "Europe/Brussels", "bel": "Europe/Brussels",
+	"se": "Europe/Stockholm", "swe": "Europe/Stockholm",
+	"no": "Europe/Oslo", "nor": "Europe/Oslo",
+	"dk": "Europe/Copenhagen", "dnk": "Europe/Copenhagen",
+	"fi": "Europe/Helsinki", "fin": "Europe/Helsinki",
+	"pl": "Europe/Warsaw", "pol": "Europe/Warsaw",
+	"tr": "Europe/Istanbul", "tur": "Europe/Istanbul",
+	"ru": "Europe/Moscow", "rus": "Europe/Moscow",
+	"ua": "Europe/Kiev", "ukr": "Europe/Kiev",
+	"il": "Asia/Jerusalem", "isr": "Asia/Jerusalem",
+	"in": "Asia/Kolkata", "ind": "Asia/Kolkata",
+	"cn": "Asia/Shanghai", "chn": "Asia/Shanghai",
+	"tw": "Asia/Taipei", "twn": "Asia/Taipei",
+	"jp": "Asia/Tokyo", "jpn": "Asia/Tokyo",
+	"kr": "Asia/Seoul", "kor": "Asia/Seoul",
+	"th": "Asia/Bangkok", "tha": "Asia/Bangkok",
+	"au": "Australia/Sydney", "aus": "Australia/Sydney",
+	"nz": "Pacific/Auckland", "nzl": "Pacific/Auckland",
+	"za": "Africa/Johannesburg", "zaf": "Africa/Johannesburg",
+}
+
+// zoneOffsets are standard offsets in minutes of zones above and of other
+// common ones, they are used when the system has no zone database,
+// like on Android and Windows. Daylight saving time is not known then,
+// so air times can be an hour off.
+var zoneOffsets = map[string]int{
+	"America/New_York": -300, "America/Toronto": -300, "America/Chicago": -360,
+	"America/Denver": -420, "America/Los_Angeles": -480, "America/Mexico_City": -360,
+	"America/Sao_Paulo": -180, "America/Argentina/Buenos_Aires": -180,
+	"Europe/London": 0, "Europe/Dublin": 0, "Europe/Lisbon": 0,
+	"Europe/Paris": 60, "Europe/Berlin": 60, "Europe/Madrid": 60, "Europe/Rome": 60,
+	"Europe/Amsterdam": 60, "Europe/Brussels": 60, "Europe/Stockholm": 60, "Europe/Oslo": 60,
+	"Europe/Copenhagen": 60, "Europe/Warsaw": 60, "Europe/Prague": 60, "Europe/Vienna": 60,
+	"Europe/Helsinki": 120, "Europe/Kiev": 120, "Europe/Athens": 120, "Asia/Jerusalem": 120,
+	"Africa/Johannesburg": 120, "Europe/Istanbul": 180, "Europe/Moscow": 180,
+	"Asia/Kolkata": 330, "Asia/Bangkok": 420, "Asia/Shanghai": 480, "Asia/Taipei": 480,
+	"Asia/Hong_Kong": 480, "Asia/Singapore": 480, "Asia/Tokyo": 540, "Asia/Seoul": 540,
+	"Australia/Sydney": 600, "Pacific/Auckland": 720,
+}
+
+// Missing zone database is reported once, not for every episode
+var zoneWarning sync.Once
+
+// ShowTimezone returns the zone a show airs in, guessed by its network,
+// then by its country, or an empty string if both are unknown
+func ShowTimezone(network, country string) string {
+	if tz, ok := networkTimezones[strings.ToLower(strings.TrimSpace(network))]; ok {
+		return tz
+	}
+	return countryTimezones[strings.ToLower(strings.TrimSpace(country))]
+}
+
+// AirTime returns the moment an episode airs. Date is either a full timestamp,
+// like Trakt keeps, or a date in show's local time, clock is a local air time,
+// like "21:00" or "9:00 PM", and timezone is an IANA zone name, UTC if empty.
+// Without a clock the end of the air day is used, so an episode is not
+// considered aired before it could have aired anywhere in its zone.
+// Zero time is returned for an empty or broken date.
+func AirTime(date, clock, timezone string) time.Time {
+	if date == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t
+	}
+	if len(date) > 10 {
+		date = date[0:10]
+	}
+
+	location := loadLocation(timezone)
+
+	day, err := time.ParseInLocation("2006-01-02", date, location)
+	if err != nil {
+		return time.Time{}
+	}
+
+	clock = strings.TrimSpace(clock)
+	for _, layout := range clockLayouts {
+		if c, err := time.Parse(layout, clock); err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), 0, 0, location)
+		}
+	}
+	return day.AddDate(0, 0, 1)
+}
+
+// loadLocation returns a zone by its name, falling back to a fixed offset,
+// when zone database is missing, and to UTC for unknown zones
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	l, err := time.LoadLocation(name)
+	if err == nil {
+		return l
+	}
+	zoneWarning.Do(func() {
+		airtimeLog.Warningf("Could not load time zone %s, air times use fixed offsets: %s", name, err)
+	})
+
+	if offset, ok := zoneOffsets[name]; ok {
+		return time.FixedZone(name, offset*60)
+	}
+	return time.UTC
+}
+
+// IsUnaired reports whether an air time is still ahead
+func IsUnaired(aired time.Time) bool {
+	return aired.After(time.Now())
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShowTimezone(t *testing.T) {
+	tests := []struct {
+		network  string
+		country  string
+		timezone string
+	}{
+		{"HBO", "US", "America/New_York"},
+		{" BBC One ", "", "Europe/London"},
+		{"Tokyo MX", "us", "Asia/Tokyo"},
+		{"Unknown network", "DEU", "Europe/Berlin"},
+		{"", "kr", "Asia/Seoul"},
+		{"", "", ""},
+		{"Unknown network", "xx", ""},
+	}
+
+	for _, test := range tests {
+		if tz := ShowTimezone(test.network, test.country); tz != test.timezone {
+			t.Errorf("ShowTimezone(%q, %q) = %q, expected %q", test.network, test.country, tz, test.timezone)
+		}
+	}
+}
+
+func TestAirTime(t *testing.T) {
+	tests := []struct {
+		date     string
+		clock    string
+		timezone string
+		expected string
+	}{
+		{"", "21:00", "America/New_York", ""},
+		{"broken", "21:00", "", ""},
+		{"2020-01-10T02:00:00.000Z", "21:00", "America/New_York", "2020-01-10T02:00:00Z"},
+		{"2020-01-09", "21:00", "America/New_York", "2020-01-10T02:00:00Z"},
+		{"2020-07-09", "21:00", "America/New_York", "2020-07-10T01:00:00Z"},
+		{"2020-01-09", "9:00 PM", "America/New_York", "2020-01-10T02:00:00Z"},
+		{"2020-01-09", "23:30:00", "Asia/Tokyo", "2020-01-09T14:30:00Z"},
+		{"2020-01-09T00:00:00", "21:00", "", "2020-01-09T21:00:00Z"},
+		{"2020-01-09", "", "Europe/London", "2020-01-10T00:00:00Z"},
+		{"2020-01-09", "late", "Invalid/Zone", "2020-01-10T00:00:00Z"},
+	}
+
+	for _, test := range tests {
+		aired := AirTime(test.date, test.clock, test.timezone)
+		got := ""
+		if !aired.IsZero() {
+			got = aired.UTC().Format(time.RFC3339)
+		}
+		if got != test.expected {
+			t.Errorf("AirTime(%q, %q, %q) = %q, expected %q", test.date, test.clock, test.timezone, got, test.expected)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"container/list"
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -58,6 +59,25 @@ func (r *RateLimiter) Wait() {
 	}
 }
 
+// WaitContext is the same as Wait, but returns early with ctx error
+// if the context is done before the rate limit allows the call.
+func (r *RateLimiter) WaitContext(ctx context.Context) error {
+	for {
+		ok, remaining := r.Try()
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 // ForceWait is forcing rate limit if we have an external cause
 // (like Response from API).
 func (r *RateLimiter) ForceWait() {
@@ -150,6 +170,16 @@ func (r *RateLimiter) Enter() {
 	r.parallelChan <- true
 }
 
+// EnterContext is the same as Enter, but gives up when ctx is done
+func (r *RateLimiter) EnterContext(ctx context.Context) error {
+	select {
+	case r.parallelChan <- true:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Leave removes channel usage
 func (r *RateLimiter) Leave() {
 	<-r.parallelChan
@@ -758,9 +758,9 @@ func (z *ListItemArt) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *ListItemInfo) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 44
+	// map header, size 46
 	// string "Count"
-	o = append(o, 0xde, 0x0, 0x2c, 0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = append(o, 0xde, 0x0, 0x2e, 0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 	o = msgp.AppendInt(o, z.Count)
 	// string "Size"
 	o = append(o, 0xa4, 0x53, 0x69, 0x7a, 0x65)
@@ -903,6 +903,12 @@ func (z *ListItemInfo) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "Exif"
 	o = append(o, 0xa4, 0x45, 0x78, 0x69, 0x66)
 	o = msgp.AppendString(o, z.Exif)
+	// string "UserRating"
+	o = append(o, 0xaa, 0x55, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67)
+	o = msgp.AppendInt(o, z.UserRating)
+	// string "Set"
+	o = append(o, 0xa3, 0x53, 0x65, 0x74)
+	o = msgp.AppendString(o, z.Set)
 	return
 }
 
@@ -1194,6 +1200,16 @@ func (z *ListItemInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if err != nil {
 				return
 			}
+		case "UserRating":
+			z.UserRating, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Set":
+			z.Set, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1207,7 +1223,7 @@ func (z *ListItemInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ListItemInfo) Msgsize() (s int) {
-	s = 3 + 6 + msgp.IntSize + 5 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Date) + 6 + msgp.StringPrefixSize + len(z.Genre) + 5 + msgp.IntSize + 8 + msgp.IntSize + 7 + msgp.IntSize + 7 + msgp.IntSize + 12 + msgp.IntSize + 7 + msgp.Float32Size + 10 + msgp.IntSize + 8 + msgp.IntSize + 5 + msgp.ArrayHeaderSize
+	s = 3 + 6 + msgp.IntSize + 5 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Date) + 6 + msgp.StringPrefixSize + len(z.Genre) + 5 + msgp.IntSize + 8 + msgp.IntSize + 7 + msgp.IntSize + 7 + msgp.IntSize + 12 + msgp.IntSize + 7 + msgp.Float32Size + 10 + msgp.IntSize + 8 + msgp.IntSize + 5 + msgp.ArrayHeaderSize + 11 + msgp.IntSize
 	for za0001 := range z.Cast {
 		s += msgp.StringPrefixSize + len(z.Cast[za0001])
 	}
@@ -1222,7 +1238,7 @@ func (z *ListItemInfo) Msgsize() (s int) {
 	for za0004 := range z.Artist {
 		s += msgp.StringPrefixSize + len(z.Artist[za0004])
 	}
-	s += 6 + msgp.StringPrefixSize + len(z.Votes) + 8 + msgp.StringPrefixSize + len(z.Trailer) + 10 + msgp.StringPrefixSize + len(z.DateAdded) + 5 + msgp.IntSize + 7 + msgp.StringPrefixSize + len(z.DBTYPE) + 10 + msgp.StringPrefixSize + len(z.Mediatype) + 11 + msgp.StringPrefixSize + len(z.IMDBNumber) + 7 + msgp.StringPrefixSize + len(z.Lyrics) + 12 + msgp.StringPrefixSize + len(z.PicturePath) + 5 + msgp.StringPrefixSize + len(z.Exif)
+	s += 6 + msgp.StringPrefixSize + len(z.Votes) + 8 + msgp.StringPrefixSize + len(z.Trailer) + 10 + msgp.StringPrefixSize + len(z.DateAdded) + 5 + msgp.IntSize + 7 + msgp.StringPrefixSize + len(z.DBTYPE) + 10 + msgp.StringPrefixSize + len(z.Mediatype) + 11 + msgp.StringPrefixSize + len(z.IMDBNumber) + 4 + msgp.StringPrefixSize + len(z.Set) + 7 + msgp.StringPrefixSize + len(z.Lyrics) + 12 + msgp.StringPrefixSize + len(z.PicturePath) + 5 + msgp.StringPrefixSize + len(z.Exif)
 	return
 }
 
@@ -1832,9 +1848,9 @@ func (z *VideoLibraryEpisode) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *VideoLibraryEpisodeItem) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 10
 	// string "ID"
-	o = append(o, 0x89, 0xa2, 0x49, 0x44)
+	o = append(o, 0x8a, 0xa2, 0x49, 0x44)
 	o = msgp.AppendInt(o, z.ID)
 	// string "Title"
 	o = append(o, 0xa5, 0x54, 0x69, 0x74, 0x6c, 0x65)
@@ -1873,6 +1889,9 @@ func (z *VideoLibraryEpisodeItem) MarshalMsg(b []byte) (o []byte, err error) {
 		o = append(o, 0xa5, 0x54, 0x6f, 0x74, 0x61, 0x6c)
 		o = msgp.AppendFloat64(o, z.Resume.Total)
 	}
+	// string "LastPlayed"
+	o = append(o, 0xaa, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64)
+	o = msgp.AppendString(o, z.LastPlayed)
 	return
 }
 
@@ -1973,6 +1992,11 @@ func (z *VideoLibraryEpisodeItem) UnmarshalMsg(bts []byte) (o []byte, err error)
 					}
 				}
 			}
+		case "LastPlayed":
+			z.LastPlayed, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1986,7 +2010,7 @@ func (z *VideoLibraryEpisodeItem) UnmarshalMsg(bts []byte) (o []byte, err error)
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *VideoLibraryEpisodeItem) Msgsize() (s int) {
-	s = 1 + 3 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 7 + msgp.IntSize + 8 + msgp.IntSize + 9 + msgp.IntSize + 10 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.File) + 10 + z.UniqueIDs.Msgsize() + 7
+	s = 1 + 3 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 7 + msgp.IntSize + 8 + msgp.IntSize + 9 + msgp.IntSize + 10 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.File) + 10 + z.UniqueIDs.Msgsize() + 7 + 11 + msgp.StringPrefixSize + len(z.LastPlayed)
 	if z.Resume == nil {
 		s += msgp.NilSize
 	} else {
@@ -2150,9 +2174,9 @@ func (z VideoLibraryLimits) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *VideoLibraryMovieItem) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 8
+	// map header, size 9
 	// string "ID"
-	o = append(o, 0x88, 0xa2, 0x49, 0x44)
+	o = append(o, 0x89, 0xa2, 0x49, 0x44)
 	o = msgp.AppendInt(o, z.ID)
 	// string "Title"
 	o = append(o, 0xa5, 0x54, 0x69, 0x74, 0x6c, 0x65)
@@ -2188,6 +2212,9 @@ func (z *VideoLibraryMovieItem) MarshalMsg(b []byte) (o []byte, err error) {
 		o = append(o, 0xa5, 0x54, 0x6f, 0x74, 0x61, 0x6c)
 		o = msgp.AppendFloat64(o, z.Resume.Total)
 	}
+	// string "LastPlayed"
+	o = append(o, 0xaa, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64)
+	o = msgp.AppendString(o, z.LastPlayed)
 	return
 }
 
@@ -2283,6 +2310,11 @@ func (z *VideoLibraryMovieItem) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "LastPlayed":
+			z.LastPlayed, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2296,7 +2328,7 @@ func (z *VideoLibraryMovieItem) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *VideoLibraryMovieItem) Msgsize() (s int) {
-	s = 1 + 3 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 11 + msgp.StringPrefixSize + len(z.IMDBNumber) + 10 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.File) + 5 + msgp.IntSize + 10 + z.UniqueIDs.Msgsize() + 7
+	s = 1 + 3 + msgp.IntSize + 6 + msgp.StringPrefixSize + len(z.Title) + 11 + msgp.StringPrefixSize + len(z.IMDBNumber) + 10 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.File) + 5 + msgp.IntSize + 10 + z.UniqueIDs.Msgsize() + 7 + 11 + msgp.StringPrefixSize + len(z.LastPlayed)
 	if z.Resume == nil {
 		s += msgp.NilSize
 	} else {
@@ -68,6 +68,7 @@ type ListItemInfo struct {
 	Top250        int            `json:"top250,omitempty"`
 	TrackNumber   int            `json:"tracknumber,omitempty"`
 	Rating        float32        `json:"rating,omitempty"`
+	UserRating    int            `json:"userrating,omitempty"`
 	PlayCount     int            `json:"playcount,omitempty"`
 	Overlay       GUIIconOverlay `json:"overlay,omitempty"`
 	Cast          []string       `json:"cast,omitempty"`
@@ -99,6 +100,7 @@ type ListItemInfo struct {
 	DBTYPE        string         `json:"dbtype,omitempty"`
 	Mediatype     string         `json:"mediatype,omitempty"`
 	IMDBNumber    string         `json:"imdbnumber,omitempty"`
+	Set           string         `json:"set,omitempty"`
 
 	// Music Values
 	Lyrics string `json:"lyrics,omitempty"`
@@ -167,6 +169,7 @@ type VideoLibraryMovieItem struct {
 	File       string    `json:"file"`
 	Year       int       `json:"year"`
 	UniqueIDs  UniqueIDs `json:"uniqueid"`
+	LastPlayed string    `json:"lastplayed"`
 	Resume     *Resume
 }
 
@@ -220,15 +223,16 @@ type VideoLibraryEpisode struct {
 
 // VideoLibraryEpisodeItem ...
 type VideoLibraryEpisodeItem struct {
-	ID        int       `json:"episodeid"`
-	Title     string    `json:"label"`
-	Season    int       `json:"season"`
-	Episode   int       `json:"episode"`
-	TVShowID  int       `json:"tvshowid"`
-	PlayCount int       `json:"playcount"`
-	File      string    `json:"file"`
-	UniqueIDs UniqueIDs `json:"uniqueid"`
-	Resume    *Resume
+	ID         int       `json:"episodeid"`
+	Title      string    `json:"label"`
+	Season     int       `json:"season"`
+	Episode    int       `json:"episode"`
+	TVShowID   int       `json:"tvshowid"`
+	PlayCount  int       `json:"playcount"`
+	File       string    `json:"file"`
+	UniqueIDs  UniqueIDs `json:"uniqueid"`
+	LastPlayed string    `json:"lastplayed"`
+	Resume     *Resume
 }
 
 // UniqueIDs ...
@@ -287,14 +291,44 @@ type SettingValue struct {
 	Value string `json:"value"`
 }
 
+// ArtRewriter, when set, changes URLs of list items art,
+// so images can be served from elsewhere, like a local cache
+var ArtRewriter func(uri string, kind string) string
+
 // NewView ...
 func NewView(contentType string, items ListItems) *View {
+	if ArtRewriter != nil {
+		for _, item := range items {
+			item.rewriteArt(ArtRewriter)
+		}
+	}
+
 	return &View{
 		ContentType: contentType,
 		Items:       items,
 	}
 }
 
+func (item *ListItem) rewriteArt(rewrite func(string, string) string) {
+	if item == nil {
+		return
+	}
+
+	item.Icon = rewrite(item.Icon, "icon")
+	item.Thumbnail = rewrite(item.Thumbnail, "thumb")
+	if art := item.Art; art != nil {
+		art.Thumbnail = rewrite(art.Thumbnail, "thumb")
+		art.Poster = rewrite(art.Poster, "poster")
+		art.TvShowPoster = rewrite(art.TvShowPoster, "tvshowposter")
+		art.Banner = rewrite(art.Banner, "banner")
+		art.FanArt = rewrite(art.FanArt, "fanart")
+		art.ClearArt = rewrite(art.ClearArt, "clearart")
+		art.ClearLogo = rewrite(art.ClearLogo, "clearlogo")
+		art.Landscape = rewrite(art.Landscape, "landscape")
+		art.Icon = rewrite(art.Icon, "icon")
+	}
+}
+
 func (li ListItems) Len() int           { return len(li) }
 func (li ListItems) Swap(i, j int)      { li[i], li[j] = li[j], li[i] }
 func (li ListItems) Less(i, j int) bool { return false }
@@ -216,6 +216,20 @@ func ListDialogLarge(title string, subject string, items ...string) int {
 	return retVal
 }
 
+// Browse dialog types
+const (
+	BrowseDirectory = 0
+	BrowseFile      = 1
+)
+
+// BrowseSingle shows Kodi browse dialog, returns selected path,
+// or empty string if the dialog is canceled
+func BrowseSingle(browseType int, title string, shares string) string {
+	retVal := ""
+	executeJSONRPCEx("Dialog_Browse_Single", &retVal, Args{browseType, title, shares})
+	return retVal
+}
+
 // PlayerGetPlayingFile ...
 func PlayerGetPlayingFile() string {
 	retVal := ""